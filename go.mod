@@ -19,6 +19,7 @@ require (
 	github.com/gdamore/tcell/v2 v2.5.3
 	github.com/glendc/go-external-ip v0.1.0
 	github.com/google/uuid v1.3.0
+	github.com/gorilla/websocket v1.5.0
 	github.com/hashicorp/go-version v1.6.0
 	github.com/herumi/bls-eth-go-binary v1.28.1 // indirect
 	github.com/imdario/mergo v0.3.13
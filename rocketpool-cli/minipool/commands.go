@@ -32,6 +32,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "list",
+				Usage:     "List the node's minipools with their status and validator pubkey",
+				UsageText: "rocketpool minipool list [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "with-index",
+						Usage: "Include the index of each minipool's validator on the Beacon chain",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return listMinipools(c, c.Bool("with-index"))
+
+				},
+			},
+
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
@@ -0,0 +1,81 @@
+package minipool
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// A row in the minipool list
+type minipoolListRow struct {
+	Address         string
+	ValidatorPubkey string
+	Status          string
+	ValidatorIndex  string
+}
+
+// Get the rows of the minipool list, joining each minipool with the index of its validator on the Beacon chain.
+// Validators the Beacon chain doesn't know about yet have no index.
+func getMinipoolListRows(minipools []api.MinipoolDetails) []minipoolListRow {
+	rows := make([]minipoolListRow, 0, len(minipools))
+	for _, minipool := range minipools {
+		status := minipool.Status.Status.String()
+		if minipool.Finalised {
+			status = "Finalised"
+		}
+		index := "not on the Beacon chain yet"
+		if minipool.Validator.Exists {
+			index = strconv.FormatUint(minipool.Validator.Index, 10)
+		}
+		rows = append(rows, minipoolListRow{
+			Address:         minipool.Address.Hex(),
+			ValidatorPubkey: hex.AddPrefix(minipool.ValidatorPubkey.Hex()),
+			Status:          status,
+			ValidatorIndex:  index,
+		})
+	}
+	return rows
+}
+
+func listMinipools(c *cli.Context, withIndex bool) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get minipool statuses
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+	if len(status.Minipools) == 0 {
+		fmt.Println("The node does not have any minipools yet.")
+		return nil
+	}
+
+	// Print the list
+	for _, row := range getMinipoolListRows(status.Minipools) {
+		if withIndex {
+			fmt.Printf("%s  %-12s  %s  %s\n", row.Address, row.Status, row.ValidatorPubkey, row.ValidatorIndex)
+		} else {
+			fmt.Printf("%s  %-12s  %s\n", row.Address, row.Status, row.ValidatorPubkey)
+		}
+	}
+	return nil
+
+}
@@ -0,0 +1,45 @@
+package minipool
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func TestGetMinipoolListRows(t *testing.T) {
+	staking := api.MinipoolDetails{}
+	staking.Status.Status = types.Staking
+	staking.Validator.Exists = true
+	staking.Validator.Index = 12345
+
+	prelaunch := api.MinipoolDetails{}
+	prelaunch.Status.Status = types.Prelaunch
+
+	finalised := api.MinipoolDetails{Finalised: true}
+	finalised.Status.Status = types.Withdrawable
+	finalised.Validator.Exists = true
+
+	tests := []struct {
+		name       string
+		minipool   api.MinipoolDetails
+		wantStatus string
+		wantIndex  string
+	}{
+		{"staking", staking, types.Staking.String(), "12345"},
+		{"not on the Beacon chain", prelaunch, types.Prelaunch.String(), "not on the Beacon chain yet"},
+		{"finalised", finalised, "Finalised", "0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows := getMinipoolListRows([]api.MinipoolDetails{test.minipool})
+			if len(rows) != 1 {
+				t.Fatalf("getMinipoolListRows() returned %d rows, want 1", len(rows))
+			}
+			if rows[0].Status != test.wantStatus || rows[0].ValidatorIndex != test.wantIndex {
+				t.Errorf("getMinipoolListRows() = %+v, want status %q and index %q", rows[0], test.wantStatus, test.wantIndex)
+			}
+		})
+	}
+}
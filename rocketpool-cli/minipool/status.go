@@ -173,7 +173,9 @@ func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Ad
 			} else {
 				fmt.Printf("Validator active:     no\n")
 			}
+			fmt.Printf("Beacon status:        %s\n", minipool.Validator.BeaconStatus)
 			fmt.Printf("Validator balance:    %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Validator.Balance), 6))
+			fmt.Printf("Effective balance:    %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Validator.EffectiveBalance), 6))
 			fmt.Printf("Expected rewards:     %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Validator.NodeBalance), 6))
 		} else {
 			fmt.Printf("Validator seen:       no\n")
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/urfave/cli"
 )
@@ -39,6 +40,15 @@ func getActiveDAOProposals(c *cli.Context) error {
 
 	// Voting status
 	fmt.Printf("%s=== DAO Voting ===%s\n", colorGreen, colorReset)
+	if proposalsResponse.ProposalsError != "" {
+		fmt.Printf("%sWARNING: Unable to fetch the active proposals from snapshot.org: %s%s\n", colorYellow, proposalsResponse.ProposalsError, colorReset)
+	}
+	if proposalsResponse.VotingDelegateError != "" {
+		fmt.Printf("%sWARNING: Unable to fetch the node's voting delegate: %s%s\n", colorYellow, proposalsResponse.VotingDelegateError, colorReset)
+	}
+	if proposalsResponse.ProposalVotesError != "" {
+		fmt.Printf("%sWARNING: Unable to fetch the node's votes from snapshot.org: %s%s\n", colorYellow, proposalsResponse.ProposalVotesError, colorReset)
+	}
 	blankAddress := common.Address{}
 	if proposalsResponse.VotingDelegate == blankAddress {
 		fmt.Println("The node does not currently have a voting delegate set, and will not be able to vote on Rocket Pool governance proposals.")
@@ -46,19 +56,13 @@ func getActiveDAOProposals(c *cli.Context) error {
 		fmt.Printf("The node has a voting delegate of %s%s%s which can represent it when voting on Rocket Pool governance proposals.\n", colorBlue, proposalsResponse.VotingDelegate.Hex(), colorReset)
 	}
 
-	voteCount := 0
-	for _, activeProposal := range proposalsResponse.ActiveSnapshotProposals {
-		for _, votedProposal := range proposalsResponse.ProposalVotes {
-			if votedProposal.Proposal.Id == activeProposal.Id {
-				voteCount++
-				break
-			}
-		}
-	}
+	voteCount := countActiveProposalVotes(proposalsResponse.ActiveSnapshotProposals, proposalsResponse.ProposalVotes)
+	nodeVoteCount := countActiveProposalVotes(proposalsResponse.ActiveSnapshotProposals, proposalsResponse.NodeProposalVotes)
+	delegateVoteCount := countActiveProposalVotes(proposalsResponse.ActiveSnapshotProposals, proposalsResponse.DelegateProposalVotes)
 	if len(proposalsResponse.ActiveSnapshotProposals) == 0 {
 		fmt.Print("Rocket Pool has no governance proposals being voted on.\n")
 	} else {
-		fmt.Printf("Rocket Pool has %d governance proposal(s) being voted on. You have voted on %d of those.\n", len(proposalsResponse.ActiveSnapshotProposals), voteCount)
+		fmt.Printf("Rocket Pool has %d governance proposal(s) being voted on. You have voted on %d of those (%d directly, %d through your delegate).\n", len(proposalsResponse.ActiveSnapshotProposals), voteCount, nodeVoteCount, delegateVoteCount)
 	}
 
 	for _, proposal := range proposalsResponse.ActiveSnapshotProposals {
@@ -74,7 +78,7 @@ func getActiveDAOProposals(c *cli.Context) error {
 			}
 			fmt.Printf("Scores: %s\n", scoresBuilder.String())
 			quorumResult := ""
-			if proposal.ScoresTotal > float64(proposal.Quorum) {
+			if proposal.QuorumReached {
 				quorumResult += "✓"
 			}
 			fmt.Printf("Quorum: %.2f of %d needed %s\n", proposal.ScoresTotal, proposal.Quorum, quorumResult)
@@ -138,3 +142,17 @@ func getActiveDAOProposals(c *cli.Context) error {
 	fmt.Println("")
 	return nil
 }
+
+// Count the active proposals with at least one of the given votes
+func countActiveProposalVotes(activeProposals []api.SnapshotProposal, votes []api.SnapshotProposalVote) int {
+	voteCount := 0
+	for _, activeProposal := range activeProposals {
+		for _, votedProposal := range votes {
+			if votedProposal.Proposal.Id == activeProposal.Id {
+				voteCount++
+				break
+			}
+		}
+	}
+	return voteCount
+}
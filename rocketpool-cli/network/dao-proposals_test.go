@@ -0,0 +1,35 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Create a vote on the proposal with the given ID
+func newTestSnapshotVote(proposalId string) api.SnapshotProposalVote {
+	vote := api.SnapshotProposalVote{}
+	vote.Proposal.Id = proposalId
+	return vote
+}
+
+func TestCountActiveProposalVotes(t *testing.T) {
+	activeProposals := []api.SnapshotProposal{{Id: "a"}, {Id: "b"}, {Id: "c"}}
+	tests := []struct {
+		name  string
+		votes []api.SnapshotProposalVote
+		want  int
+	}{
+		{"no votes", nil, 0},
+		{"votes on inactive proposals", []api.SnapshotProposalVote{newTestSnapshotVote("x")}, 0},
+		{"node and delegate vote on the same proposal", []api.SnapshotProposalVote{newTestSnapshotVote("a"), newTestSnapshotVote("a")}, 1},
+		{"votes on several proposals", []api.SnapshotProposalVote{newTestSnapshotVote("a"), newTestSnapshotVote("c"), newTestSnapshotVote("x")}, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := countActiveProposalVotes(activeProposals, test.votes); got != test.want {
+				t.Errorf("countActiveProposalVotes() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
@@ -32,6 +32,41 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "stake-breakdown",
+				Aliases:   []string{"sb"},
+				Usage:     "Show a breakdown of the node's RPL stake and collateral",
+				UsageText: "rocketpool node stake-breakdown",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getStakeBreakdown(c)
+
+				},
+			},
+
+			{
+				Name:      "queue-summary",
+				Usage:     "Show how many of the node's minipools are waiting in the deposit queue, in prelaunch and staking",
+				UsageText: "rocketpool node queue-summary",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getQueueSummary(c)
+
+				},
+			},
+
 			{
 				Name:      "sync",
 				Aliases:   []string{"y"},
@@ -0,0 +1,42 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getQueueSummary(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the queue summary
+	summary, err := rp.NodeQueueSummary()
+	if err != nil {
+		return err
+	}
+
+	// Print the summary
+	fmt.Printf("%s=== Minipools ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Waiting in the deposit queue: %d\n", summary.Queued)
+	fmt.Printf("Assigned, in prelaunch:       %d\n", summary.Prelaunch)
+	fmt.Printf("Staking:                      %d\n", summary.Staking)
+
+	// Return
+	return nil
+
+}
@@ -0,0 +1,70 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getStakeBreakdown(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the stake breakdown
+	breakdown, err := rp.NodeStakeBreakdown()
+	if err != nil {
+		return err
+	}
+
+	// Print the stake
+	fmt.Printf("%s=== RPL Stake ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Staked RPL:          %.6f RPL\n", math.RoundDown(eth.WeiToEth(breakdown.RplStake), 6))
+	fmt.Printf("Effective RPL stake: %.6f RPL\n", math.RoundDown(eth.WeiToEth(breakdown.EffectiveRplStake), 6))
+	fmt.Printf("Minimum RPL stake:   %.6f RPL\n", math.RoundDown(eth.WeiToEth(breakdown.MinimumRplStake), 6))
+	fmt.Printf("Maximum RPL stake:   %.6f RPL\n", math.RoundDown(eth.WeiToEth(breakdown.MaximumRplStake), 6))
+	fmt.Println()
+
+	// Print the collateral
+	fmt.Printf("%s=== Collateral ===%s\n", colorGreen, colorReset)
+	fmt.Printf("RPL price:           %.6f ETH\n", math.RoundDown(eth.WeiToEth(breakdown.RplPrice), 6))
+	fmt.Printf("Active minipools:    %d\n", breakdown.ActiveMinipools)
+	if breakdown.CollateralRatio >= 0 {
+		fmt.Printf("Collateral ratio:    %.2f%% of borrowed ETH\n", breakdown.CollateralRatio*100)
+	} else {
+		fmt.Println("Collateral ratio:    n/a (no active minipools)")
+	}
+	fmt.Println()
+
+	// Print the headroom
+	fmt.Printf("%s=== Headroom ===%s\n", colorGreen, colorReset)
+	if breakdown.RewardableStakeRemaining.Sign() > 0 {
+		fmt.Printf("You can stake %.6f more RPL before reaching the maximum that earns rewards.\n", math.RoundDown(eth.WeiToEth(breakdown.RewardableStakeRemaining), 6))
+	} else {
+		fmt.Printf("%sYour stake is at or above the maximum that earns rewards; additional RPL will not earn more.%s\n", colorYellow, colorReset)
+	}
+	if breakdown.WithdrawableRpl.Sign() > 0 {
+		fmt.Printf("You can withdraw up to %.6f RPL while keeping the minimum stake (subject to the withdrawal cooldown).\n", math.RoundDown(eth.WeiToEth(breakdown.WithdrawableRpl), 6))
+	} else {
+		fmt.Printf("%sYour stake is at or below the minimum; no RPL can be withdrawn.%s\n", colorYellow, colorReset)
+	}
+
+	// Return
+	return nil
+
+}
@@ -34,6 +34,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "queue-position",
+				Usage:     "Get the position of a minipool in the deposit queue",
+				UsageText: "rocketpool api minipool queue-position minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolQueuePosition(c, minipoolAddress))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-stake",
 				Usage:     "Check whether the minipool is ready to be staked, moving from prelaunch to staking status",
@@ -78,6 +99,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "refund-info",
+				Usage:     "Get the refundable ETH and finalization status of the node's minipools",
+				UsageText: "rocketpool api minipool refund-info",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolRefundInfo(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-refund",
 				Usage:     "Check whether the node can refund ETH from the minipool",
@@ -166,6 +205,28 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "exit-readiness",
+				Usage:     "Check whether the minipool's validator is eligible to be voluntarily exited on the beacon chain",
+				UsageText: "rocketpool api minipool exit-readiness minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolExitReadiness(c, minipoolAddress))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-exit",
 				Usage:     "Check whether the minipool can be exited from the beacon chain",
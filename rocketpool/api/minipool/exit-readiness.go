@@ -0,0 +1,105 @@
+package minipool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of epochs a validator has to be active for before it can be voluntarily exited (SHARD_COMMITTEE_PERIOD)
+const shardCommitteePeriod uint64 = 256
+
+func getMinipoolExitReadiness(c *cli.Context, minipoolAddress common.Address) (*api.MinipoolExitReadinessResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Get the validator status
+	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	status, err := bc.GetValidatorStatus(validatorPubkey, nil)
+	if err != nil {
+		return nil, err
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	response := getExitReadiness(status, head.Epoch)
+	return &response, nil
+
+}
+
+// Check whether a validator can be voluntarily exited at the given epoch
+func getExitReadiness(status beacon.ValidatorStatus, currentEpoch uint64) api.MinipoolExitReadinessResponse {
+
+	response := api.MinipoolExitReadinessResponse{
+		CurrentEpoch: currentEpoch,
+	}
+
+	// Check the validator exists
+	if !status.Exists {
+		response.ValidatorMissing = true
+		return response
+	}
+	response.ValidatorStatus = string(status.Status)
+	response.ActivationEpoch = status.ActivationEpoch
+
+	// Check the validator's state
+	switch status.Status {
+	case beacon.ValidatorState_PendingInitialized, beacon.ValidatorState_PendingQueued:
+		response.InActivationQueue = true
+		return response
+	case beacon.ValidatorState_ActiveOngoing:
+	default:
+		response.AlreadyExiting = true
+		return response
+	}
+
+	// Check the validator has been active for long enough
+	response.ExitableEpoch = status.ActivationEpoch + shardCommitteePeriod
+	response.TooRecentlyActivated = (currentEpoch < response.ExitableEpoch)
+	response.CanExit = !response.TooRecentlyActivated
+	return response
+
+}
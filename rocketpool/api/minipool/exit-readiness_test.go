@@ -0,0 +1,56 @@
+package minipool
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func TestGetExitReadiness(t *testing.T) {
+	active := beacon.ValidatorStatus{Exists: true, Status: beacon.ValidatorState_ActiveOngoing, ActivationEpoch: 1000}
+	tests := []struct {
+		name         string
+		status       beacon.ValidatorStatus
+		currentEpoch uint64
+		want         api.MinipoolExitReadinessResponse
+	}{
+		{
+			name:         "missing validator",
+			status:       beacon.ValidatorStatus{},
+			currentEpoch: 2000,
+			want:         api.MinipoolExitReadinessResponse{CurrentEpoch: 2000, ValidatorMissing: true},
+		},
+		{
+			name:         "in the activation queue",
+			status:       beacon.ValidatorStatus{Exists: true, Status: beacon.ValidatorState_PendingQueued},
+			currentEpoch: 2000,
+			want:         api.MinipoolExitReadinessResponse{CurrentEpoch: 2000, InActivationQueue: true, ValidatorStatus: string(beacon.ValidatorState_PendingQueued)},
+		},
+		{
+			name:         "already exiting",
+			status:       beacon.ValidatorStatus{Exists: true, Status: beacon.ValidatorState_ActiveExiting, ActivationEpoch: 1000},
+			currentEpoch: 2000,
+			want:         api.MinipoolExitReadinessResponse{CurrentEpoch: 2000, AlreadyExiting: true, ValidatorStatus: string(beacon.ValidatorState_ActiveExiting), ActivationEpoch: 1000},
+		},
+		{
+			name:         "too recently activated",
+			status:       active,
+			currentEpoch: 1255,
+			want:         api.MinipoolExitReadinessResponse{CurrentEpoch: 1255, TooRecentlyActivated: true, ValidatorStatus: string(active.Status), ActivationEpoch: 1000, ExitableEpoch: 1256},
+		},
+		{
+			name:         "exitable",
+			status:       active,
+			currentEpoch: 1256,
+			want:         api.MinipoolExitReadinessResponse{CurrentEpoch: 1256, CanExit: true, ValidatorStatus: string(active.Status), ActivationEpoch: 1000, ExitableEpoch: 1256},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getExitReadiness(test.status, test.currentEpoch); got != test.want {
+				t.Errorf("getExitReadiness() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
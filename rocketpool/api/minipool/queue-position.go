@@ -0,0 +1,108 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The storage keys of the deposit queues, by deposit type
+var minipoolQueueKeys = map[rptypes.MinipoolDeposit]string{
+	rptypes.Half:  "minipools.available.half",
+	rptypes.Full:  "minipools.available.full",
+	rptypes.Empty: "minipools.available.empty",
+}
+
+func getMinipoolQueuePosition(c *cli.Context, minipoolAddress common.Address) (*api.MinipoolQueuePositionResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolQueuePositionResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the deposit type
+	depositType, err := mp.GetDepositType(nil)
+	if err != nil {
+		return nil, err
+	}
+	queueKey, exists := minipoolQueueKeys[depositType]
+	if !exists {
+		return &response, nil
+	}
+	response.Queue = depositType.String()
+
+	// Data
+	var wg errgroup.Group
+	var index int64
+	var lengths minipool.QueueLengths
+
+	// Get data
+	wg.Go(func() error {
+		var err error
+		index, err = storage.GetAddressQueueIndexOf(rp, nil, crypto.Keccak256Hash([]byte(queueKey)), minipoolAddress)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		lengths, err = minipool.GetQueueLengths(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Check if the minipool is queued
+	if index < 0 {
+		return &response, nil
+	}
+	response.InQueue = true
+	response.Position = uint64(index)
+	response.OverallPosition, err = getOverallQueuePosition(depositType, uint64(index), lengths)
+	if err != nil {
+		return nil, err
+	}
+	response.TotalLength = lengths.Total
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get a minipool's position across all of the queues from its position in its own queue.
+// The half deposit queue is assigned first, then the full deposit queue, then the empty deposit queue.
+func getOverallQueuePosition(depositType rptypes.MinipoolDeposit, position uint64, lengths minipool.QueueLengths) (uint64, error) {
+	switch depositType {
+	case rptypes.Half:
+		return position, nil
+	case rptypes.Full:
+		return lengths.HalfDeposit + position, nil
+	case rptypes.Empty:
+		return lengths.HalfDeposit + lengths.FullDeposit + position, nil
+	default:
+		return 0, fmt.Errorf("minipools with deposit type %s are not queued", depositType.String())
+	}
+}
@@ -0,0 +1,40 @@
+package minipool
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestGetOverallQueuePosition(t *testing.T) {
+	lengths := minipool.QueueLengths{
+		Total:        17,
+		HalfDeposit:  5,
+		FullDeposit:  3,
+		EmptyDeposit: 9,
+	}
+	tests := []struct {
+		name        string
+		depositType rptypes.MinipoolDeposit
+		position    uint64
+		want        uint64
+		wantErr     bool
+	}{
+		{name: "half deposit queue is first", depositType: rptypes.Half, position: 2, want: 2},
+		{name: "full deposit queue is after the half queue", depositType: rptypes.Full, position: 2, want: 7},
+		{name: "empty deposit queue is last", depositType: rptypes.Empty, position: 2, want: 10},
+		{name: "unqueued deposit type", depositType: rptypes.None, position: 2, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := getOverallQueuePosition(test.depositType, test.position, lengths)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("getOverallQueuePosition() error = %v, want error: %t", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("getOverallQueuePosition() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
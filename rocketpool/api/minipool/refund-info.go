@@ -0,0 +1,122 @@
+package minipool
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getMinipoolRefundInfo(c *cli.Context) (*api.MinipoolRefundInfoResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolRefundInfoResponse{}
+
+	// Get minipool addresses
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the refund info for each minipool
+	infos := make([]api.MinipoolRefundInfo, len(addresses))
+	var wg errgroup.Group
+	for i, address := range addresses {
+		i, address := i, address
+		wg.Go(func() error {
+			info, err := getRefundInfo(rp, address)
+			if err != nil {
+				return err
+			}
+			infos[i] = info
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	response.Minipools = infos
+	response.TotalRefundable = getTotalRefundable(infos)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get a minipool's refund and finalization details
+func getRefundInfo(rp *rocketpool.RocketPool, minipoolAddress common.Address) (api.MinipoolRefundInfo, error) {
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return api.MinipoolRefundInfo{}, err
+	}
+
+	// Data
+	var wg errgroup.Group
+	info := api.MinipoolRefundInfo{Address: minipoolAddress}
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		info.Status, err = mp.GetStatus(nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		info.RefundBalance, err = mp.GetNodeRefundBalance(nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		info.Finalised, err = mp.GetFinalised(nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return api.MinipoolRefundInfo{}, err
+	}
+
+	// Update & return
+	info.RefundAvailable = (info.RefundBalance.Sign() > 0)
+	info.FinaliseAvailable = (info.Status == types.Withdrawable && !info.Finalised)
+	info.CloseAvailable = (info.Status == types.Dissolved)
+	return info, nil
+
+}
+
+// Get the total ETH that can be refunded across a set of minipools
+func getTotalRefundable(infos []api.MinipoolRefundInfo) *big.Int {
+	total := big.NewInt(0)
+	for _, info := range infos {
+		if info.RefundAvailable {
+			total.Add(total, info.RefundBalance)
+		}
+	}
+	return total
+}
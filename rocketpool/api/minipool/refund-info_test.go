@@ -0,0 +1,33 @@
+package minipool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func TestGetTotalRefundable(t *testing.T) {
+	tests := []struct {
+		name  string
+		infos []api.MinipoolRefundInfo
+		want  int64
+	}{
+		{"no minipools", nil, 0},
+		{"nothing refundable", []api.MinipoolRefundInfo{
+			{RefundBalance: big.NewInt(0), RefundAvailable: false},
+		}, 0},
+		{"mixed minipools", []api.MinipoolRefundInfo{
+			{RefundBalance: big.NewInt(100), RefundAvailable: true},
+			{RefundBalance: big.NewInt(50), RefundAvailable: false},
+			{RefundBalance: big.NewInt(25), RefundAvailable: true},
+		}, 125},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getTotalRefundable(test.infos); got.Cmp(big.NewInt(test.want)) != 0 {
+				t.Errorf("getTotalRefundable() = %s, want %d", got, test.want)
+			}
+		})
+	}
+}
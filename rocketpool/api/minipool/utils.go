@@ -273,17 +273,8 @@ func getMinipoolValidatorDetails(rp *rocketpool.RocketPool, minipoolDetails api.
 		return api.ValidatorDetails{}, err
 	}
 
-	// Validator details
-	details := api.ValidatorDetails{}
-
 	// Set validator status details
-	validatorActivated := false
-	if validator.Exists {
-		details.Exists = true
-		details.Active = (validator.ActivationEpoch < currentEpoch && validator.ExitEpoch > currentEpoch)
-		details.Index = validator.Index
-		validatorActivated = (validator.ActivationEpoch < currentEpoch)
-	}
+	details, validatorActivated := getValidatorStatusDetails(validator, currentEpoch)
 
 	// use deposit balances if validator not activated
 	if !validatorActivated {
@@ -317,3 +308,23 @@ func getMinipoolValidatorDetails(rp *rocketpool.RocketPool, minipoolDetails api.
 	return details, nil
 
 }
+
+// Get the status details of a minipool's validator, and whether it has been activated
+func getValidatorStatusDetails(validator beacon.ValidatorStatus, currentEpoch uint64) (api.ValidatorDetails, bool) {
+
+	// Validators that aren't on the beacon chain yet have no effective balance
+	details := api.ValidatorDetails{
+		EffectiveBalance: big.NewInt(0),
+	}
+	if !validator.Exists {
+		return details, false
+	}
+
+	details.Exists = true
+	details.Active = (validator.ActivationEpoch < currentEpoch && validator.ExitEpoch > currentEpoch)
+	details.Index = validator.Index
+	details.BeaconStatus = string(validator.Status)
+	details.EffectiveBalance = eth.GweiToWei(float64(validator.EffectiveBalance))
+	return details, (validator.ActivationEpoch < currentEpoch)
+
+}
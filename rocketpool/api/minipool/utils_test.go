@@ -0,0 +1,83 @@
+package minipool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+func TestGetValidatorStatusDetails(t *testing.T) {
+	tests := []struct {
+		name                 string
+		validator            beacon.ValidatorStatus
+		wantExists           bool
+		wantActive           bool
+		wantActivated        bool
+		wantBeaconStatus     string
+		wantEffectiveBalance *big.Int
+	}{
+		{
+			name:                 "not on the beacon chain",
+			validator:            beacon.ValidatorStatus{},
+			wantEffectiveBalance: big.NewInt(0),
+		},
+		{
+			name: "pending",
+			validator: beacon.ValidatorStatus{
+				Exists:           true,
+				Status:           beacon.ValidatorState_PendingQueued,
+				EffectiveBalance: 32e9,
+				ActivationEpoch:  ^uint64(0),
+				ExitEpoch:        ^uint64(0),
+			},
+			wantExists:           true,
+			wantBeaconStatus:     "pending_queued",
+			wantEffectiveBalance: eth.EthToWei(32),
+		},
+		{
+			name: "active",
+			validator: beacon.ValidatorStatus{
+				Exists:           true,
+				Status:           beacon.ValidatorState_ActiveOngoing,
+				EffectiveBalance: 31e9,
+				ActivationEpoch:  50,
+				ExitEpoch:        ^uint64(0),
+			},
+			wantExists:           true,
+			wantActive:           true,
+			wantActivated:        true,
+			wantBeaconStatus:     "active_ongoing",
+			wantEffectiveBalance: eth.EthToWei(31),
+		},
+		{
+			name: "exited",
+			validator: beacon.ValidatorStatus{
+				Exists:          true,
+				Status:          beacon.ValidatorState_ExitedUnslashed,
+				ActivationEpoch: 50,
+				ExitEpoch:       80,
+			},
+			wantExists:           true,
+			wantActivated:        true,
+			wantBeaconStatus:     "exited_unslashed",
+			wantEffectiveBalance: big.NewInt(0),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			details, activated := getValidatorStatusDetails(test.validator, 100)
+			if details.Exists != test.wantExists || details.Active != test.wantActive || activated != test.wantActivated {
+				t.Errorf("exists, active, activated = %t, %t, %t, want %t, %t, %t", details.Exists, details.Active, activated, test.wantExists, test.wantActive, test.wantActivated)
+			}
+			if details.BeaconStatus != test.wantBeaconStatus {
+				t.Errorf("BeaconStatus = %q, want %q", details.BeaconStatus, test.wantBeaconStatus)
+			}
+			if details.EffectiveBalance == nil || details.EffectiveBalance.Cmp(test.wantEffectiveBalance) != 0 {
+				t.Errorf("EffectiveBalance = %v, want %s", details.EffectiveBalance, test.wantEffectiveBalance)
+			}
+		})
+	}
+}
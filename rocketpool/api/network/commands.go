@@ -53,6 +53,60 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "next-checkpoint",
+				Usage:     "Get the next reportable prices and balances blocks and the estimated time until them",
+				UsageText: "rocketpool api network next-checkpoint",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNextCheckpointEstimate(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "deposit-pool-info",
+				Usage:     "Get the deposit pool balance and capacity, and how many queued deposits it could assign",
+				UsageText: "rocketpool api network deposit-pool-info",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDepositPoolInfo(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "reth-info",
+				Usage:     "Get the rETH supply, collateral and exchange rate",
+				UsageText: "rocketpool api network reth-info",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRethInfo(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "stats",
 				Aliases:   []string{"s"},
@@ -155,6 +209,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "onchain-dao-proposals",
+				Usage:     "Get the pending and active on-chain protocol DAO proposals",
+				UsageText: "rocketpool api network onchain-dao-proposals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getOnchainDAOProposals(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -1,10 +1,14 @@
 package network
 
 import (
+	"fmt"
+
 	"github.com/rocket-pool/smartnode/rocketpool/api/node"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
 )
 
 func getActiveDAOProposals(c *cli.Context) (*api.NetworkDAOProposalsResponse, error) {
@@ -31,26 +35,84 @@ func getActiveDAOProposals(c *cli.Context) (*api.NetworkDAOProposalsResponse, er
 	response := api.NetworkDAOProposalsResponse{}
 	response.AccountAddress = nodeAccount.Address
 
-	// Get snapshot proposals
-	snapshotResponse, err := node.GetSnapshotProposals(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), "active")
-	if err != nil {
+	// Get the sections concurrently; a section that fails records its error in the response instead of failing the
+	// whole request, so the others can still be shown
+	var wg errgroup.Group
+
+	// Get snapshot proposals, checking quorum and refreshing the scores first if enabled
+	wg.Go(func() error {
+		proposals, err := getActiveSnapshotProposals(cfg)
+		if err != nil {
+			response.ProposalsError = err.Error()
+			return nil
+		}
+		response.ActiveSnapshotProposals = proposals
+		return nil
+	})
+
+	// Get the delegate address, then the proposals voted on by the node and its delegate
+	wg.Go(func() error {
+		idHash := cfg.Smartnode.GetVotingSnapshotID()
+		delegate, err := s.Delegation(nil, nodeAccount.Address, idHash)
+		if err != nil {
+			response.VotingDelegateError = err.Error()
+		} else {
+			response.VotingDelegate = delegate
+		}
+
+		votedProposals, err := node.GetSnapshotVotedProposals(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), nodeAccount.Address, response.VotingDelegate)
+		if err != nil {
+			response.ProposalVotesError = err.Error()
+			return nil
+		}
+		response.ProposalVotes = votedProposals.Data.Votes
+		response.NodeProposalVotes, response.DelegateProposalVotes = node.SplitSnapshotVotes(votedProposals.Data.Votes, nodeAccount.Address)
+		return nil
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
 		return nil, err
 	}
 
-	// Get delegate address
-	idHash := cfg.Smartnode.GetVotingSnapshotID()
-	response.VotingDelegate, err = s.Delegation(nil, nodeAccount.Address, idHash)
-	if err != nil {
+	// Only fail if nothing could be loaded
+	if err := checkDAOProposalSections(&response); err != nil {
 		return nil, err
 	}
+	return &response, nil
+}
+
+// Get an error if none of the DAO proposal sections could be loaded
+func checkDAOProposalSections(response *api.NetworkDAOProposalsResponse) error {
+	if response.ProposalsError != "" && response.VotingDelegateError != "" && response.ProposalVotesError != "" {
+		return fmt.Errorf("Error getting DAO proposals: %s", response.ProposalsError)
+	}
+	return nil
+}
+
+// Get the active Snapshot proposals, with their quorum status
+func getActiveSnapshotProposals(cfg *config.RocketPoolConfig) ([]api.SnapshotProposal, error) {
 
-	// Get voted proposals
-	votedProposals, err := node.GetSnapshotVotedProposals(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), nodeAccount.Address, response.VotingDelegate)
+	snapshotResponse, err := node.GetSnapshotProposals(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), "active")
 	if err != nil {
 		return nil, err
 	}
-	response.ProposalVotes = votedProposals.Data.Votes
 
-	response.ActiveSnapshotProposals = snapshotResponse.Data.Proposals
-	return &response, nil
+	// Check quorum, refreshing the scores first if enabled
+	for i := range snapshotResponse.Data.Proposals {
+		proposal := &snapshotResponse.Data.Proposals[i]
+		if cfg.Smartnode.RefreshSnapshotScores.Value == true {
+			scoresResponse, err := node.GetSnapshotProposalScores(cfg.Smartnode.GetSnapshotApiDomain(), proposal.Id)
+			if err != nil {
+				return nil, fmt.Errorf("Error getting scores for proposal %s: %w", proposal.Id, err)
+			}
+			proposal.Scores = scoresResponse.Data.Proposal.Scores
+			proposal.ScoresTotal = scoresResponse.Data.Proposal.ScoresTotal
+			proposal.ScoresUpdated = scoresResponse.Data.Proposal.ScoresUpdated
+			proposal.Quorum = scoresResponse.Data.Proposal.Quorum
+		}
+		proposal.QuorumReached = node.IsQuorumReached(*proposal)
+	}
+	return snapshotResponse.Data.Proposals, nil
+
 }
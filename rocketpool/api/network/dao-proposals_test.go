@@ -0,0 +1,28 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func TestCheckDAOProposalSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		response api.NetworkDAOProposalsResponse
+		wantErr  bool
+	}{
+		{"all sections loaded", api.NetworkDAOProposalsResponse{}, false},
+		{"proposals failed", api.NetworkDAOProposalsResponse{ProposalsError: "timeout"}, false},
+		{"votes failed", api.NetworkDAOProposalsResponse{VotingDelegateError: "no delegate", ProposalVotesError: "timeout"}, false},
+		{"all sections failed", api.NetworkDAOProposalsResponse{ProposalsError: "timeout", VotingDelegateError: "no delegate", ProposalVotesError: "timeout"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkDAOProposalSections(&test.response)
+			if (err != nil) != test.wantErr {
+				t.Errorf("checkDAOProposalSections() = %v, want error %t", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package network
+
+import (
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getDepositPoolInfo(c *cli.Context) (*api.DepositPoolInfoResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DepositPoolInfoResponse{}
+
+	// Data
+	var wg errgroup.Group
+	var halfQueueLength uint64
+	var fullQueueLength uint64
+	var maxAssignments uint64
+
+	// Get the deposit pool balances and capacity
+	wg.Go(func() error {
+		var err error
+		response.Balance, err = deposit.GetBalance(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.ExcessBalance, err = deposit.GetExcessBalance(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MaximumSize, err = protocol.GetMaximumDepositPoolSize(rp, nil)
+		return err
+	})
+
+	// Get the queued deposits and how many can be assigned at once
+	wg.Go(func() error {
+		var err error
+		halfQueueLength, err = minipool.GetQueueLength(rp, types.Half, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		fullQueueLength, err = minipool.GetQueueLength(rp, types.Full, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		maxAssignments, err = protocol.GetMaximumDepositAssignments(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Work out how many of the queued deposits the excess balance could assign
+	response.HalfDepositsAssignable, response.FullDepositsAssignable = rputils.GetAssignableDeposits(response.ExcessBalance, halfQueueLength, fullQueueLength, maxAssignments)
+
+	// Return response
+	return &response, nil
+
+}
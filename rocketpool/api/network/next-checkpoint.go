@@ -0,0 +1,126 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of recent blocks the average block time is measured over
+const blockTimeSampleSize uint64 = 1000
+
+func getNextCheckpointEstimate(c *cli.Context) (*api.NextCheckpointResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NextCheckpointResponse{}
+
+	// Get the current block
+	currentBlock, err := ec.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("Error getting current block: %w", err)
+	}
+	response.CurrentBlock = currentBlock
+
+	// Data
+	var wg errgroup.Group
+	var pricesFrequency uint64
+	var balancesFrequency uint64
+
+	// Get the submission frequencies
+	wg.Go(func() error {
+		var err error
+		pricesFrequency, err = protocol.GetSubmitPricesFrequency(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		balancesFrequency, err = protocol.GetSubmitBalancesFrequency(rp, nil)
+		return err
+	})
+
+	// Get the average block time
+	wg.Go(func() error {
+		sampleSize := blockTimeSampleSize
+		if sampleSize > currentBlock {
+			sampleSize = currentBlock
+		}
+		if sampleSize == 0 {
+			return nil
+		}
+		latestHeader, err := ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(currentBlock))
+		if err != nil {
+			return fmt.Errorf("Error getting header for block %d: %w", currentBlock, err)
+		}
+		sampleHeader, err := ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(currentBlock-sampleSize))
+		if err != nil {
+			return fmt.Errorf("Error getting header for block %d: %w", currentBlock-sampleSize, err)
+		}
+		response.AverageBlockTime = float64(latestHeader.Time-sampleHeader.Time) / float64(sampleSize)
+		return nil
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the estimates
+	confirmationDepth := cfg.Smartnode.ConfirmationDepth.Value.(uint64)
+	response.PricesBlock, response.PricesSeconds = estimateNextCheckpoint(currentBlock, pricesFrequency, confirmationDepth, response.AverageBlockTime)
+	response.BalancesBlock, response.BalancesSeconds = estimateNextCheckpoint(currentBlock, balancesFrequency, confirmationDepth, response.AverageBlockTime)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the checkpoint after the latest reportable one, and the estimated number of seconds until it becomes reportable.
+// Like the watchtower, a checkpoint is only reportable once it's the confirmation depth behind the current block.
+func estimateNextCheckpoint(currentBlock uint64, frequency uint64, confirmationDepth uint64, averageBlockTime float64) (uint64, uint64) {
+
+	if frequency == 0 {
+		return 0, 0
+	}
+
+	// Get the latest reportable checkpoint
+	var safeHead uint64
+	if currentBlock > confirmationDepth {
+		safeHead = currentBlock - confirmationDepth
+	}
+	nextCheckpoint := safeHead/frequency*frequency + frequency
+
+	// Estimate the time until the safe head reaches it
+	reportableAt := nextCheckpoint + confirmationDepth
+	if reportableAt <= currentBlock {
+		return nextCheckpoint, 0
+	}
+	return nextCheckpoint, uint64(float64(reportableAt-currentBlock) * averageBlockTime)
+
+}
@@ -0,0 +1,31 @@
+package network
+
+import (
+	"testing"
+)
+
+func TestEstimateNextCheckpoint(t *testing.T) {
+	tests := []struct {
+		name              string
+		currentBlock      uint64
+		frequency         uint64
+		confirmationDepth uint64
+		averageBlockTime  float64
+		wantCheckpoint    uint64
+		wantSeconds       uint64
+	}{
+		{"no frequency", 1000, 0, 0, 12, 0, 0},
+		{"no confirmation depth", 1010, 100, 0, 12, 1100, 1080},
+		{"on a checkpoint", 1100, 100, 0, 12, 1200, 1200},
+		{"with confirmation depth", 1110, 100, 20, 12, 1100, 120},
+		{"before the confirmation depth", 10, 100, 20, 12, 100, 1320},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkpoint, seconds := estimateNextCheckpoint(test.currentBlock, test.frequency, test.confirmationDepth, test.averageBlockTime)
+			if checkpoint != test.wantCheckpoint || seconds != test.wantSeconds {
+				t.Errorf("estimateNextCheckpoint() = (%d, %d), want (%d, %d)", checkpoint, seconds, test.wantCheckpoint, test.wantSeconds)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package network
+
+import (
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The name the protocol DAO's proposals are registered under in RocketDAOProposal
+const protocolDAOProposalsName string = "rocketDAOProtocolProposals"
+
+func getOnchainDAOProposals(c *cli.Context) (*api.NetworkOnchainDAOProposalsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkOnchainDAOProposalsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// Get the proposals, with the node's voting status for each
+	proposals, err := dao.GetDAOProposalsWithMember(rp, protocolDAOProposalsName, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.Proposals = getPendingProposals(proposals)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the proposals that are still waiting for or open to votes
+func getPendingProposals(proposals []dao.ProposalDetails) []dao.ProposalDetails {
+	pending := []dao.ProposalDetails{}
+	for _, proposal := range proposals {
+		if proposal.State == types.Pending || proposal.State == types.Active {
+			pending = append(pending, proposal)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,27 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestGetPendingProposals(t *testing.T) {
+	proposals := []dao.ProposalDetails{
+		{ID: 1, State: types.Pending},
+		{ID: 2, State: types.Active},
+		{ID: 3, State: types.Cancelled},
+		{ID: 4, State: types.Defeated},
+		{ID: 5, State: types.Succeeded},
+		{ID: 6, State: types.Expired},
+		{ID: 7, State: types.Executed},
+	}
+	pending := getPendingProposals(proposals)
+	if len(pending) != 2 || pending[0].ID != 1 || pending[1].ID != 2 {
+		t.Errorf("getPendingProposals() returned %+v, want proposals 1 and 2", pending)
+	}
+	if got := getPendingProposals(nil); len(got) != 0 {
+		t.Errorf("getPendingProposals(nil) returned %d proposals", len(got))
+	}
+}
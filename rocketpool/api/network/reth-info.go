@@ -0,0 +1,67 @@
+package network
+
+import (
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getRethInfo(c *cli.Context) (*api.RethInfoResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RethInfoResponse{}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Get data
+	wg.Go(func() error {
+		totalSupply, err := tokens.GetRETHTotalSupply(rp, nil)
+		if err == nil {
+			response.TotalSupply = totalSupply
+		}
+		return err
+	})
+	wg.Go(func() error {
+		totalCollateral, err := tokens.GetRETHTotalCollateral(rp, nil)
+		if err == nil {
+			response.TotalCollateral = totalCollateral
+		}
+		return err
+	})
+	wg.Go(func() error {
+		exchangeRate, err := tokens.GetRETHExchangeRate(rp, nil)
+		if err == nil {
+			response.ExchangeRate = exchangeRate
+		}
+		return err
+	})
+	wg.Go(func() error {
+		collateralRate, err := tokens.GetRETHCollateralRate(rp, nil)
+		if err == nil {
+			response.CollateralRate = collateralRate
+		}
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
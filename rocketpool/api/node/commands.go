@@ -34,6 +34,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "withdrawal-info",
+				Usage:     "Get the node's withdrawal address, any pending change to it, and whether it is the node address",
+				UsageText: "rocketpool api node withdrawal-info",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNodeWithdrawalInfo(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "sync",
 				Aliases:   []string{"y"},
@@ -53,6 +71,42 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "stake-breakdown",
+				Usage:     "Get a breakdown of the node's RPL stake",
+				UsageText: "rocketpool api node stake-breakdown",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNodeStakeBreakdown(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "queue-summary",
+				Usage:     "Get the number of the node's minipools in the deposit queue, in prelaunch and staking",
+				UsageText: "rocketpool api node queue-summary",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNodeQueueSummary(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-register",
 				Usage:     "Check whether the node can be registered with Rocket Pool",
@@ -74,6 +128,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "get-registration-gas-estimate",
+				Usage:     "Estimate the cost of registering the node with Rocket Pool at the current gas fees",
+				UsageText: "rocketpool api node get-registration-gas-estimate timezone-location",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					timezoneLocation, err := cliutils.ValidateTimezoneLocation("timezone location", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRegistrationGasEstimate(c, timezoneLocation))
+					return nil
+
+				},
+			},
 			{
 				Name:      "register",
 				Aliases:   []string{"r"},
@@ -0,0 +1,113 @@
+package node
+
+import (
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Where a minipool is on its way from the deposit queue to staking
+type queueCategory int
+
+const (
+	queueCategoryNone queueCategory = iota
+	queueCategoryQueued
+	queueCategoryPrelaunch
+	queueCategoryStaking
+)
+
+func getNodeQueueSummary(c *cli.Context) (*api.NodeQueueSummaryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeQueueSummaryResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's minipools
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Categorize each minipool by its status and its position in the deposit queue
+	categories := make([]queueCategory, len(addresses))
+	var wg errgroup.Group
+	for i, address := range addresses {
+		i, address := i, address
+		wg.Go(func() error {
+			mp, err := minipool.NewMinipool(rp, address, nil)
+			if err != nil {
+				return err
+			}
+			status, err := mp.GetStatus(nil)
+			if err != nil {
+				return err
+			}
+			position := uint64(0)
+			if status == types.Initialized {
+				position, err = minipool.GetQueuePositionOfMinipool(mp, nil)
+				if err != nil {
+					return err
+				}
+			}
+			categories[i] = getQueueCategory(status, position)
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Count the categories
+	for _, category := range categories {
+		switch category {
+		case queueCategoryQueued:
+			response.Queued++
+		case queueCategoryPrelaunch:
+			response.Prelaunch++
+		case queueCategoryStaking:
+			response.Staking++
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the queue category of a minipool from its status and its 1-indexed position in the deposit queue (0 if it
+// isn't in the queue). Initialized minipools are only counted as queued while they're still in the queue.
+func getQueueCategory(status types.MinipoolStatus, queuePosition uint64) queueCategory {
+	switch status {
+	case types.Initialized:
+		if queuePosition > 0 {
+			return queueCategoryQueued
+		}
+	case types.Prelaunch:
+		return queueCategoryPrelaunch
+	case types.Staking:
+		return queueCategoryStaking
+	}
+	return queueCategoryNone
+}
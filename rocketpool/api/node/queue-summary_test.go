@@ -0,0 +1,30 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestGetQueueCategory(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        types.MinipoolStatus
+		queuePosition uint64
+		want          queueCategory
+	}{
+		{"queued", types.Initialized, 3, queueCategoryQueued},
+		{"initialized without a queue position", types.Initialized, 0, queueCategoryNone},
+		{"prelaunch", types.Prelaunch, 0, queueCategoryPrelaunch},
+		{"staking", types.Staking, 0, queueCategoryStaking},
+		{"withdrawable", types.Withdrawable, 0, queueCategoryNone},
+		{"dissolved", types.Dissolved, 0, queueCategoryNone},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getQueueCategory(test.status, test.queuePosition); got != test.want {
+				t.Errorf("getQueueCategory(%s, %d) = %d, want %d", test.status, test.queuePosition, got, test.want)
+			}
+		})
+	}
+}
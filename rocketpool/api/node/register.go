@@ -1,7 +1,9 @@
 package node
 
 import (
+	"context"
 	"fmt"
+	"math/big"
 
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/settings/protocol"
@@ -84,6 +86,86 @@ func canRegisterNode(c *cli.Context, timezoneLocation string) (*api.CanRegisterN
 
 }
 
+func getRegistrationGasEstimate(c *cli.Context, timezoneLocation string) (*api.RegisterGasEstimateResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RegisterGasEstimateResponse{}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Get gas estimate
+	wg.Go(func() error {
+		opts, err := w.GetNodeAccountTransactor()
+		if err != nil {
+			return err
+		}
+		gasInfo, err := node.EstimateRegisterNodeGas(rp, timezoneLocation, opts)
+		if err == nil {
+			response.GasInfo = gasInfo
+		}
+		return err
+	})
+
+	// Get the current fees
+	wg.Go(func() error {
+		header, err := ec.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return err
+		}
+		if header.BaseFee == nil {
+			return fmt.Errorf("The latest block does not have a base fee.")
+		}
+		response.BaseFee = header.BaseFee
+		return nil
+	})
+	wg.Go(func() error {
+		var err error
+		response.PriorityFee, err = ec.SuggestGasTipCap(context.Background())
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Calculate the cost at the current fees, and the most it could cost if the base fee doubles before it's included
+	response.MaxFee = big.NewInt(0).Mul(response.BaseFee, big.NewInt(2))
+	response.MaxFee.Add(response.MaxFee, response.PriorityFee)
+	response.EstimatedCost = getGasCost(response.GasInfo.EstGasLimit, big.NewInt(0).Add(response.BaseFee, response.PriorityFee))
+	response.MaxCost = getGasCost(response.GasInfo.SafeGasLimit, response.MaxFee)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the cost, in wei, of a transaction using the given amount of gas
+func getGasCost(gas uint64, feePerGas *big.Int) *big.Int {
+	return big.NewInt(0).Mul(big.NewInt(0).SetUint64(gas), feePerGas)
+}
+
 func registerNode(c *cli.Context, timezoneLocation string) (*api.RegisterNodeResponse, error) {
 
 	// Get services
@@ -0,0 +1,28 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGetGasCost(t *testing.T) {
+	gwei := big.NewInt(1e9)
+	tests := []struct {
+		name      string
+		gas       uint64
+		feePerGas *big.Int
+		want      string
+	}{
+		{"no gas", 0, gwei, "0"},
+		{"free gas", 100000, big.NewInt(0), "0"},
+		{"one gwei", 21000, gwei, "21000000000000"},
+		{"large gas limit", 10000000, big.NewInt(0).Mul(gwei, big.NewInt(500)), "5000000000000000000"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getGasCost(test.gas, test.feePerGas); got.String() != test.want {
+				t.Errorf("getGasCost(%d, %s) = %s, want %s", test.gas, test.feePerGas, got, test.want)
+			}
+		})
+	}
+}
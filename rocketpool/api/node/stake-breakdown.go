@@ -0,0 +1,111 @@
+package node
+
+import (
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The amount of ETH borrowed from the protocol for each minipool
+const borrowedEthPerMinipool float64 = 16.0
+
+func getNodeStakeBreakdown(c *cli.Context) (*api.NodeStakeBreakdownResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeStakeBreakdownResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Get the RPL stake details
+	wg.Go(func() error {
+		var err error
+		response.RplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.EffectiveRplStake, err = node.GetNodeEffectiveRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MinimumRplStake, err = node.GetNodeMinimumRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MaximumRplStake, err = node.GetNodeMaximumRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+
+	// Get the values needed for the collateral ratio
+	wg.Go(func() error {
+		var err error
+		response.RplPrice, err = network.GetRPLPrice(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.ActiveMinipools, err = minipool.GetNodeActiveMinipoolCount(rp, nodeAccount.Address, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Calculate the collateral ratio and headroom
+	response.CollateralRatio = getCollateralRatio(response.RplStake, response.RplPrice, response.ActiveMinipools)
+	response.RewardableStakeRemaining = getStakeHeadroom(response.MaximumRplStake, response.RplStake)
+	response.WithdrawableRpl = getStakeHeadroom(response.RplStake, response.MinimumRplStake)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the value of the RPL stake as a fraction of the ETH borrowed by the node's active minipools, or -1 if it has none
+func getCollateralRatio(rplStake *big.Int, rplPrice *big.Int, activeMinipools uint64) float64 {
+	if activeMinipools == 0 {
+		return -1
+	}
+	return eth.WeiToEth(rplPrice) * eth.WeiToEth(rplStake) / (float64(activeMinipools) * borrowedEthPerMinipool)
+}
+
+// Get how far a value is above a limit, or zero if it isn't
+func getStakeHeadroom(value *big.Int, limit *big.Int) *big.Int {
+	headroom := big.NewInt(0).Sub(value, limit)
+	if headroom.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return headroom
+}
@@ -0,0 +1,52 @@
+package node
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+func TestGetCollateralRatio(t *testing.T) {
+	tests := []struct {
+		name            string
+		rplStake        *big.Int
+		rplPrice        *big.Int
+		activeMinipools uint64
+		want            float64
+	}{
+		{"minimum collateral", eth.EthToWei(160), eth.EthToWei(0.01), 1, 0.1},
+		{"maximum collateral", eth.EthToWei(2400), eth.EthToWei(0.01), 1, 1.5},
+		{"several minipools", eth.EthToWei(800), eth.EthToWei(0.02), 4, 0.25},
+		{"no stake", big.NewInt(0), eth.EthToWei(0.01), 2, 0},
+		{"no minipools", eth.EthToWei(1000), eth.EthToWei(0.01), 0, -1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getCollateralRatio(test.rplStake, test.rplPrice, test.activeMinipools); math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("getCollateralRatio() = %f, want %f", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetStakeHeadroom(t *testing.T) {
+	tests := []struct {
+		name  string
+		value *big.Int
+		limit *big.Int
+		want  *big.Int
+	}{
+		{"above the limit", eth.EthToWei(300), eth.EthToWei(160), eth.EthToWei(140)},
+		{"at the limit", eth.EthToWei(160), eth.EthToWei(160), big.NewInt(0)},
+		{"below the limit", eth.EthToWei(100), eth.EthToWei(160), big.NewInt(0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getStakeHeadroom(test.value, test.limit); got.Cmp(test.want) != 0 {
+				t.Errorf("getStakeHeadroom(%s, %s) = %s, want %s", test.value, test.limit, got, test.want)
+			}
+		})
+	}
+}
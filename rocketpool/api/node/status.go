@@ -143,6 +143,7 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 				return nil
 			}
 			r.ProposalVotes = votedProposals.Data.Votes
+			r.NodeProposalVotes, r.DelegateProposalVotes = SplitSnapshotVotes(votedProposals.Data.Votes, nodeAccount.Address)
 		}
 		snapshotResponse, err := GetSnapshotProposals(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), "active")
 		if err != nil {
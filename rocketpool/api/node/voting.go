@@ -318,6 +318,21 @@ func GetSnapshotVotedProposals(apiDomain string, space string, nodeAddress commo
 	return &votedProposals, nil
 }
 
+// Split the votes from GetSnapshotVotedProposals into the ones cast by the node's own address and the ones cast by
+// its delegate
+func SplitSnapshotVotes(votes []api.SnapshotProposalVote, nodeAddress common.Address) ([]api.SnapshotProposalVote, []api.SnapshotProposalVote) {
+	nodeVotes := []api.SnapshotProposalVote{}
+	delegateVotes := []api.SnapshotProposalVote{}
+	for _, vote := range votes {
+		if vote.Voter == nodeAddress {
+			nodeVotes = append(nodeVotes, vote)
+		} else {
+			delegateVotes = append(delegateVotes, vote)
+		}
+	}
+	return nodeVotes, delegateVotes
+}
+
 func GetSnapshotProposals(apiDomain string, space string, state string) (*api.SnapshotResponse, error) {
 	stateFilter := ""
 	if state != "" {
@@ -365,3 +380,46 @@ func GetSnapshotProposals(apiDomain string, space string, state string) (*api.Sn
 
 	return &snapshotResponse, nil
 }
+
+func GetSnapshotProposalScores(apiDomain string, id string) (*api.SnapshotProposalResponse, error) {
+	query := fmt.Sprintf(`query Proposal {
+	proposal(id: "%s") {
+	    id
+		scores
+		scores_total
+		scores_updated
+		quorum
+	  }
+    }`, id)
+
+	url := fmt.Sprintf("https://%s/graphql?operationName=Proposal&query=%s", apiDomain, url.PathEscape(query))
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// Check the response code
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with code %d", resp.StatusCode)
+	}
+
+	// Get response
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var proposalResponse api.SnapshotProposalResponse
+	if err := json.Unmarshal(body, &proposalResponse); err != nil {
+		return nil, fmt.Errorf("Could not decode snapshot response: %w", err)
+	}
+	if proposalResponse.Data.Proposal == nil {
+		return nil, fmt.Errorf("proposal %s was not found", id)
+	}
+
+	return &proposalResponse, nil
+}
+
+// Check if a proposal's total score has met its quorum
+func IsQuorumReached(proposal api.SnapshotProposal) bool {
+	return proposal.ScoresTotal >= float64(proposal.Quorum)
+}
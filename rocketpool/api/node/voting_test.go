@@ -0,0 +1,55 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func TestIsQuorumReached(t *testing.T) {
+	tests := []struct {
+		name        string
+		scoresTotal float64
+		quorum      int64
+		want        bool
+	}{
+		{"no votes", 0, 1000, false},
+		{"below quorum", 999.9, 1000, false},
+		{"at quorum", 1000, 1000, true},
+		{"above quorum", 2500.5, 1000, true},
+		{"no quorum required", 0, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proposal := api.SnapshotProposal{ScoresTotal: test.scoresTotal, Quorum: test.quorum}
+			if got := IsQuorumReached(proposal); got != test.want {
+				t.Errorf("IsQuorumReached(%g of %d) = %t, want %t", test.scoresTotal, test.quorum, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSplitSnapshotVotes(t *testing.T) {
+	nodeAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	delegateAddress := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	votes := []api.SnapshotProposalVote{
+		{Voter: nodeAddress},
+		{Voter: delegateAddress},
+		{Voter: delegateAddress},
+	}
+
+	nodeVotes, delegateVotes := SplitSnapshotVotes(votes, nodeAddress)
+	if len(nodeVotes) != 1 || nodeVotes[0].Voter != nodeAddress {
+		t.Errorf("SplitSnapshotVotes() returned node votes %+v, want only the node's vote", nodeVotes)
+	}
+	if len(delegateVotes) != 2 {
+		t.Errorf("SplitSnapshotVotes() returned %d delegate votes, want 2", len(delegateVotes))
+	}
+	for _, vote := range delegateVotes {
+		if vote.Voter != delegateAddress {
+			t.Errorf("SplitSnapshotVotes() returned a delegate vote from %s", vote.Voter.Hex())
+		}
+	}
+}
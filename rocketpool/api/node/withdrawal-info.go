@@ -0,0 +1,46 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getNodeWithdrawalInfo(c *cli.Context) (*api.NodeWithdrawalInfoResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeWithdrawalInfoResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+
+	// Get the withdrawal info
+	withdrawalInfo, err := rputils.GetNodeWithdrawalInfo(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.WithdrawalInfo = *withdrawalInfo
+
+	// Return response
+	return &response, nil
+
+}
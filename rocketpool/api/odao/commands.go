@@ -53,6 +53,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "member-stats",
+				Usage:     "Get the oracle DAO members along with their recent participation",
+				UsageText: "rocketpool api odao member-stats",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getOdaoMembers(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "proposals",
 				Aliases:   []string{"p"},
@@ -0,0 +1,95 @@
+package odao
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getOdaoMembers(c *cli.Context) (*api.OdaoMembersResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.OdaoMembersResponse{
+		ParticipationWindow: rputils.ParticipationWindow,
+	}
+
+	// Sync
+	var wg errgroup.Group
+	var members []trustednode.MemberDetails
+	var pricesBlock uint64
+	var balancesBlock uint64
+
+	// Get members
+	wg.Go(func() error {
+		var err error
+		members, err = trustednode.GetMembers(rp, nil)
+		return err
+	})
+
+	// Get the latest consensus blocks
+	wg.Go(func() error {
+		var err error
+		pricesBlock, err = network.GetPricesBlock(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		balancesBlock, err = network.GetBalancesBlock(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get participation
+	addresses := make([]common.Address, len(members))
+	joinedTimes := make([]uint64, len(members))
+	for i, member := range members {
+		addresses[i] = member.Address
+		joinedTimes[i] = member.JoinedTime
+	}
+	participation, err := rputils.GetOdaoParticipation(rp, addresses, joinedTimes, pricesBlock, balancesBlock)
+	if err != nil {
+		return nil, err
+	}
+	response.Members = getOdaoMemberStats(members, participation)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Merge the member details with their participation rates
+func getOdaoMemberStats(members []trustednode.MemberDetails, participation *rputils.OdaoParticipation) []api.OdaoMemberStats {
+	stats := make([]api.OdaoMemberStats, len(members))
+	for i, member := range members {
+		stats[i] = api.OdaoMemberStats{
+			Address:    member.Address,
+			ID:         member.ID,
+			Url:        member.Url,
+			JoinedTime: time.Unix(int64(member.JoinedTime), 0),
+		}
+		stats[i].PricesParticipation, stats[i].PricesEligible = participation.Prices[member.Address.Hex()]
+		stats[i].BalancesParticipation, stats[i].BalancesEligible = participation.Balances[member.Address.Hex()]
+	}
+	return stats
+}
@@ -0,0 +1,43 @@
+package odao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func TestGetOdaoMemberStats(t *testing.T) {
+	active := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	joined := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	members := []trustednode.MemberDetails{
+		{Address: active, ID: "active", Url: "https://active.example.com", JoinedTime: 1650000000},
+		{Address: joined, ID: "joined", Url: "https://joined.example.com", JoinedTime: 1660000000},
+	}
+	participation := &rputils.OdaoParticipation{
+		Prices:   map[string]float64{active.Hex(): 0.9},
+		Balances: map[string]float64{active.Hex(): 1, joined.Hex(): 0.5},
+	}
+
+	stats := getOdaoMemberStats(members, participation)
+	if len(stats) != 2 {
+		t.Fatalf("getOdaoMemberStats() returned %d members, want 2", len(stats))
+	}
+	if stats[0].Address != active || stats[0].ID != "active" || !stats[0].JoinedTime.Equal(time.Unix(1650000000, 0)) {
+		t.Errorf("first member = %+v, want the active member's details", stats[0])
+	}
+	if !stats[0].PricesEligible || stats[0].PricesParticipation != 0.9 || !stats[0].BalancesEligible || stats[0].BalancesParticipation != 1 {
+		t.Errorf("first member participation = %+v, want 0.9 for prices and 1 for balances", stats[0])
+	}
+
+	// A member that wasn't eligible for any prices checkpoint is marked as such
+	if stats[1].PricesEligible || stats[1].PricesParticipation != 0 {
+		t.Errorf("second member prices participation = %g (eligible: %t), want none", stats[1].PricesParticipation, stats[1].PricesEligible)
+	}
+	if !stats[1].BalancesEligible || stats[1].BalancesParticipation != 0.5 {
+		t.Errorf("second member balances participation = %g (eligible: %t), want 0.5", stats[1].BalancesParticipation, stats[1].BalancesEligible)
+	}
+}
@@ -0,0 +1,272 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	mathutils "github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Claim rewards task
+type claimRewards struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	threshold      *big.Int
+	restake        bool
+	gasThreshold   float64
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+}
+
+// The rewards for a set of unclaimed intervals
+type claimableRewards struct {
+	indices      []*big.Int
+	amountRPL    []*big.Int
+	amountETH    []*big.Int
+	merkleProofs [][]common.Hash
+	totalRPL     *big.Int
+	totalETH     *big.Int
+}
+
+// Create claim rewards task
+func newClaimRewards(c *cli.Context, logger log.ColorLogger) (*claimRewards, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &claimRewards{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		threshold:      eth.EthToWei(cfg.Smartnode.AutoClaimThreshold.Value.(float64)),
+		restake:        cfg.Smartnode.AutoClaimRestake.Value == true,
+		gasThreshold:   cfg.Smartnode.AutoClaimGasThreshold.Value.(float64),
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+	}, nil
+
+}
+
+// Claim rewards
+func (t *claimRewards) run() error {
+
+	// Check if auto-claiming is disabled
+	if t.threshold.Sign() == 0 {
+		return nil
+	}
+
+	// Reload the wallet (in case a call to `node claim-rewards` changed it)
+	if err := t.w.Reload(); err != nil {
+		return err
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Checking for unclaimed rewards...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the rewards
+	claimable, err := t.getClaimableRewards(nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	if len(claimable.indices) == 0 {
+		return nil
+	}
+
+	// Check the value against the threshold
+	rplPrice, err := network.GetRPLPrice(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting RPL price: %w", err)
+	}
+	value := getRewardsValue(claimable.totalRPL, claimable.totalETH, rplPrice)
+	if value.Cmp(t.threshold) < 0 {
+		t.log.Printlnf("Unclaimed rewards (%.6f RPL and %.6f ETH, worth %.6f ETH) are below the auto-claim threshold of %.6f ETH.",
+			mathutils.RoundDown(eth.WeiToEth(claimable.totalRPL), 6), mathutils.RoundDown(eth.WeiToEth(claimable.totalETH), 6),
+			mathutils.RoundDown(eth.WeiToEth(value), 6), mathutils.RoundDown(eth.WeiToEth(t.threshold), 6))
+		return nil
+	}
+
+	// Claim
+	return t.claim(nodeAccount.Address, claimable)
+
+}
+
+// Get the rewards for every unclaimed interval the node has a valid tree file for
+func (t *claimRewards) getClaimableRewards(nodeAddress common.Address) (claimableRewards, error) {
+
+	claimable := claimableRewards{
+		totalRPL: big.NewInt(0),
+		totalETH: big.NewInt(0),
+	}
+
+	unclaimed, _, err := rprewards.GetClaimStatus(t.rp, nodeAddress)
+	if err != nil {
+		return claimableRewards{}, fmt.Errorf("Error getting rewards claim status: %w", err)
+	}
+
+	for _, interval := range unclaimed {
+		intervalInfo, err := rprewards.GetIntervalInfo(t.rp, t.cfg, nodeAddress, interval)
+		if err != nil {
+			return claimableRewards{}, err
+		}
+		if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
+			t.log.Printlnf("The rewards tree for interval %d is missing or invalid, skipping it.", interval)
+			continue
+		}
+		if !intervalInfo.NodeExists {
+			continue
+		}
+
+		rplForInterval := big.NewInt(0)
+		rplForInterval.Add(rplForInterval, &intervalInfo.CollateralRplAmount.Int)
+		rplForInterval.Add(rplForInterval, &intervalInfo.ODaoRplAmount.Int)
+		ethForInterval := big.NewInt(0).Set(&intervalInfo.SmoothingPoolEthAmount.Int)
+
+		claimable.indices = append(claimable.indices, big.NewInt(0).SetUint64(interval))
+		claimable.amountRPL = append(claimable.amountRPL, rplForInterval)
+		claimable.amountETH = append(claimable.amountETH, ethForInterval)
+		claimable.merkleProofs = append(claimable.merkleProofs, intervalInfo.MerkleProof)
+		claimable.totalRPL.Add(claimable.totalRPL, rplForInterval)
+		claimable.totalETH.Add(claimable.totalETH, ethForInterval)
+	}
+
+	return claimable, nil
+
+}
+
+// Get the value of a set of rewards in ETH, with the RPL valued at the provided price
+func getRewardsValue(amountRPL *big.Int, amountETH *big.Int, rplPrice *big.Int) *big.Int {
+	value := big.NewInt(0).Mul(amountRPL, rplPrice)
+	value.Quo(value, eth.EthToWei(1))
+	return value.Add(value, amountETH)
+}
+
+// Claim the rewards, restaking the RPL if enabled
+func (t *claimRewards) claim(nodeAddress common.Address, claimable claimableRewards) error {
+
+	// Log
+	if t.restake {
+		t.log.Printlnf("Claiming %.6f RPL and %.6f ETH from %d interval(s) and restaking the RPL...", mathutils.RoundDown(eth.WeiToEth(claimable.totalRPL), 6), mathutils.RoundDown(eth.WeiToEth(claimable.totalETH), 6), len(claimable.indices))
+	} else {
+		t.log.Printlnf("Claiming %.6f RPL and %.6f ETH from %d interval(s)...", mathutils.RoundDown(eth.WeiToEth(claimable.totalRPL), 6), mathutils.RoundDown(eth.WeiToEth(claimable.totalETH), 6), len(claimable.indices))
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	var gasInfo rocketpool.GasInfo
+	if t.restake {
+		gasInfo, err = rewards.EstimateClaimAndStakeGas(t.rp, nodeAddress, claimable.indices, claimable.amountRPL, claimable.amountETH, claimable.merkleProofs, claimable.totalRPL, opts)
+	} else {
+		gasInfo, err = rewards.EstimateClaimGas(t.rp, nodeAddress, claimable.indices, claimable.amountRPL, claimable.amountETH, claimable.merkleProofs, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to claim rewards: %w", err)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, 0) {
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Claim
+	var hash common.Hash
+	if t.restake {
+		hash, err = rewards.ClaimAndStake(t.rp, nodeAddress, claimable.indices, claimable.amountRPL, claimable.amountETH, claimable.merkleProofs, claimable.totalRPL, opts)
+	} else {
+		hash, err = rewards.Claim(t.rp, nodeAddress, claimable.indices, claimable.amountRPL, claimable.amountETH, claimable.merkleProofs, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Successfully claimed rewards.")
+
+	// Return
+	return nil
+
+}
@@ -0,0 +1,31 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+func TestGetRewardsValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		amountRPL *big.Int
+		amountETH *big.Int
+		rplPrice  *big.Int
+		want      *big.Int
+	}{
+		{"nothing to claim", big.NewInt(0), big.NewInt(0), eth.EthToWei(0.01), big.NewInt(0)},
+		{"only ETH", big.NewInt(0), eth.EthToWei(1.5), eth.EthToWei(0.01), eth.EthToWei(1.5)},
+		{"only RPL", eth.EthToWei(100), big.NewInt(0), eth.EthToWei(0.01), eth.EthToWei(1)},
+		{"RPL and ETH", eth.EthToWei(50), eth.EthToWei(0.25), eth.EthToWei(0.02), eth.EthToWei(1.25)},
+		{"RPL with no price", eth.EthToWei(100), eth.EthToWei(0.5), big.NewInt(0), eth.EthToWei(0.5)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getRewardsValue(test.amountRPL, test.amountETH, test.rplPrice); got.Cmp(test.want) != 0 {
+				t.Errorf("getRewardsValue() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The details of a minipool that decide whether it can be finalised
+type minipoolFinaliseState struct {
+	Address       common.Address
+	Status        rptypes.MinipoolStatus
+	Finalised     bool
+	RefundBalance *big.Int
+}
+
+// Finalise minipools task
+type finaliseMinipools struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	maxPerRun      uint64
+	gasThreshold   float64
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+}
+
+// Create finalise minipools task
+func newFinaliseMinipools(c *cli.Context, logger log.ColorLogger) (*finaliseMinipools, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &finaliseMinipools{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		maxPerRun:      cfg.Smartnode.AutoFinaliseMaxPerRun.Value.(uint64),
+		gasThreshold:   cfg.Smartnode.AutoFinaliseGasThreshold.Value.(float64),
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+	}, nil
+
+}
+
+// Finalise the node's withdrawn minipools
+func (t *finaliseMinipools) run() error {
+
+	// Check if auto-finalising is disabled
+	if t.maxPerRun == 0 {
+		return nil
+	}
+
+	// Reload the wallet (in case a call to `node deposit` changed it)
+	if err := t.w.Reload(); err != nil {
+		return err
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Checking for minipools to finalise...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the minipools that can be finalised
+	states, err := t.getMinipoolFinaliseStates(nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	finalisable, needsRefund := getFinalisableMinipools(states, t.maxPerRun)
+	for _, address := range needsRefund {
+		t.log.Printlnf("Minipool %s has a refund available; it will be finalised once you run `rocketpool minipool refund`.", address.Hex())
+	}
+	if len(finalisable) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("%d minipool(s) are ready to be finalised...", len(finalisable))
+
+	// Finalise minipools
+	for _, address := range finalisable {
+		if err := t.finaliseMinipool(address); err != nil {
+			t.log.Println(fmt.Errorf("Could not finalise minipool %s: %w", address.Hex(), err))
+			return err
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the details of the node's minipools that decide whether they can be finalised
+func (t *finaliseMinipools) getMinipoolFinaliseStates(nodeAddress common.Address) ([]minipoolFinaliseState, error) {
+
+	// Get node minipool addresses
+	addresses, err := minipool.GetNodeMinipoolAddresses(t.rp, nodeAddress, nil)
+	if err != nil {
+		return []minipoolFinaliseState{}, err
+	}
+
+	// Load the details
+	var wg errgroup.Group
+	states := make([]minipoolFinaliseState, len(addresses))
+	for mi, address := range addresses {
+		mi, address := mi, address
+		wg.Go(func() error {
+			mp, err := minipool.NewMinipool(t.rp, address, nil)
+			if err != nil {
+				return err
+			}
+			status, err := mp.GetStatus(nil)
+			if err != nil {
+				return err
+			}
+			finalised, err := mp.GetFinalised(nil)
+			if err != nil {
+				return err
+			}
+			refundBalance, err := mp.GetNodeRefundBalance(nil)
+			if err != nil {
+				return err
+			}
+			states[mi] = minipoolFinaliseState{
+				Address:       address,
+				Status:        status,
+				Finalised:     finalised,
+				RefundBalance: refundBalance,
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return []minipoolFinaliseState{}, err
+	}
+
+	// Return
+	return states, nil
+
+}
+
+// Get the withdrawn minipools that haven't been finalised yet, up to maxPerRun of them, along with the ones that
+// need to be refunded before they can be finalised
+func getFinalisableMinipools(states []minipoolFinaliseState, maxPerRun uint64) ([]common.Address, []common.Address) {
+	finalisable := []common.Address{}
+	needsRefund := []common.Address{}
+	for _, state := range states {
+		if state.Status != rptypes.Withdrawable || state.Finalised {
+			continue
+		}
+		if state.RefundBalance != nil && state.RefundBalance.Sign() > 0 {
+			needsRefund = append(needsRefund, state.Address)
+			continue
+		}
+		if uint64(len(finalisable)) < maxPerRun {
+			finalisable = append(finalisable, state.Address)
+		}
+	}
+	return finalisable, needsRefund
+}
+
+// Get the max fee for a transaction, using the headless suggestion if none was set
+func (t *finaliseMinipools) getMaxFee() (*big.Int, error) {
+	if t.maxFee != nil && t.maxFee.Uint64() != 0 {
+		return t.maxFee, nil
+	}
+	return rpgas.GetHeadlessMaxFeeWei()
+}
+
+// Finalise a minipool
+func (t *finaliseMinipools) finaliseMinipool(address common.Address) error {
+
+	// Log
+	t.log.Printlnf("Finalising minipool %s...", address.Hex())
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(t.rp, address, nil)
+	if err != nil {
+		return err
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := mp.EstimateFinaliseGas(opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to finalise the minipool: %w", err)
+	}
+
+	// Get the max fee
+	maxFee, err := t.getMaxFee()
+	if err != nil {
+		return err
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, 0) {
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Finalise
+	hash, err := mp.Finalise(opts)
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully finalised minipool %s.", address.Hex())
+
+	// Return
+	return nil
+
+}
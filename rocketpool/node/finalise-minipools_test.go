@@ -0,0 +1,62 @@
+package node
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestGetFinalisableMinipools(t *testing.T) {
+	withdrawable := func(index int64) minipoolFinaliseState {
+		return minipoolFinaliseState{
+			Address:       common.BigToAddress(big.NewInt(index)),
+			Status:        rptypes.Withdrawable,
+			RefundBalance: big.NewInt(0),
+		}
+	}
+	address := func(index int64) common.Address {
+		return common.BigToAddress(big.NewInt(index))
+	}
+
+	staking := withdrawable(2)
+	staking.Status = rptypes.Staking
+	finalised := withdrawable(3)
+	finalised.Finalised = true
+	refund := withdrawable(4)
+	refund.RefundBalance = big.NewInt(1)
+	nilRefund := withdrawable(5)
+	nilRefund.RefundBalance = nil
+
+	tests := []struct {
+		name            string
+		states          []minipoolFinaliseState
+		maxPerRun       uint64
+		wantFinalisable []common.Address
+		wantNeedsRefund []common.Address
+	}{
+		{"withdrawable", []minipoolFinaliseState{withdrawable(1)}, 10, []common.Address{address(1)}, []common.Address{}},
+		{"not withdrawable", []minipoolFinaliseState{staking}, 10, []common.Address{}, []common.Address{}},
+		{"already finalised", []minipoolFinaliseState{finalised}, 10, []common.Address{}, []common.Address{}},
+		{"needs a refund", []minipoolFinaliseState{refund}, 10, []common.Address{}, []common.Address{address(4)}},
+		{"no refund balance", []minipoolFinaliseState{nilRefund}, 10, []common.Address{address(5)}, []common.Address{}},
+		{"mixed", []minipoolFinaliseState{withdrawable(1), staking, finalised, refund, nilRefund}, 10, []common.Address{address(1), address(5)}, []common.Address{address(4)}},
+		{"capped per run", []minipoolFinaliseState{withdrawable(1), withdrawable(6), withdrawable(7)}, 2, []common.Address{address(1), address(6)}, []common.Address{}},
+		{"refunds aren't capped", []minipoolFinaliseState{withdrawable(1), refund, withdrawable(6)}, 1, []common.Address{address(1)}, []common.Address{address(4)}},
+		{"none per run", []minipoolFinaliseState{withdrawable(1)}, 0, []common.Address{}, []common.Address{}},
+		{"no minipools", []minipoolFinaliseState{}, 10, []common.Address{}, []common.Address{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			finalisable, needsRefund := getFinalisableMinipools(test.states, test.maxPerRun)
+			if !reflect.DeepEqual(finalisable, test.wantFinalisable) {
+				t.Errorf("finalisable = %v, want %v", finalisable, test.wantFinalisable)
+			}
+			if !reflect.DeepEqual(needsRefund, test.wantNeedsRefund) {
+				t.Errorf("needsRefund = %v, want %v", needsRefund, test.wantNeedsRefund)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package node
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	mathutils "github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The amount of ETH each active minipool borrows from the deposit pool
+const borrowedEthPerMinipool float64 = 16
+
+// Maintain collateral task
+type maintainCollateral struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	targetRatio    float64
+	minAmount      *big.Int
+	gasThreshold   float64
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+}
+
+// Create maintain collateral task
+func newMaintainCollateral(c *cli.Context, logger log.ColorLogger) (*maintainCollateral, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &maintainCollateral{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		targetRatio:    cfg.Smartnode.AutoStakeTargetCollateral.Value.(float64) / 100,
+		minAmount:      eth.EthToWei(cfg.Smartnode.AutoStakeMinAmount.Value.(float64)),
+		gasThreshold:   cfg.Smartnode.AutoStakeGasThreshold.Value.(float64),
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+	}, nil
+
+}
+
+// Stake RPL if the node's collateral is below the target
+func (t *maintainCollateral) run() error {
+
+	// Check if auto-staking is disabled
+	if t.targetRatio == 0 {
+		return nil
+	}
+
+	// Reload the wallet (in case a call to `node stake-rpl` changed it)
+	if err := t.w.Reload(); err != nil {
+		return err
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Checking the node's RPL collateral...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the node's stake and the RPL price
+	rplStake, err := node.GetNodeRPLStake(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting RPL stake: %w", err)
+	}
+	rplPrice, err := network.GetRPLPrice(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting RPL price: %w", err)
+	}
+	activeMinipools, err := minipool.GetNodeActiveMinipoolCount(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting active minipool count: %w", err)
+	}
+
+	// Get the amount needed to reach the target
+	topUp := getCollateralTopUp(rplStake, rplPrice, activeMinipools, t.targetRatio)
+	if topUp.Sign() == 0 {
+		return nil
+	}
+	if topUp.Cmp(t.minAmount) < 0 {
+		t.log.Printlnf("The node needs %.6f RPL to reach its collateral target, which is below the minimum auto-stake amount of %.6f RPL.", mathutils.RoundDown(eth.WeiToEth(topUp), 6), mathutils.RoundDown(eth.WeiToEth(t.minAmount), 6))
+		return nil
+	}
+
+	// Check the RPL balance
+	rplBalance, err := tokens.GetRPLBalance(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting RPL balance: %w", err)
+	}
+	if rplBalance.Cmp(topUp) < 0 {
+		t.log.Printlnf("The node needs %.6f RPL to reach its collateral target but only has %.6f RPL in its wallet.", mathutils.RoundDown(eth.WeiToEth(topUp), 6), mathutils.RoundDown(eth.WeiToEth(rplBalance), 6))
+		return nil
+	}
+
+	// Check network consensus
+	inConsensus, err := network.InConsensus(t.rp, nil)
+	if err != nil {
+		return err
+	}
+	if !inConsensus {
+		t.log.Println("The RPL price and total effective RPL stake are not in consensus, waiting to stake.")
+		return nil
+	}
+
+	// Stake
+	return t.stake(topUp)
+
+}
+
+// Get the amount of RPL that needs to be staked to bring the collateral ratio up to the target, or zero if it is
+// already there or the node has no active minipools
+func getCollateralTopUp(rplStake *big.Int, rplPrice *big.Int, activeMinipools uint64, targetRatio float64) *big.Int {
+
+	if activeMinipools == 0 || rplPrice.Sign() == 0 {
+		return big.NewInt(0)
+	}
+
+	// Required stake = borrowed ETH * target ratio / RPL price, with the ratio in basis points to stay in integers
+	targetBasisPoints := big.NewInt(int64(math.Round(targetRatio * 10000)))
+	borrowed := eth.EthToWei(float64(activeMinipools) * borrowedEthPerMinipool)
+	required := big.NewInt(0).Mul(borrowed, targetBasisPoints)
+	required.Mul(required, eth.EthToWei(1))
+	required.Quo(required, big.NewInt(10000))
+	required.Quo(required, rplPrice)
+
+	topUp := required.Sub(required, rplStake)
+	if topUp.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return topUp
+
+}
+
+// Get the max fee for a transaction, using the headless suggestion if none was set
+func (t *maintainCollateral) getMaxFee() (*big.Int, error) {
+	if t.maxFee != nil && t.maxFee.Uint64() != 0 {
+		return t.maxFee, nil
+	}
+	return rpgas.GetHeadlessMaxFeeWei()
+}
+
+// Approve the staking contract to spend the RPL if necessary, then stake it
+func (t *maintainCollateral) stake(amount *big.Int) error {
+
+	// Log
+	t.log.Printlnf("Staking %.6f RPL to reach the collateral target of %.2f%%...", mathutils.RoundDown(eth.WeiToEth(amount), 6), t.targetRatio*100)
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Check the allowance
+	rocketNodeStakingAddress, err := t.rp.GetAddress("rocketNodeStaking", nil)
+	if err != nil {
+		return err
+	}
+	allowance, err := tokens.GetRPLAllowance(t.rp, opts.From, *rocketNodeStakingAddress, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting RPL allowance: %w", err)
+	}
+
+	// Approve the RPL if needed
+	if allowance.Cmp(amount) < 0 {
+		gasInfo, err := tokens.EstimateApproveRPLGas(t.rp, *rocketNodeStakingAddress, amount, opts)
+		if err != nil {
+			return fmt.Errorf("Could not estimate the gas required to approve RPL for staking: %w", err)
+		}
+		maxFee, err := t.getMaxFee()
+		if err != nil {
+			return err
+		}
+		if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, 0) {
+			return nil
+		}
+		opts.GasFeeCap = maxFee
+		opts.GasTipCap = t.maxPriorityFee
+		opts.GasLimit = gasInfo.SafeGasLimit
+
+		hash, err := tokens.ApproveRPL(t.rp, *rocketNodeStakingAddress, amount, opts)
+		if err != nil {
+			return err
+		}
+		if err := api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log); err != nil {
+			return err
+		}
+		opts.GasLimit = 0
+	}
+
+	// Get the gas limit
+	gasInfo, err := node.EstimateStakeGas(t.rp, amount, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to stake RPL: %w", err)
+	}
+
+	// Get the max fee
+	maxFee, err := t.getMaxFee()
+	if err != nil {
+		return err
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, 0) {
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Stake
+	hash, err := node.StakeRPL(t.rp, amount, opts)
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully staked %.6f RPL.", mathutils.RoundDown(eth.WeiToEth(amount), 6))
+
+	// Return
+	return nil
+
+}
@@ -0,0 +1,34 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+func TestGetCollateralTopUp(t *testing.T) {
+	tests := []struct {
+		name            string
+		rplStake        *big.Int
+		rplPrice        *big.Int
+		activeMinipools uint64
+		targetRatio     float64
+		want            *big.Int
+	}{
+		{"below the target", eth.EthToWei(100), eth.EthToWei(0.01), 1, 0.1, eth.EthToWei(60)},
+		{"nothing staked", big.NewInt(0), eth.EthToWei(0.02), 2, 0.15, eth.EthToWei(240)},
+		{"at the target", eth.EthToWei(160), eth.EthToWei(0.01), 1, 0.1, big.NewInt(0)},
+		{"above the target", eth.EthToWei(500), eth.EthToWei(0.01), 1, 0.1, big.NewInt(0)},
+		{"no active minipools", big.NewInt(0), eth.EthToWei(0.01), 0, 0.1, big.NewInt(0)},
+		{"no RPL price", big.NewInt(0), big.NewInt(0), 1, 0.1, big.NewInt(0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := getCollateralTopUp(test.rplStake, test.rplPrice, test.activeMinipools, test.targetRatio)
+			if got.Cmp(test.want) != 0 {
+				t.Errorf("getCollateralTopUp() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -73,17 +73,22 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec)
 
 	// Set up Prometheus
+	labels, err := services.GetMetricLabels(c)
+	if err != nil {
+		return err
+	}
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(demandCollector)
-	registry.MustRegister(performanceCollector)
-	registry.MustRegister(supplyCollector)
-	registry.MustRegister(rplCollector)
-	registry.MustRegister(odaoCollector)
-	registry.MustRegister(nodeCollector)
-	registry.MustRegister(trustedNodeCollector)
-	registry.MustRegister(beaconCollector)
-	registry.MustRegister(snapshotCollector)
-	registry.MustRegister(smoothingPoolCollector)
+	registerer := prometheus.WrapRegistererWith(labels, registry)
+	registerer.MustRegister(demandCollector)
+	registerer.MustRegister(performanceCollector)
+	registerer.MustRegister(supplyCollector)
+	registerer.MustRegister(rplCollector)
+	registerer.MustRegister(odaoCollector)
+	registerer.MustRegister(nodeCollector)
+	registerer.MustRegister(trustedNodeCollector)
+	registerer.MustRegister(beaconCollector)
+	registerer.MustRegister(snapshotCollector)
+	registerer.MustRegister(smoothingPoolCollector)
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -91,8 +96,9 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 	metricsPort := c.GlobalUint("metricsPort")
 	logger.Printlnf("Starting metrics exporter on %s:%d.", metricsAddress, metricsPort)
 	metricsPath := "/metrics"
-	http.Handle(metricsPath, handler)
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, handler)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`<html>
             <head><title>Rocket Pool Metrics Exporter</title></head>
             <body>
@@ -102,7 +108,7 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
             </html>`,
 		))
 	})
-	err = http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), nil)
+	err = http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), mux)
 	if err != nil {
 		return fmt.Errorf("Error running HTTP server: %w", err)
 	}
@@ -3,6 +3,7 @@ package node
 import (
 	"fmt"
 	"io/ioutil"
+	stdlog "log"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -33,6 +35,8 @@ const (
 	DownloadRewardsTreesColor    = color.FgGreen
 	MetricsColor                 = color.FgHiYellow
 	ManageFeeRecipientColor      = color.FgHiCyan
+	MaintainCollateralColor      = color.FgHiGreen
+	FinaliseMinipoolsColor       = color.FgHiBlue
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 )
@@ -52,8 +56,17 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Configure the log output
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if err := configureLogging(cfg); err != nil {
+		return err
+	}
+
 	// Handle the initial fee recipient file deployment
-	err := deployDefaultFeeRecipientFile(c)
+	err = deployDefaultFeeRecipientFile(c)
 	if err != nil {
 		return err
 	}
@@ -67,6 +80,11 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
+	// Make sure the wallet has loaded the registered node account
+	if err := verifyNodeIdentity(c); err != nil {
+		return err
+	}
+
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
 		return err
@@ -85,6 +103,18 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	claimRewards, err := newClaimRewards(c, log.NewColorLogger(ClaimRplRewardsColor))
+	if err != nil {
+		return err
+	}
+	maintainCollateral, err := newMaintainCollateral(c, log.NewColorLogger(MaintainCollateralColor))
+	if err != nil {
+		return err
+	}
+	finaliseMinipools, err := newFinaliseMinipools(c, log.NewColorLogger(FinaliseMinipoolsColor))
+	if err != nil {
+		return err
+	}
 
 	// Initialize loggers
 	errorLog := log.NewColorLogger(ErrorColor)
@@ -122,6 +152,24 @@ func run(c *cli.Context) error {
 					if err := stakePrelaunchMinipools.run(); err != nil {
 						errorLog.Println(err)
 					}
+					time.Sleep(taskCooldown)
+
+					// Run the rewards claim check
+					if err := claimRewards.run(); err != nil {
+						errorLog.Println(err)
+					}
+					time.Sleep(taskCooldown)
+
+					// Run the collateral check
+					if err := maintainCollateral.run(); err != nil {
+						errorLog.Println(err)
+					}
+					time.Sleep(taskCooldown)
+
+					// Run the minipool finalise check
+					if err := finaliseMinipools.run(); err != nil {
+						errorLog.Println(err)
+					}
 				}
 			}
 			time.Sleep(tasksInterval)
@@ -154,6 +202,63 @@ func configureHTTP() {
 
 }
 
+// Route the daemon's logs to the console, the configured log file, or both
+func configureLogging(cfg *config.RocketPoolConfig) error {
+
+	output, err := log.NewOutput(os.Stderr, os.ExpandEnv(cfg.Smartnode.LogFile.Value.(string)), cfg.Smartnode.LogMaxSizeMb.Value.(uint64), cfg.Smartnode.LogToConsole.Value == true)
+	if err != nil {
+		return err
+	}
+	stdlog.SetOutput(output)
+	return nil
+
+}
+
+// Check that the node account loaded from the wallet is registered with Rocket Pool, and warn if it isn't since
+// a wallet recovered with the wrong derivation path or index has a different address than the registered node
+func verifyNodeIdentity(c *cli.Context) error {
+
+	// Get services
+	if err := services.WaitNodeWallet(c, true); err != nil {
+		return err
+	}
+	if err := services.WaitRocketStorage(c, true); err != nil {
+		return err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+
+	// Check the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	exists, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("Error checking if node %s is registered: %w", nodeAccount.Address.Hex(), err)
+	}
+	if exists {
+		return nil
+	}
+
+	// Warn about the mismatch
+	warningLog := log.NewColorLogger(WarningColor)
+	path, err := w.GetNodeDerivationPath()
+	if err != nil {
+		path = "unknown"
+	}
+	warningLog.Printlnf("WARNING: the node wallet's address %s (derivation path %s) is not registered with Rocket Pool.", nodeAccount.Address.Hex(), path)
+	warningLog.Println("If you have already registered this node, the wallet may have been recovered with the wrong derivation path or index. You can use `rocketpool wallet recover --address` with your node address to search for the correct ones.")
+	return nil
+
+}
+
 // Copy the default fee recipient file into the proper location
 func deployDefaultFeeRecipientFile(c *cli.Context) error {
 
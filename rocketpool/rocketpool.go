@@ -50,6 +50,10 @@ func main() {
 			Usage: "Rocket Pool service user config absolute `path`",
 			Value: "/.rocketpool/user-settings.yml",
 		},
+		cli.StringFlag{
+			Name:  "configUrl",
+			Usage: "HTTPS `url` to fetch the Rocket Pool service user config from; the settings file is used if it can't be fetched",
+		},
 		cli.StringFlag{
 			Name:  "storageAddress, a",
 			Usage: "Rocket Pool storage contract `address`",
@@ -0,0 +1,94 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An execution client that can build access lists for calls
+type accessListCreator interface {
+	CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, error)
+}
+
+// Get the access list for a contract call, if the execution client supports building one
+func getAccessList(ec rocketpool.ExecutionClient, from common.Address, to *common.Address, data []byte) (types.AccessList, uint64, error) {
+	creator, ok := ec.(accessListCreator)
+	if !ok {
+		return nil, 0, fmt.Errorf("the execution client does not support creating access lists")
+	}
+	accessList, gasUsed, err := creator.CreateAccessList(context.Background(), ethereum.CallMsg{
+		From: from,
+		To:   to,
+		Data: data,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return *accessList, gasUsed, nil
+}
+
+// Build a dynamic fee transaction for a contract call with the access list attached, signed by the transactor
+func buildAccessListTransaction(ec rocketpool.ExecutionClient, chainID *big.Int, to *common.Address, data []byte, accessList types.AccessList, opts *bind.TransactOpts) (*types.Transaction, error) {
+
+	var nonce uint64
+	if opts.Nonce != nil {
+		nonce = opts.Nonce.Uint64()
+	} else {
+		var err error
+		nonce, err = ec.PendingNonceAt(context.Background(), opts.From)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting nonce: %w", err)
+		}
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    chainID,
+		Nonce:      nonce,
+		GasTipCap:  opts.GasTipCap,
+		GasFeeCap:  opts.GasFeeCap,
+		Gas:        opts.GasLimit,
+		To:         to,
+		Data:       data,
+		AccessList: accessList,
+	})
+	return opts.Signer(opts.From, tx)
+
+}
+
+// Submit a contract call with a precomputed access list attached.
+// The transaction is only sent if the access list could be built, so callers can fall back to a normal transaction when this fails.
+func transactWithAccessList(ec rocketpool.ExecutionClient, chainID *big.Int, contract *rocketpool.Contract, opts *bind.TransactOpts, method string, params ...interface{}) (common.Hash, error) {
+
+	data, err := contract.ABI.Pack(method, params...)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Error encoding %s call: %w", method, err)
+	}
+
+	// Get the access list, making sure the gas limit still covers the call with it applied
+	accessList, gasUsed, err := getAccessList(ec, opts.From, contract.Address, data)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Error creating access list: %w", err)
+	}
+	safeGasLimit := gasUsed * 3 / 2
+	if opts.GasLimit < safeGasLimit {
+		opts.GasLimit = safeGasLimit
+	}
+
+	// Sign and send the transaction
+	tx, err := buildAccessListTransaction(ec, chainID, contract.Address, data, accessList, opts)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := ec.SendTransaction(context.Background(), tx); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+
+}
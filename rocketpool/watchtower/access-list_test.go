@@ -0,0 +1,125 @@
+package watchtower
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An execution client that builds a fixed access list and returns a fixed nonce.
+// Calling any other method panics.
+type fakeAccessListClient struct {
+	rocketpool.ExecutionClient
+	accessList types.AccessList
+	gasUsed    uint64
+	nonce      uint64
+	msgs       []ethereum.CallMsg
+}
+
+func (c *fakeAccessListClient) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, error) {
+	c.msgs = append(c.msgs, msg)
+	return &c.accessList, c.gasUsed, nil
+}
+
+func (c *fakeAccessListClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return c.nonce, nil
+}
+
+func TestGetAccessList(t *testing.T) {
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	data := []byte{0x01, 0x02}
+	client := &fakeAccessListClient{
+		accessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x01")}}},
+		gasUsed:    50000,
+	}
+
+	accessList, gasUsed, err := getAccessList(client, from, &to, data)
+	if err != nil {
+		t.Fatalf("getAccessList() returned an error: %s", err)
+	}
+	if !reflect.DeepEqual(accessList, client.accessList) || gasUsed != client.gasUsed {
+		t.Errorf("getAccessList() = (%v, %d), want (%v, %d)", accessList, gasUsed, client.accessList, client.gasUsed)
+	}
+	if len(client.msgs) != 1 || client.msgs[0].From != from || *client.msgs[0].To != to || !reflect.DeepEqual(client.msgs[0].Data, data) {
+		t.Errorf("getAccessList() requested %+v, want a call from %s to %s", client.msgs, from.Hex(), to.Hex())
+	}
+
+	// Clients that can't build access lists are reported rather than called
+	if _, _, err := getAccessList(struct{ rocketpool.ExecutionClient }{}, from, &to, data); err == nil {
+		t.Error("getAccessList() didn't return an error for a client without access list support")
+	}
+}
+
+func TestBuildAccessListTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID := big.NewInt(5)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.GasTipCap = big.NewInt(2e9)
+	opts.GasFeeCap = big.NewInt(50e9)
+	opts.GasLimit = 120000
+
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	accessList := types.AccessList{{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x01")}}}
+	client := &fakeAccessListClient{nonce: 7}
+
+	tx, err := buildAccessListTransaction(client, chainID, &to, []byte{0x01}, accessList, opts)
+	if err != nil {
+		t.Fatalf("buildAccessListTransaction() returned an error: %s", err)
+	}
+	if tx.Type() != types.DynamicFeeTxType {
+		t.Errorf("transaction type = %d, want %d", tx.Type(), types.DynamicFeeTxType)
+	}
+	if tx.Nonce() != 7 || tx.Gas() != opts.GasLimit || tx.GasTipCap().Cmp(opts.GasTipCap) != 0 || tx.GasFeeCap().Cmp(opts.GasFeeCap) != 0 {
+		t.Errorf("transaction has nonce %d, gas %d, tip %s and fee cap %s, want the pending nonce and the transactor's gas settings", tx.Nonce(), tx.Gas(), tx.GasTipCap(), tx.GasFeeCap())
+	}
+	if !reflect.DeepEqual(tx.AccessList(), accessList) {
+		t.Errorf("transaction access list = %v, want %v", tx.AccessList(), accessList)
+	}
+
+	// The transaction is signed by the transactor
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != opts.From {
+		t.Errorf("transaction is signed by %s, want %s", sender.Hex(), opts.From.Hex())
+	}
+}
+
+func TestBuildAccessListTransactionWithNonce(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID := big.NewInt(5)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.Nonce = big.NewInt(12)
+
+	// A nonce set on the transactor is used instead of the pending nonce
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	tx, err := buildAccessListTransaction(&fakeAccessListClient{nonce: 7}, chainID, &to, []byte{0x01}, nil, opts)
+	if err != nil {
+		t.Fatalf("buildAccessListTransaction() returned an error: %s", err)
+	}
+	if tx.Nonce() != 12 {
+		t.Errorf("transaction nonce = %d, want 12", tx.Nonce())
+	}
+}
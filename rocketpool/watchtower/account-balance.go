@@ -0,0 +1,132 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The alert key for a low node account balance
+const lowBalanceAlertKey string = "low-balance"
+
+// Checks that the node account has enough ETH to pay for submissions before they're attempted
+type accountBalanceCheck struct {
+	ec         rocketpool.ExecutionClient
+	minBalance *big.Int
+	coll       *collectors.AccountCollector
+	alerter    *dedupeAlerter
+	log        log.ColorLogger
+
+	// Funding requests for topping the account up before it runs out
+	fundingThreshold *big.Int
+	fundingTarget    *big.Int
+	funding          *alerting.FundingNotifier
+	fundingInterval  time.Duration
+	lastFunding      time.Time
+	fundingLock      sync.Mutex
+}
+
+// Create a new account balance check
+func newAccountBalanceCheck(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, coll *collectors.AccountCollector, alerter *dedupeAlerter, logger log.ColorLogger) *accountBalanceCheck {
+	return &accountBalanceCheck{
+		ec:         ec,
+		minBalance: eth.EthToWei(cfg.Smartnode.MinAccountBalanceEth.Value.(float64)),
+		coll:       coll,
+		alerter:    alerter,
+		log:        logger,
+
+		fundingThreshold: eth.EthToWei(cfg.Smartnode.FundingThresholdEth.Value.(float64)),
+		fundingTarget:    eth.EthToWei(cfg.Smartnode.FundingTargetEth.Value.(float64)),
+		funding:          alerting.NewFundingNotifier(cfg),
+		fundingInterval:  time.Duration(cfg.Smartnode.AlertCooldown.Value.(uint64)) * time.Minute,
+	}
+}
+
+// Check if a balance is enough to submit with; a minimum of zero disables the check
+func isBalanceSufficient(balance *big.Int, minBalance *big.Int) bool {
+	return minBalance.Sign() == 0 || balance.Cmp(minBalance) >= 0
+}
+
+// Get the amount of ETH needed to bring a balance that's fallen below the low threshold back up to the target.
+// This is zero if the balance isn't below the threshold, if it's already at the target, or if the threshold is zero.
+func computeTopUpAmount(balance *big.Int, low *big.Int, target *big.Int) *big.Int {
+	if low.Sign() == 0 || balance.Cmp(low) >= 0 || balance.Cmp(target) >= 0 {
+		return big.NewInt(0)
+	}
+	return big.NewInt(0).Sub(target, balance)
+}
+
+// Request funding for the node account if its balance has fallen below the funding threshold.
+// Requests are repeated at most once per alert cooldown while the balance stays low.
+func (b *accountBalanceCheck) checkFunding(address common.Address, balance *big.Int) {
+
+	b.fundingLock.Lock()
+	defer b.fundingLock.Unlock()
+
+	topUp := computeTopUpAmount(balance, b.fundingThreshold, b.fundingTarget)
+	if topUp.Sign() == 0 {
+		b.lastFunding = time.Time{}
+		return
+	}
+	if !b.lastFunding.IsZero() && time.Since(b.lastFunding) < b.fundingInterval {
+		return
+	}
+	b.lastFunding = time.Now()
+
+	request := alerting.FundingRequest{
+		Event:   "needs-funding",
+		Address: address.Hex(),
+		Balance: balance.String(),
+		Target:  b.fundingTarget.String(),
+		TopUp:   topUp.String(),
+		Time:    time.Now().UTC(),
+	}
+	line, err := json.Marshal(request)
+	if err != nil {
+		b.log.Printlnf("Error serializing funding request: %s", err.Error())
+		return
+	}
+	b.log.Printlnf("Node account needs %.6f ETH to reach its target balance: %s", eth.WeiToEth(topUp), string(line))
+	if err := b.funding.Notify(request); err != nil {
+		b.log.Printlnf("Error sending funding request: %s", err.Error())
+	}
+
+}
+
+// Check if the node account has enough ETH to submit, alerting if it doesn't
+func (b *accountBalanceCheck) hasSufficientBalance(address common.Address) (bool, error) {
+
+	balance, err := b.ec.BalanceAt(context.Background(), address, nil)
+	if err != nil {
+		return false, fmt.Errorf("Error getting node account balance: %w", err)
+	}
+	b.coll.SetBalance(eth.WeiToEth(balance))
+	b.checkFunding(address, balance)
+
+	if isBalanceSufficient(balance, b.minBalance) {
+		if err := b.alerter.Resolve(lowBalanceAlertKey); err != nil {
+			b.log.Printlnf("Error clearing low balance alert: %s", err.Error())
+		}
+		return true, nil
+	}
+
+	message := fmt.Sprintf("Node account %s has %.6f ETH, below the minimum of %.6f ETH needed to submit. Submissions will be skipped until it is topped up.", address.Hex(), eth.WeiToEth(balance), eth.WeiToEth(b.minBalance))
+	b.log.Println(message)
+	if err := b.alerter.Alert(lowBalanceAlertKey, "Node account balance low", message); err != nil {
+		b.log.Printlnf("Error sending low balance alert: %s", err.Error())
+	}
+	return false, nil
+
+}
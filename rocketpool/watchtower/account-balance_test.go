@@ -0,0 +1,109 @@
+package watchtower
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// An execution client with a fixed account balance. Calling any other method panics.
+type fakeBalanceClient struct {
+	rocketpool.ExecutionClient
+	balance *big.Int
+}
+
+func (c *fakeBalanceClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return c.balance, nil
+}
+
+func TestIsBalanceSufficient(t *testing.T) {
+	tests := []struct {
+		name       string
+		balance    *big.Int
+		minBalance *big.Int
+		want       bool
+	}{
+		{"above the minimum", eth.EthToWei(1), eth.EthToWei(0.5), true},
+		{"at the minimum", eth.EthToWei(0.5), eth.EthToWei(0.5), true},
+		{"below the minimum", eth.EthToWei(0.49), eth.EthToWei(0.5), false},
+		{"empty account", big.NewInt(0), eth.EthToWei(0.5), false},
+		{"check disabled", big.NewInt(0), big.NewInt(0), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isBalanceSufficient(test.balance, test.minBalance); got != test.want {
+				t.Errorf("isBalanceSufficient(%s, %s) = %t, want %t", test.balance, test.minBalance, got, test.want)
+			}
+		})
+	}
+}
+
+func TestHasSufficientBalance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watchtower")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	alerter, err := newTestDedupeAlerter("", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		balance *big.Int
+		want    bool
+	}{
+		{"enough ETH", eth.EthToWei(1), true},
+		{"too little ETH", eth.EthToWei(0.1), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.NewRocketPoolConfig("", false)
+			cfg.Smartnode.MinAccountBalanceEth.Value = float64(0.5)
+			check := newAccountBalanceCheck(cfg, &fakeBalanceClient{balance: test.balance}, collectors.NewAccountCollector(), alerter, log.NewColorLogger(WarningColor))
+			got, err := check.hasSufficientBalance(common.HexToAddress("0x1111111111111111111111111111111111111111"))
+			if err != nil {
+				t.Fatalf("hasSufficientBalance() returned an error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("hasSufficientBalance() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestComputeTopUpAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance *big.Int
+		low     *big.Int
+		target  *big.Int
+		want    *big.Int
+	}{
+		{"below the threshold", eth.EthToWei(0.5), eth.EthToWei(1), eth.EthToWei(3), eth.EthToWei(2.5)},
+		{"empty", big.NewInt(0), eth.EthToWei(1), eth.EthToWei(3), eth.EthToWei(3)},
+		{"at the threshold", eth.EthToWei(1), eth.EthToWei(1), eth.EthToWei(3), big.NewInt(0)},
+		{"above the threshold", eth.EthToWei(2), eth.EthToWei(1), eth.EthToWei(3), big.NewInt(0)},
+		{"threshold disabled", big.NewInt(0), big.NewInt(0), eth.EthToWei(3), big.NewInt(0)},
+		{"target below the threshold and the balance", eth.EthToWei(0.5), eth.EthToWei(1), eth.EthToWei(0.25), big.NewInt(0)},
+		{"target at the balance", eth.EthToWei(0.5), eth.EthToWei(1), eth.EthToWei(0.5), big.NewInt(0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := computeTopUpAmount(test.balance, test.low, test.target); got.Cmp(test.want) != 0 {
+				t.Errorf("computeTopUpAmount(%s, %s, %s) = %s, want %s", test.balance, test.low, test.target, got, test.want)
+			}
+		})
+	}
+}
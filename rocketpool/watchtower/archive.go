@@ -0,0 +1,53 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// The number of recent blocks every execution client keeps the state for, even without archive mode (Geth's default,
+// which is the smallest of the major clients)
+const nonArchiveStateBlocks uint64 = 128
+
+// Check if reading the state at a block needs an archive node, given the latest block
+func isOutsideNonArchiveWindow(latestBlock uint64, blockNumber uint64) bool {
+	return latestBlock > blockNumber && latestBlock-blockNumber >= nonArchiveStateBlocks
+}
+
+// Make sure a client can serve the state at a historical block instead of erroring or quietly serving the latest state.
+// Recent blocks are always available so they aren't checked; for older ones, the probe reads the block the network
+// prices were last reported for, which can never be later than the block it's read at.
+func requireArchiveAt(rp *rocketpool.RocketPool, blockNumber uint64) error {
+
+	// Only check blocks that a non-archive client may have pruned
+	latestHeader, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the latest block: %w", err)
+	}
+	if !isOutsideNonArchiveWindow(latestHeader.Number.Uint64(), blockNumber) {
+		return nil
+	}
+
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(blockNumber),
+	}
+	pricesBlock, err := network.GetPricesBlock(rp, opts)
+	if err != nil {
+		if eth1.IsMissingStateError(err) {
+			return fmt.Errorf("The execution client doesn't have the state for block %d; an archive node is required to read it: %w", blockNumber, err)
+		}
+		return fmt.Errorf("Error checking the execution client's state at block %d: %w", blockNumber, err)
+	}
+	if pricesBlock > blockNumber {
+		return fmt.Errorf("The execution client returned state from block %d or later when asked for block %d; an archive node is required to read it", pricesBlock, blockNumber)
+	}
+	return nil
+
+}
@@ -0,0 +1,28 @@
+package watchtower
+
+import (
+	"testing"
+)
+
+func TestIsOutsideNonArchiveWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		latestBlock uint64
+		blockNumber uint64
+		want        bool
+	}{
+		{"latest block", 1000, 1000, false},
+		{"recent block", 1000, 990, false},
+		{"last block in the window", 1000, 1000 - nonArchiveStateBlocks + 1, false},
+		{"first block outside the window", 1000, 1000 - nonArchiveStateBlocks, true},
+		{"backfill block", 1000, 100, true},
+		{"block ahead of the client", 1000, 1005, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isOutsideNonArchiveWindow(test.latestBlock, test.blockNumber); got != test.want {
+				t.Errorf("isOutsideNonArchiveWindow(%d, %d) = %t, want %t", test.latestBlock, test.blockNumber, got, test.want)
+			}
+		})
+	}
+}
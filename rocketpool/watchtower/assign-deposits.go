@@ -0,0 +1,220 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Assign deposits task
+type assignDeposits struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	rp  *rocketpool.RocketPool
+}
+
+// Create assign deposits task
+func newAssignDeposits(c *cli.Context, logger log.ColorLogger) (*assignDeposits, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &assignDeposits{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+	}, nil
+
+}
+
+// Assign deposits from the deposit pool to queued minipools
+func (t *assignDeposits) run() error {
+
+	// Check if the task is enabled
+	maxTxs := t.cfg.Smartnode.MaxDepositAssignmentTxs.Value.(uint64)
+	if maxTxs == 0 {
+		return nil
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Checking for deposits to assign...")
+
+	// Assign deposits
+	_, err := runAssignments(maxTxs, t.canAssignDeposits, t.assignDeposits)
+	return err
+
+}
+
+// Submit assignment transactions until nothing more can be assigned, the gas check rejects one, or maxTxs have been
+// submitted, returning the number that were submitted
+func runAssignments(maxTxs uint64, canAssign func() (bool, error), assign func() (bool, error)) (uint64, error) {
+
+	submitted := uint64(0)
+	for submitted < maxTxs {
+
+		// Check if any assignments can be made
+		ok, err := canAssign()
+		if err != nil {
+			return submitted, err
+		}
+		if !ok {
+			break
+		}
+
+		// Assign deposits, stopping if the gas is too expensive since it won't be any cheaper on the next attempt
+		sent, err := assign()
+		if err != nil {
+			return submitted, fmt.Errorf("Could not assign deposits: %w", err)
+		}
+		if !sent {
+			break
+		}
+		submitted++
+
+	}
+
+	return submitted, nil
+
+}
+
+// Check whether the deposit pool can currently assign a deposit to a queued minipool
+func (t *assignDeposits) canAssignDeposits() (bool, error) {
+
+	// Data
+	var wg errgroup.Group
+	var assignDepositsEnabled bool
+	var balance *big.Int
+	var halfQueueLength uint64
+	var fullQueueLength uint64
+	var maxAssignments uint64
+
+	// Get data
+	wg.Go(func() error {
+		var err error
+		assignDepositsEnabled, err = protocol.GetAssignDepositsEnabled(t.rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		balance, err = deposit.GetBalance(t.rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		halfQueueLength, err = minipool.GetQueueLength(t.rp, types.Half, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		fullQueueLength, err = minipool.GetQueueLength(t.rp, types.Full, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		maxAssignments, err = protocol.GetMaximumDepositAssignments(t.rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return false, err
+	}
+
+	// Nothing to assign if assignments are disabled or the deposit pool can't cover any of the queue
+	if !assignDepositsEnabled {
+		return false, nil
+	}
+	half, full := rputils.GetAssignableDeposits(balance, halfQueueLength, fullQueueLength, maxAssignments)
+	if half+full == 0 {
+		return false, nil
+	}
+
+	t.log.Printlnf("The deposit pool has %.6f ETH, enough to assign %d half and %d full deposits from the queue.", eth.WeiToEth(balance), half, full)
+	return true, nil
+
+}
+
+// Submit an assign deposits transaction, returning false if the gas check rejected it
+func (t *assignDeposits) assignDeposits() (bool, error) {
+
+	// Log
+	t.log.Println("Assigning deposits...")
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	gasInfo, err := deposit.EstimateAssignDepositsGas(t.rp, opts)
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required to assign deposits: %w", err)
+	}
+
+	// Print the gas info
+	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
+		return false, nil
+	}
+
+	// Set the gas settings
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = eth.GweiToWei(WatchtowerMaxPriorityFee)
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Assign deposits
+	hash, err := deposit.AssignDeposits(t.rp, opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	t.log.Println("Successfully assigned deposits.")
+
+	// Return
+	return true, nil
+
+}
@@ -0,0 +1,63 @@
+package watchtower
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunAssignments(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxTxs        uint64
+		assignable    int
+		gasRejectedAt int
+		canAssignErr  error
+		wantSubmitted uint64
+		wantAttempts  int
+		wantErr       bool
+	}{
+		{"nothing to assign", 3, 0, -1, nil, 0, 0, false},
+		{"assignments available", 3, 2, -1, nil, 2, 2, false},
+		{"capped by max transactions", 3, 10, -1, nil, 3, 3, false},
+		{"gas check rejects the first", 3, 10, 0, nil, 0, 1, false},
+		{"gas check rejects a later one", 3, 10, 1, nil, 1, 2, false},
+		{"pool state unavailable", 3, 10, -1, errors.New("no state"), 0, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			canAssign := func() (bool, error) {
+				return attempts < test.assignable, test.canAssignErr
+			}
+			assign := func() (bool, error) {
+				attempts++
+				return attempts-1 != test.gasRejectedAt, nil
+			}
+
+			submitted, err := runAssignments(test.maxTxs, canAssign, assign)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("runAssignments() error = %v, want error %t", err, test.wantErr)
+			}
+			if submitted != test.wantSubmitted || attempts != test.wantAttempts {
+				t.Errorf("runAssignments() submitted %d in %d attempts, want %d in %d", submitted, attempts, test.wantSubmitted, test.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRunAssignmentsStopsOnError(t *testing.T) {
+	attempts := 0
+	canAssign := func() (bool, error) {
+		return true, nil
+	}
+	assign := func() (bool, error) {
+		attempts++
+		return false, errors.New("reverted")
+	}
+	if _, err := runAssignments(3, canAssign, assign); err == nil {
+		t.Fatal("runAssignments() should return the assignment error")
+	}
+	if attempts != 1 {
+		t.Errorf("runAssignments() made %d attempts after an error, want 1", attempts)
+	}
+}
@@ -0,0 +1,127 @@
+package watchtower
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// The action taken at a submission decision point
+type auditAction string
+
+const (
+	auditSubmit auditAction = "submit"
+	auditSkip   auditAction = "skip"
+	auditDefer  auditAction = "defer"
+)
+
+// A single submission decision, written as one line of the audit log
+type auditRecord struct {
+	Time        time.Time         `json:"time"`
+	Task        string            `json:"task"`
+	Block       uint64            `json:"block"`
+	Submittable bool              `json:"submittable"`
+	Sources     map[string]string `json:"sources,omitempty"`
+	Value       string            `json:"value,omitempty"`
+	Deviation   *float64          `json:"deviation,omitempty"`
+	Action      auditAction       `json:"action"`
+	Reason      string            `json:"reason"`
+	Clients     *clientVersions   `json:"clients,omitempty"`
+}
+
+// Appends submission decisions to a JSON lines file for machine analysis, separately from the human log
+type auditLogger struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a new audit logger that writes to the given file
+func newAuditLogger(path string) *auditLogger {
+	return &auditLogger{
+		path: path,
+	}
+}
+
+// Append a record to the audit log
+func (l *auditLogger) record(record *auditRecord) error {
+
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("Error serializing audit record: %w", err)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("Error creating audit log folder: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("Error opening audit log: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("Error writing audit record: %w", err)
+	}
+	return nil
+
+}
+
+// Remove the records written before a cutoff, returning the number removed.
+// Lines that can't be parsed are kept so a damaged record is never silently lost.
+func (l *auditLogger) prune(olderThan time.Time) (int, error) {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	data, err := ioutil.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("Error reading audit log: %w", err)
+	}
+
+	// Keep the records at or after the cutoff
+	kept := [][]byte{}
+	pruned := 0
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var record auditRecord
+		if err := json.Unmarshal(line, &record); err == nil && record.Time.Before(olderThan) {
+			pruned++
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+
+	// Write the remaining records to a temporary file and move it into place so a crash can't truncate the log
+	contents := []byte{}
+	for _, line := range kept {
+		contents = append(append(contents, line...), '\n')
+	}
+	tempPath := l.path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, contents, 0644); err != nil {
+		return 0, fmt.Errorf("Error writing pruned audit log: %w", err)
+	}
+	if err := os.Rename(tempPath, l.path); err != nil {
+		_ = os.Remove(tempPath)
+		return 0, fmt.Errorf("Error saving pruned audit log: %w", err)
+	}
+	return pruned, nil
+
+}
@@ -0,0 +1,58 @@
+package watchtower
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAuditLoggerRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The folder is created on the first record
+	logger := newAuditLogger(filepath.Join(dir, "watchtower", "audit.jsonl"))
+	deviation := 0.5
+	records := []*auditRecord{
+		{Task: "prices", Block: 100, Submittable: true, Sources: map[string]string{"1inch": "1000"}, Value: "1000", Deviation: &deviation, Action: auditSubmit, Reason: "new checkpoint"},
+		{Task: "prices", Block: 100, Action: auditSkip, Reason: "already submitted"},
+	}
+	for _, record := range records {
+		if err := logger.record(record); err != nil {
+			t.Fatalf("record() returned an error: %s", err)
+		}
+	}
+
+	file, err := os.Open(logger.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	lines := 0
+	for ; scanner.Scan(); lines++ {
+		var record auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line %d isn't a JSON record: %s", lines+1, err)
+		}
+		if lines >= len(records) {
+			continue
+		}
+		want := records[lines]
+		if record.Action != want.Action || record.Reason != want.Reason || record.Block != want.Block {
+			t.Errorf("line %d = %+v, want %+v", lines+1, record, *want)
+		}
+		if record.Time.IsZero() {
+			t.Errorf("line %d has no time", lines+1)
+		}
+	}
+	if lines != len(records) {
+		t.Errorf("audit log has %d lines, want %d", lines, len(records))
+	}
+}
@@ -0,0 +1,221 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpstate "github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Aggregate results of comparing locally computed prices against the ones that reached consensus
+type backtestStats struct {
+	Compared         uint64  `yaml:"compared"`
+	Skipped          uint64  `yaml:"skipped"`
+	OutsideTolerance uint64  `yaml:"outsideTolerance"`
+	MeanDeviation    float64 `yaml:"meanDeviation"` // Mean absolute relative difference, in percent
+	MaxDeviation     float64 `yaml:"maxDeviation"`  // Largest absolute relative difference, in percent
+	MaxDeviationAt   uint64  `yaml:"maxDeviationAt"`
+}
+
+// A checkpoint that couldn't be compared
+type backfillFailure struct {
+	Block uint64 `yaml:"block"`
+	Error string `yaml:"error"`
+}
+
+// The progress of a backtest run, saved after each checkpoint so an interrupted run can be resumed
+type backfillState struct {
+	FromBlock     uint64            `yaml:"fromBlock"`
+	ToBlock       uint64            `yaml:"toBlock"`
+	Tolerance     float64           `yaml:"tolerance"`
+	LastCompleted uint64            `yaml:"lastCompleted"`
+	Stats         backtestStats     `yaml:"stats"`
+	Failures      []backfillFailure `yaml:"failures"`
+}
+
+// Load the saved progress of a previous run
+func loadBackfillState(store rpstate.StateStore) (*backfillState, bool, error) {
+	data, exists, err := store.Get(config.WatchtowerBacktestStateFile)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	state := &backfillState{}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, false, fmt.Errorf("Error deserializing backtest state: %w", err)
+	}
+	return state, true, nil
+}
+
+// Save the progress of the current run
+func (s *backfillState) save(store rpstate.StateStore) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("Error serializing backtest state: %w", err)
+	}
+	return store.Set(config.WatchtowerBacktestStateFile, data)
+}
+
+// Add a comparison for a checkpoint to the stats
+func (s *backtestStats) add(checkpoint uint64, comparison priceComparison) {
+	total := s.MeanDeviation * float64(s.Compared)
+	s.Compared++
+	s.MeanDeviation = (total + comparison.RelativeDiff) / float64(s.Compared)
+	if comparison.RelativeDiff > s.MaxDeviation || s.Compared == 1 {
+		s.MaxDeviation = comparison.RelativeDiff
+		s.MaxDeviationAt = checkpoint
+	}
+	if !comparison.Pass {
+		s.OutsideTolerance++
+	}
+}
+
+// Print the stats
+func (s *backtestStats) print(tolerance float64) {
+	fmt.Printf("Checkpoints compared:    %d\n", s.Compared)
+	fmt.Printf("Checkpoints skipped:     %d\n", s.Skipped)
+	if s.Compared == 0 {
+		return
+	}
+	fmt.Printf("Mean absolute deviation: %.4f%%\n", s.MeanDeviation)
+	fmt.Printf("Max deviation:           %.4f%% (block %d)\n", s.MaxDeviation, s.MaxDeviationAt)
+	fmt.Printf("Outside tolerance:       %d (tolerance %.4f%%)\n", s.OutsideTolerance, tolerance)
+}
+
+// Compare the prices the configured oracles would have submitted for each checkpoint in a range against the
+// prices that reached consensus.
+// Checkpoints that fail are recorded and skipped. Progress is saved after each checkpoint; with resume set, the
+// range, tolerance and results of the previous run are loaded and it continues after the last completed checkpoint.
+func backtestPrices(c *cli.Context, fromBlock uint64, toBlock uint64, tolerance float64, resume bool) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	store, err := services.GetStateStore(c)
+	if err != nil {
+		return err
+	}
+
+	// Load the previous run
+	var state *backfillState
+	if resume {
+		var exists bool
+		state, exists, err = loadBackfillState(store)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("There is no interrupted backtest to resume.")
+		}
+		fromBlock = state.FromBlock
+		toBlock = state.ToBlock
+		tolerance = state.Tolerance
+	}
+
+	// Get the checkpoints
+	frequency, err := protocol.GetSubmitPricesFrequency(rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting prices submission frequency: %w", err)
+	}
+	if err := checkSubmitFrequency(frequency); err != nil {
+		return err
+	}
+	latestBlock, err := ec.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("Error getting latest block: %w", err)
+	}
+	if toBlock == 0 || toBlock > latestBlock {
+		toBlock = latestBlock
+	}
+	if fromBlock > toBlock {
+		return fmt.Errorf("The start block (%d) is after the end block (%d).", fromBlock, toBlock)
+	}
+	if state == nil {
+		state = &backfillState{
+			FromBlock: fromBlock,
+			ToBlock:   toBlock,
+			Tolerance: tolerance,
+		}
+	}
+	checkpoints := getCheckpointBlocks(fromBlock, toBlock, frequency)
+	if state.LastCompleted > 0 {
+		remaining := getCheckpointBlocks(state.LastCompleted+1, toBlock, frequency)
+		fmt.Printf("Resuming after block %d; %d of %d checkpoints remaining.\n\n", state.LastCompleted, len(remaining), len(checkpoints))
+		checkpoints = remaining
+	} else {
+		fmt.Printf("Backtesting %d checkpoints between blocks %d and %d (every %d blocks).\n\n", len(checkpoints), fromBlock, toBlock, frequency)
+	}
+
+	// Compare each checkpoint
+	for _, checkpoint := range checkpoints {
+		if err := backtestCheckpoint(c, rp, cfg, state, checkpoint, frequency, latestBlock); err != nil {
+			fmt.Printf("Block %d: %s\n", checkpoint, err.Error())
+			state.Failures = append(state.Failures, backfillFailure{
+				Block: checkpoint,
+				Error: err.Error(),
+			})
+		}
+		state.LastCompleted = checkpoint
+		if err := state.save(store); err != nil {
+			return err
+		}
+	}
+
+	// Print the results
+	fmt.Println()
+	state.Stats.print(tolerance)
+	if len(state.Failures) > 0 {
+		fmt.Printf("Checkpoints failed:      %d\n", len(state.Failures))
+		for _, failure := range state.Failures {
+			fmt.Printf("\tBlock %d: %s\n", failure.Block, failure.Error)
+		}
+	}
+
+	// Clear the saved progress now that the run is complete
+	return store.Delete(config.WatchtowerBacktestStateFile)
+
+}
+
+// Compare the price for a single checkpoint, adding the result to the run's stats
+func backtestCheckpoint(c *cli.Context, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, state *backfillState, checkpoint uint64, frequency uint64, latestBlock uint64) error {
+
+	consensusPrice, found, err := getConsensusPrice(rp, checkpoint, frequency, latestBlock)
+	if err != nil {
+		return fmt.Errorf("Error getting the consensus price: %w", err)
+	}
+	if !found {
+		fmt.Printf("Block %d: no consensus price, skipping.\n", checkpoint)
+		state.Stats.Skipped++
+		return nil
+	}
+
+	localPrice, err := getRplPriceAtBlock(c, rp, cfg, checkpoint, func(message string) {})
+	if err != nil {
+		return err
+	}
+
+	comparison := comparePrices(consensusPrice, localPrice, state.Tolerance)
+	state.Stats.add(checkpoint, comparison)
+	fmt.Printf("Block %d: consensus %s wei, local %s wei, deviation %.4f%%\n", checkpoint, consensusPrice.String(), localPrice.String(), comparison.RelativeDiff)
+	return nil
+
+}
@@ -0,0 +1,72 @@
+package watchtower
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	rpstate "github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+func TestBacktestStatsAdd(t *testing.T) {
+	stats := backtestStats{}
+	stats.add(100, priceComparison{RelativeDiff: 0.5, Pass: true})
+	stats.add(200, priceComparison{RelativeDiff: 2.5, Pass: false})
+	stats.add(300, priceComparison{RelativeDiff: 0, Pass: true})
+
+	if stats.Compared != 3 {
+		t.Errorf("Compared = %d, want 3", stats.Compared)
+	}
+	if stats.OutsideTolerance != 1 {
+		t.Errorf("OutsideTolerance = %d, want 1", stats.OutsideTolerance)
+	}
+	if math.Abs(stats.MeanDeviation-1) > 1e-9 {
+		t.Errorf("MeanDeviation = %f, want 1", stats.MeanDeviation)
+	}
+	if stats.MaxDeviation != 2.5 || stats.MaxDeviationAt != 200 {
+		t.Errorf("max deviation = %f at block %d, want 2.5 at block 200", stats.MaxDeviation, stats.MaxDeviationAt)
+	}
+}
+
+func TestBacktestStatsFirstComparison(t *testing.T) {
+	// A first comparison with no deviation still sets where the max was seen
+	stats := backtestStats{}
+	stats.add(100, priceComparison{RelativeDiff: 0, Pass: true})
+	if stats.MaxDeviationAt != 100 {
+		t.Errorf("MaxDeviationAt = %d, want 100", stats.MaxDeviationAt)
+	}
+}
+
+func TestBackfillStateSaveAndLoad(t *testing.T) {
+	store := rpstate.NewMemoryStateStore()
+
+	// Nothing is loaded before the first save
+	if _, exists, err := loadBackfillState(store); err != nil || exists {
+		t.Fatalf("loadBackfillState() = (%t, %v), want (false, nil) with no saved state", exists, err)
+	}
+
+	state := &backfillState{
+		FromBlock:     1000,
+		ToBlock:       5000,
+		Tolerance:     1.5,
+		LastCompleted: 3000,
+		Stats: backtestStats{
+			Compared:       2,
+			MeanDeviation:  0.25,
+			MaxDeviation:   0.5,
+			MaxDeviationAt: 2000,
+		},
+		Failures: []backfillFailure{{Block: 1000, Error: "missing trie node"}},
+	}
+	if err := state.save(store); err != nil {
+		t.Fatalf("save() returned an error: %s", err)
+	}
+
+	loaded, exists, err := loadBackfillState(store)
+	if err != nil || !exists {
+		t.Fatalf("loadBackfillState() = (%t, %v), want (true, nil) after a save", exists, err)
+	}
+	if !reflect.DeepEqual(loaded, state) {
+		t.Errorf("loadBackfillState() = %+v, want %+v", loaded, state)
+	}
+}
@@ -0,0 +1,159 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+)
+
+// Latency statistics for a single benchmark target
+type latencyStats struct {
+	Name    string        `json:"name"`
+	Samples int           `json:"samples"`
+	Errors  int           `json:"errors"`
+	Min     time.Duration `json:"min"`
+	Avg     time.Duration `json:"avg"`
+	P95     time.Duration `json:"p95"`
+}
+
+// Accumulates latency samples for a benchmark target
+type latencyAccumulator struct {
+	name      string
+	durations []time.Duration
+	errors    int
+}
+
+// Record a successful sample
+func (a *latencyAccumulator) add(d time.Duration) {
+	a.durations = append(a.durations, d)
+}
+
+// Record a failed sample
+func (a *latencyAccumulator) addError() {
+	a.errors++
+}
+
+// Compute the min, average, and 95th percentile latencies of the recorded samples
+func (a *latencyAccumulator) stats() latencyStats {
+
+	stats := latencyStats{
+		Name:    a.name,
+		Samples: len(a.durations),
+		Errors:  a.errors,
+	}
+	if len(a.durations) == 0 {
+		return stats
+	}
+
+	sorted := make([]time.Duration, len(a.durations))
+	copy(sorted, a.durations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	// Nearest-rank percentile
+	p95Index := (len(sorted)*95+99)/100 - 1
+	if p95Index < 0 {
+		p95Index = 0
+	}
+
+	stats.Min = sorted[0]
+	stats.Avg = total / time.Duration(len(sorted))
+	stats.P95 = sorted[p95Index]
+	return stats
+
+}
+
+// Time GetRate calls against each configured price oracle, and a plain RPC call as a baseline
+func benchmarkOracle(c *cli.Context, iterations uint64, printJson bool) error {
+
+	if iterations == 0 {
+		return fmt.Errorf("iterations must be greater than 0")
+	}
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	oracles, err := getPriceOracles(cfg)
+	if err != nil {
+		return err
+	}
+	if err := requirePriceOracles(c, oracles); err != nil {
+		return err
+	}
+
+	// Pin every call to the same block so the oracles do the same work each time
+	blockNumber, err := ec.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("Error getting latest block number: %w", err)
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(blockNumber),
+	}
+
+	// Benchmark the underlying RPC
+	rpc := &latencyAccumulator{name: "rpc (eth_blockNumber)"}
+	for i := uint64(0); i < iterations; i++ {
+		start := time.Now()
+		_, err := ec.BlockNumber(context.Background())
+		if err != nil {
+			rpc.addError()
+			continue
+		}
+		rpc.add(time.Since(start))
+	}
+	results := []latencyStats{rpc.stats()}
+
+	// Benchmark each oracle
+	for _, oracle := range oracles {
+		acc := &latencyAccumulator{name: oracle.Name()}
+		for i := uint64(0); i < iterations; i++ {
+			start := time.Now()
+			_, _, err := oracle.GetRate(ec, opts)
+			if err != nil {
+				acc.addError()
+				continue
+			}
+			acc.add(time.Since(start))
+		}
+		results = append(results, acc.stats())
+	}
+
+	// Print the results
+	if printJson {
+		bytes, err := json.MarshalIndent(results, "", "    ")
+		if err != nil {
+			return fmt.Errorf("Error serializing benchmark results: %w", err)
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+
+	fmt.Printf("Benchmarked %d iterations at block %d.\n\n", iterations, blockNumber)
+	fmt.Printf("%-24s %8s %8s %12s %12s %12s\n", "Source", "Samples", "Errors", "Min", "Avg", "P95")
+	for _, result := range results {
+		fmt.Printf("%-24s %8d %8d %12s %12s %12s\n", result.Name, result.Samples, result.Errors,
+			result.Min.Round(time.Microsecond), result.Avg.Round(time.Microsecond), result.P95.Round(time.Microsecond))
+	}
+	return nil
+
+}
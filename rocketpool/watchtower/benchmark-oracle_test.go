@@ -0,0 +1,47 @@
+package watchtower
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyAccumulatorStats(t *testing.T) {
+	ms := func(values ...int) []time.Duration {
+		durations := make([]time.Duration, len(values))
+		for i, value := range values {
+			durations[i] = time.Duration(value) * time.Millisecond
+		}
+		return durations
+	}
+	hundred := make([]int, 100)
+	for i := range hundred {
+		hundred[i] = 100 - i
+	}
+
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		errors    int
+		want      latencyStats
+	}{
+		{"no samples", nil, 0, latencyStats{Name: "test"}},
+		{"only errors", nil, 3, latencyStats{Name: "test", Errors: 3}},
+		{"single sample", ms(40), 0, latencyStats{Name: "test", Samples: 1, Min: 40 * time.Millisecond, Avg: 40 * time.Millisecond, P95: 40 * time.Millisecond}},
+		{"unsorted samples", ms(30, 10, 20), 1, latencyStats{Name: "test", Samples: 3, Errors: 1, Min: 10 * time.Millisecond, Avg: 20 * time.Millisecond, P95: 30 * time.Millisecond}},
+		{"nearest-rank percentile", ms(hundred...), 0, latencyStats{Name: "test", Samples: 100, Min: time.Millisecond, Avg: 50500 * time.Microsecond, P95: 95 * time.Millisecond}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			accumulator := &latencyAccumulator{name: "test"}
+			for _, d := range test.durations {
+				accumulator.add(d)
+			}
+			for i := 0; i < test.errors; i++ {
+				accumulator.addError()
+			}
+			if got := accumulator.stats(); got != test.want {
+				t.Errorf("stats() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package watchtower
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the RocketStorage keys that record when a member was challenged and who challenged them
+func challengeStorageKeys(memberAddress common.Address) (common.Hash, common.Hash) {
+	timeKey := crypto.Keccak256Hash([]byte("dao.trustednodes.member.challenged.time"), memberAddress.Bytes())
+	byKey := crypto.Keccak256Hash([]byte("dao.trustednodes.member.challenged.by"), memberAddress.Bytes())
+	return timeKey, byKey
+}
+
+// Get the time a challenge must be responded to by; after this anyone can decide it and remove the member
+func getChallengeDeadline(challengeTime uint64, challengeWindow uint64) time.Time {
+	return time.Unix(int64(challengeTime+challengeWindow), 0)
+}
+
+// Get the earliest time to respond to a challenge: the given fraction of the way into the window, but no later than
+// the margin before the deadline so there's time to retry a failed response
+func getChallengeResponseTime(challengeTime uint64, challengeWindow uint64, earliestFraction float64, latestMargin uint64) time.Time {
+	earliest := time.Unix(int64(challengeTime), 0).Add(time.Duration(earliestFraction * float64(challengeWindow) * float64(time.Second)))
+	deadline := getChallengeDeadline(challengeTime, challengeWindow)
+	latest := deadline.Add(-time.Duration(latestMargin) * time.Second)
+	if latest.Before(earliest) {
+		return latest
+	}
+	return earliest
+}
+
+// Get the active challenge against the node, if there is one
+func getChallenges(c *cli.Context) (*api.ChallengesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ChallengesResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+
+	// Check the node's membership and challenge status
+	response.IsMember, err = trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !response.IsMember {
+		return &response, nil
+	}
+	response.IsChallenged, err = trustednode.GetMemberIsChallenged(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !response.IsChallenged {
+		return &response, nil
+	}
+
+	// Get the challenge details
+	timeKey, byKey := challengeStorageKeys(nodeAccount.Address)
+	challengeTime, err := rp.RocketStorage.GetUint(nil, timeKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting challenge time: %w", err)
+	}
+	response.ChallengedBy, err = rp.RocketStorage.GetAddress(nil, byKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting challenger address: %w", err)
+	}
+	response.ChallengeWindow, err = tnsettings.GetChallengeWindow(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting challenge window: %w", err)
+	}
+	response.ChallengeTime = time.Unix(challengeTime.Int64(), 0)
+	response.ResponseDeadline = getChallengeDeadline(challengeTime.Uint64(), response.ChallengeWindow)
+
+	// Check if the node can respond to the challenge
+	response.CanResolve, response.GasInfo = canResolveChallenge(rp, w, nodeAccount.Address)
+	return &response, nil
+
+}
+
+// Check if the node can respond to a challenge by simulating the response
+func canResolveChallenge(rp *rocketpool.RocketPool, w *wallet.Wallet, nodeAddress common.Address) (bool, rocketpool.GasInfo) {
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, rocketpool.GasInfo{}
+	}
+	gasInfo, err := trustednode.EstimateDecideChallengeGas(rp, nodeAddress, opts)
+	if err != nil {
+		return false, rocketpool.GasInfo{}
+	}
+	return true, gasInfo
+}
+
+// Print the active challenge against the node, if there is one
+func printChallenges(c *cli.Context, printJson bool) error {
+
+	response, err := getChallenges(c)
+	if err != nil {
+		return err
+	}
+
+	// Print
+	if printJson {
+		bytes, err := json.MarshalIndent(response, "", "    ")
+		if err != nil {
+			return fmt.Errorf("Error serializing challenges: %w", err)
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+	if !response.IsMember {
+		fmt.Printf("Node %s is not a member of the Oracle DAO.\n", response.NodeAddress.Hex())
+		return nil
+	}
+	if !response.IsChallenged {
+		fmt.Printf("There are no active challenges against node %s.\n", response.NodeAddress.Hex())
+		return nil
+	}
+	fmt.Printf("Node %s has an active challenge against it.\n", response.NodeAddress.Hex())
+	fmt.Printf("Challenged by:     %s\n", response.ChallengedBy.Hex())
+	fmt.Printf("Challenged at:     %s\n", response.ChallengeTime.Format(time.RFC1123))
+	fmt.Printf("Response deadline: %s", response.ResponseDeadline.Format(time.RFC1123))
+	if remaining := time.Until(response.ResponseDeadline); remaining > 0 {
+		fmt.Printf(" (%s remaining)\n", remaining.Round(time.Second))
+	} else {
+		fmt.Println(" (passed; the node can be removed from the Oracle DAO)")
+	}
+	if response.CanResolve {
+		fmt.Printf("The node can respond to the challenge now (estimated gas: %d).\n", response.GasInfo.EstGasLimit)
+		fmt.Println("The watchtower will respond automatically on its next run.")
+	} else {
+		fmt.Println("The node cannot currently respond to the challenge.")
+	}
+	return nil
+
+}
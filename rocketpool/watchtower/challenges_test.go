@@ -0,0 +1,59 @@
+package watchtower
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestChallengeStorageKeys(t *testing.T) {
+	member := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	timeKey, byKey := challengeStorageKeys(member)
+
+	// The keys are keccak256(abi.encodePacked(name, memberAddress))
+	wantTime := crypto.Keccak256Hash(append([]byte("dao.trustednodes.member.challenged.time"), member.Bytes()...))
+	wantBy := crypto.Keccak256Hash(append([]byte("dao.trustednodes.member.challenged.by"), member.Bytes()...))
+	if timeKey != wantTime {
+		t.Errorf("time key = %s, want %s", timeKey.Hex(), wantTime.Hex())
+	}
+	if byKey != wantBy {
+		t.Errorf("challenger key = %s, want %s", byKey.Hex(), wantBy.Hex())
+	}
+}
+
+func TestGetChallengeDeadline(t *testing.T) {
+	const challengeTime uint64 = 1650000000
+	const challengeWindow uint64 = 7 * 24 * 60 * 60
+	want := time.Unix(1650000000, 0).Add(7 * 24 * time.Hour)
+	if got := getChallengeDeadline(challengeTime, challengeWindow); !got.Equal(want) {
+		t.Errorf("getChallengeDeadline() = %s, want %s", got, want)
+	}
+}
+
+func TestGetChallengeResponseTime(t *testing.T) {
+	const challengeTime uint64 = 1000000
+	tests := []struct {
+		name             string
+		challengeWindow  uint64
+		earliestFraction float64
+		latestMargin     uint64
+		want             uint64
+	}{
+		{"fraction of the window", 1000, 0.5, 100, challengeTime + 500},
+		{"start of the window", 1000, 0, 100, challengeTime},
+		{"capped by the margin", 1000, 0.95, 100, challengeTime + 900},
+		{"at the margin", 1000, 0.9, 100, challengeTime + 900},
+		{"margin wider than the window", 1000, 0.5, 2000, challengeTime - 1000},
+		{"no margin", 1000, 1, 0, challengeTime + 1000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := getChallengeResponseTime(challengeTime, test.challengeWindow, test.earliestFraction, test.latestMargin)
+			if want := time.Unix(int64(test.want), 0); !got.Equal(want) {
+				t.Errorf("getChallengeResponseTime() = %d, want %d", got.Unix(), want.Unix())
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package watchtower
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A change in the node's Oracle DAO membership between two loops
+type membershipTransition int
+
+const (
+	membershipUnchanged membershipTransition = iota
+	membershipGained
+	membershipLost
+)
+
+// Get the membership transition between the previous and current trusted status.
+// There is no transition if there was no previous status to compare against.
+func getMembershipTransition(previous *bool, current bool) membershipTransition {
+	if previous == nil || *previous == current {
+		return membershipUnchanged
+	}
+	if current {
+		return membershipGained
+	}
+	return membershipLost
+}
+
+// What the watchtower does after a membership transition
+type membershipAction int
+
+const (
+	membershipNoAction membershipAction = iota
+	membershipStartSubmitting
+	membershipStartMonitoring
+	membershipStopSubmitting
+)
+
+// Get what the watchtower does after a membership transition. A node that loses its membership keeps computing its
+// values for monitoring if read-only mode is enabled, and stops its Oracle DAO duties entirely if it isn't.
+func getMembershipAction(cfg *config.RocketPoolConfig, transition membershipTransition) membershipAction {
+	switch transition {
+	case membershipGained:
+		return membershipStartSubmitting
+	case membershipLost:
+		if isReadOnly(cfg, false) {
+			return membershipStartMonitoring
+		}
+		return membershipStopSubmitting
+	}
+	return membershipNoAction
+}
+
+// Check if the submission tasks should only compute their values for monitoring, which is the case when the node
+// isn't an Oracle DAO member and read-only mode has been enabled
+func isReadOnly(cfg *config.RocketPoolConfig, nodeTrusted bool) bool {
+	return !nodeTrusted && cfg.Smartnode.ReadOnlyMode.Value == true
+}
+
+// Check trusted membership task
+type checkTrustedMembership struct {
+	c          *cli.Context
+	log        log.ColorLogger
+	errLog     log.ColorLogger
+	cfg        *config.RocketPoolConfig
+	w          *wallet.Wallet
+	rp         *rocketpool.RocketPool
+	alerter    *alerting.Alerter
+	wasTrusted *bool
+}
+
+// Create check trusted membership task
+func newCheckTrustedMembership(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*checkTrustedMembership, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkTrustedMembership{
+		c:       c,
+		log:     logger,
+		errLog:  errorLogger,
+		cfg:     cfg,
+		w:       w,
+		rp:      rp,
+		alerter: alerting.NewAlerter(cfg),
+	}, nil
+
+}
+
+// Check for changes in the node's trusted membership
+func (t *checkTrustedMembership) run() error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check node trusted status
+	nodeTrusted, err := trustednode.GetMemberExists(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	action := getMembershipAction(t.cfg, getMembershipTransition(t.wasTrusted, nodeTrusted))
+	t.wasTrusted = &nodeTrusted
+
+	// Report the transition
+	var title, message string
+	switch action {
+	case membershipNoAction:
+		return nil
+	case membershipStartSubmitting:
+		title = "Oracle DAO membership gained"
+		message = fmt.Sprintf("Node %s is now a member of the Oracle DAO; the watchtower will begin performing Oracle DAO duties.", nodeAccount.Address.Hex())
+		t.log.Println(message)
+	case membershipStartMonitoring:
+		title = "Oracle DAO membership revoked"
+		message = fmt.Sprintf("Node %s is no longer a member of the Oracle DAO! The watchtower will stop submitting and only compute prices and balances for monitoring.", nodeAccount.Address.Hex())
+		t.logRevocation(message)
+	case membershipStopSubmitting:
+		title = "Oracle DAO membership revoked"
+		message = fmt.Sprintf("Node %s is no longer a member of the Oracle DAO! The watchtower will stop submitting prices, balances, and other Oracle DAO duties.", nodeAccount.Address.Hex())
+		t.logRevocation(message)
+	}
+
+	if err := t.alerter.Alert(title, message); err != nil {
+		return fmt.Errorf("Error sending membership alert: %w", err)
+	}
+	return nil
+
+}
+
+// Log a lost membership prominently
+func (t *checkTrustedMembership) logRevocation(message string) {
+	t.errLog.Println("***************************************************************")
+	t.errLog.Println(message)
+	t.errLog.Println("***************************************************************")
+}
@@ -0,0 +1,96 @@
+package watchtower
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+func TestGetMembershipTransition(t *testing.T) {
+	trusted := true
+	untrusted := false
+	tests := []struct {
+		name     string
+		previous *bool
+		current  bool
+		want     membershipTransition
+	}{
+		{"first check as a member", nil, true, membershipUnchanged},
+		{"first check as a non-member", nil, false, membershipUnchanged},
+		{"still a member", &trusted, true, membershipUnchanged},
+		{"still a non-member", &untrusted, false, membershipUnchanged},
+		{"joined", &untrusted, true, membershipGained},
+		{"removed", &trusted, false, membershipLost},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getMembershipTransition(test.previous, test.current); got != test.want {
+				t.Errorf("getMembershipTransition() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		name         string
+		readOnlyMode bool
+		nodeTrusted  bool
+		want         bool
+	}{
+		{"member", true, true, false},
+		{"non-member", true, false, true},
+		{"member with read-only mode disabled", false, true, false},
+		{"non-member with read-only mode disabled", false, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.NewRocketPoolConfig("", false)
+			cfg.Smartnode.ReadOnlyMode.Value = test.readOnlyMode
+			if got := isReadOnly(cfg, test.nodeTrusted); got != test.want {
+				t.Errorf("isReadOnly(readOnlyMode=%t, nodeTrusted=%t) = %t, want %t", test.readOnlyMode, test.nodeTrusted, got, test.want)
+			}
+		})
+	}
+}
+
+func TestReadOnlyModeIsOptIn(t *testing.T) {
+	cfg := config.NewRocketPoolConfig("", false)
+	if isReadOnly(cfg, false) {
+		t.Error("a non-member shouldn't be in read-only mode with the default config")
+	}
+	cfg.Smartnode.ReadOnlyMode.Value = true
+	if !isReadOnly(cfg, false) {
+		t.Error("a non-member should be in read-only mode once it's enabled")
+	}
+}
+
+func TestGetMembershipAction(t *testing.T) {
+	trusted := true
+	untrusted := false
+	tests := []struct {
+		name         string
+		previous     *bool
+		current      bool
+		readOnlyMode bool
+		want         membershipAction
+	}{
+		{"first check", nil, true, false, membershipNoAction},
+		{"unchanged member", &trusted, true, false, membershipNoAction},
+		{"unchanged non-member", &untrusted, false, true, membershipNoAction},
+		{"gained", &untrusted, true, false, membershipStartSubmitting},
+		{"gained with read-only mode", &untrusted, true, true, membershipStartSubmitting},
+		{"lost with read-only mode", &trusted, false, true, membershipStartMonitoring},
+		{"lost", &trusted, false, false, membershipStopSubmitting},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.NewRocketPoolConfig("", false)
+			cfg.Smartnode.ReadOnlyMode.Value = test.readOnlyMode
+			transition := getMembershipTransition(test.previous, test.current)
+			if got := getMembershipAction(cfg, transition); got != test.want {
+				t.Errorf("getMembershipAction() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
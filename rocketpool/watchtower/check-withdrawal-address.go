@@ -0,0 +1,73 @@
+package watchtower
+
+import (
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Check withdrawal address task
+type checkWithdrawalAddress struct {
+	c        *cli.Context
+	log      log.ColorLogger
+	w        *wallet.Wallet
+	rp       *rocketpool.RocketPool
+	lastInfo *rputils.NodeWithdrawalInfo
+}
+
+// Create check withdrawal address task
+func newCheckWithdrawalAddress(c *cli.Context, logger log.ColorLogger) (*checkWithdrawalAddress, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkWithdrawalAddress{
+		c:   c,
+		log: logger,
+		w:   w,
+		rp:  rp,
+	}, nil
+
+}
+
+// Warn if the node's withdrawal address isn't the node address or has a pending change, whenever that changes
+func (t *checkWithdrawalAddress) run() error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the withdrawal info
+	info, err := rputils.GetNodeWithdrawalInfo(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	if t.lastInfo != nil && *t.lastInfo == *info {
+		return nil
+	}
+	t.lastInfo = info
+
+	// Warn about anything unusual
+	if !info.WithdrawalAddressIsNodeAddress {
+		t.log.Printlnf("NOTE: node %s has a separate withdrawal address, %s. Rewards and withdrawn funds will be sent there rather than to the node wallet.", nodeAccount.Address.Hex(), info.WithdrawalAddress.Hex())
+	}
+	if info.HasPendingChange {
+		t.log.Printlnf("WARNING: node %s has a pending change of its withdrawal address to %s that hasn't been confirmed. If you didn't start this change, check your node wallet's security.", nodeAccount.Address.Hex(), info.PendingWithdrawalAddress.Hex())
+	}
+	return nil
+
+}
@@ -0,0 +1,122 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often the client versions are refreshed, to pick up client upgrades and fallback switches
+const clientVersionRefreshInterval = time.Hour
+
+// The versions the Execution and Beacon clients report for themselves
+type clientVersions struct {
+	Execution string `json:"execution,omitempty"`
+	Consensus string `json:"consensus,omitempty"`
+}
+
+// Get the versions of the Execution and Beacon clients the watchtower is using
+func getClientVersions(c *cli.Context) (clientVersions, error) {
+
+	// Get services
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return clientVersions{}, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return clientVersions{}, err
+	}
+
+	// Get the versions
+	ecVersion, err := ec.ClientVersion(context.Background())
+	if err != nil {
+		return clientVersions{}, fmt.Errorf("Error getting Execution client version: %w", err)
+	}
+	bcVersion, err := bc.GetClientVersion()
+	if err != nil {
+		return clientVersions{}, fmt.Errorf("Error getting Beacon client version: %w", err)
+	}
+	return clientVersions{
+		Execution: ecVersion,
+		Consensus: bcVersion,
+	}, nil
+
+}
+
+// Split a client version string such as "Geth/v1.10.25-stable-69568c55/linux-amd64/go1.18.5" into the client name
+// and its version. The platform details that follow are dropped.
+func parseClientVersion(version string) (string, string) {
+	parts := strings.Split(strings.TrimSpace(version), "/")
+	name := strings.TrimSpace(parts[0])
+	if len(parts) < 2 {
+		return name, ""
+	}
+	return name, strings.TrimSpace(parts[1])
+}
+
+// Keeps the latest client versions for the audit log and metrics, refreshing them periodically
+type clientVersionTracker struct {
+	c        *cli.Context
+	log      log.ColorLogger
+	coll     *collectors.ClientCollector
+	versions clientVersions
+	updated  time.Time
+	lock     sync.Mutex
+}
+
+// Create a new client version tracker
+func newClientVersionTracker(c *cli.Context, logger log.ColorLogger, coll *collectors.ClientCollector) *clientVersionTracker {
+	return &clientVersionTracker{
+		c:    c,
+		log:  logger,
+		coll: coll,
+	}
+}
+
+// Refresh the client versions if they haven't been fetched within the refresh interval.
+// The last known versions are kept if they can't be fetched.
+func (t *clientVersionTracker) refresh() {
+
+	t.lock.Lock()
+	stale := time.Since(t.updated) >= clientVersionRefreshInterval
+	t.lock.Unlock()
+	if !stale {
+		return
+	}
+
+	versions, err := getClientVersions(t.c)
+	if err != nil {
+		t.log.Printlnf("WARNING: could not get the client versions: %s", err.Error())
+		return
+	}
+
+	t.lock.Lock()
+	t.versions = versions
+	t.updated = time.Now()
+	t.lock.Unlock()
+
+	ecName, ecVersion := parseClientVersion(versions.Execution)
+	bcName, bcVersion := parseClientVersion(versions.Consensus)
+	t.coll.SetVersions(ecName, ecVersion, bcName, bcVersion)
+
+}
+
+// Get the latest client versions, or nil if they haven't been fetched yet
+func (t *clientVersionTracker) get() *clientVersions {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.updated.IsZero() {
+		return nil
+	}
+	versions := t.versions
+	return &versions
+}
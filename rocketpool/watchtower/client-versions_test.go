@@ -0,0 +1,28 @@
+package watchtower
+
+import (
+	"testing"
+)
+
+func TestParseClientVersion(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantName    string
+		wantVersion string
+	}{
+		{"Geth/v1.10.25-stable-69568c55/linux-amd64/go1.18.5", "Geth", "v1.10.25-stable-69568c55"},
+		{"Nethermind/v1.14.3+0ef13bc9/linux-x64/dotnet6.0.9", "Nethermind", "v1.14.3+0ef13bc9"},
+		{"Lighthouse/v3.1.2-3d5a2ff/x86_64-linux", "Lighthouse", "v3.1.2-3d5a2ff"},
+		{" teku/v22.10.1/linux-x86_64/-eclipseadoptium-openjdk64bitservervm-java-17 \n", "teku", "v22.10.1"},
+		{"Prysm", "Prysm", ""},
+		{"", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			name, version := parseClientVersion(test.version)
+			if name != test.wantName || version != test.wantVersion {
+				t.Errorf("parseClientVersion(%q) = (%q, %q), want (%q, %q)", test.version, name, version, test.wantName, test.wantVersion)
+			}
+		})
+	}
+}
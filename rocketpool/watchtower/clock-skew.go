@@ -0,0 +1,43 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Check that the system clock is close to the timestamp of the latest Execution client block.
+// Blocks are only produced every few seconds, so the tolerance has to be well above the block time.
+func checkClockSkew(ec rocketpool.ExecutionClient, cfg *config.RocketPoolConfig) error {
+
+	maxSkew := time.Duration(cfg.Smartnode.MaxClockSkewSeconds.Value.(uint64)) * time.Second
+	if maxSkew == 0 {
+		return nil
+	}
+
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("could not get the latest block: %w", err)
+	}
+	return getClockSkewError(time.Now(), time.Unix(int64(header.Time), 0), maxSkew)
+
+}
+
+// Get an error describing the skew between the local time and a block time if it's larger than the max
+func getClockSkewError(localTime time.Time, blockTime time.Time, maxSkew time.Duration) error {
+	skew := localTime.Sub(blockTime)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew <= maxSkew {
+		return nil
+	}
+	if localTime.Before(blockTime) {
+		return fmt.Errorf("the system clock is %s behind the latest block's timestamp, beyond the %s limit", skew.Round(time.Second), maxSkew)
+	}
+	return fmt.Errorf("the system clock is %s ahead of the latest block's timestamp, beyond the %s limit", skew.Round(time.Second), maxSkew)
+}
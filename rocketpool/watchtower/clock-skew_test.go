@@ -0,0 +1,37 @@
+package watchtower
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetClockSkewError(t *testing.T) {
+	blockTime := time.Unix(1660000000, 0)
+	maxSkew := 30 * time.Second
+	tests := []struct {
+		name      string
+		localTime time.Time
+		want      string
+	}{
+		{"in sync", blockTime, ""},
+		{"ahead within the limit", blockTime.Add(maxSkew), ""},
+		{"behind within the limit", blockTime.Add(-maxSkew), ""},
+		{"ahead", blockTime.Add(time.Minute), "1m0s ahead"},
+		{"behind", blockTime.Add(-2 * time.Minute), "2m0s behind"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := getClockSkewError(test.localTime, blockTime, maxSkew)
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("getClockSkewError() returned an error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("getClockSkewError() = %v, want an error containing %q", err, test.want)
+			}
+		})
+	}
+}
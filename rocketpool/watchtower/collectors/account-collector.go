@@ -0,0 +1,37 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the node account metrics
+type AccountCollector struct {
+
+	// The ETH balance of the node account
+	balance prometheus.Gauge
+}
+
+// Create a new AccountCollector instance
+func NewAccountCollector() *AccountCollector {
+	return &AccountCollector{
+		balance: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "smartnode_account_balance_eth",
+			Help: "The ETH balance of the node account the watchtower submits transactions from",
+		}),
+	}
+}
+
+// Record the latest balance of the node account
+func (collector *AccountCollector) SetBalance(balance float64) {
+	collector.balance.Set(balance)
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *AccountCollector) Describe(channel chan<- *prometheus.Desc) {
+	collector.balance.Describe(channel)
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *AccountCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.balance.Collect(channel)
+}
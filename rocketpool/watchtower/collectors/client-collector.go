@@ -0,0 +1,39 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the client software metrics
+type ClientCollector struct {
+
+	// The name and version of the Execution and Beacon clients, reported as labels on a constant metric
+	info *prometheus.GaugeVec
+}
+
+// Create a new ClientCollector instance
+func NewClientCollector() *ClientCollector {
+	return &ClientCollector{
+		info: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "smartnode_watchtower_client_info",
+			Help: "The name and version of the clients the watchtower uses",
+		}, []string{"client", "name", "version"}),
+	}
+}
+
+// Update the reported client names and versions
+func (collector *ClientCollector) SetVersions(ecName string, ecVersion string, bcName string, bcVersion string) {
+	collector.info.Reset()
+	collector.info.WithLabelValues("execution", ecName, ecVersion).Set(1)
+	collector.info.WithLabelValues("consensus", bcName, bcVersion).Set(1)
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ClientCollector) Describe(channel chan<- *prometheus.Desc) {
+	collector.info.Describe(channel)
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ClientCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.info.Collect(channel)
+}
@@ -0,0 +1,53 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for Oracle DAO member participation
+type ParticipationCollector struct {
+
+	// The fraction of recent checkpoints each Oracle DAO member submitted for
+	participationDesc *prometheus.Desc
+
+	// Participation rates by submission type, then by member address
+	Participation map[string]map[string]float64
+
+	// Mutex
+	UpdateLock sync.Mutex
+}
+
+// Create a new ParticipationCollector instance
+func NewParticipationCollector() *ParticipationCollector {
+	return &ParticipationCollector{
+		participationDesc: prometheus.NewDesc("smartnode_odao_member_participation",
+			"The fraction of recent checkpoints each Oracle DAO member submitted for",
+			[]string{"member", "type"}, nil,
+		),
+		Participation: map[string]map[string]float64{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ParticipationCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.participationDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ParticipationCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	for submissionType, rates := range collector.Participation {
+		for member, rate := range rates {
+			channel <- prometheus.MustNewConstMetric(
+				collector.participationDesc, prometheus.GaugeValue, rate, member, submissionType)
+		}
+	}
+
+}
@@ -0,0 +1,38 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the RPL price metrics
+type PriceCollector struct {
+
+	// The relative deviation between the computed RPL price and the price currently on-chain
+	deviation prometheus.Histogram
+}
+
+// Create a new PriceCollector instance
+func NewPriceCollector() *PriceCollector {
+	return &PriceCollector{
+		deviation: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "smartnode_rpl_price_deviation",
+			Help:    "The relative deviation between the computed RPL price and the price currently on-chain",
+			Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.02, 0.03, 0.05, 0.075, 0.1, 0.2},
+		}),
+	}
+}
+
+// Record the deviation from a price evaluation
+func (collector *PriceCollector) ObserveDeviation(deviation float64) {
+	collector.deviation.Observe(deviation)
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *PriceCollector) Describe(channel chan<- *prometheus.Desc) {
+	collector.deviation.Describe(channel)
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *PriceCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.deviation.Collect(channel)
+}
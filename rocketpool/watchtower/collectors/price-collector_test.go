@@ -0,0 +1,40 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPriceCollectorObserveDeviation(t *testing.T) {
+	collector := NewPriceCollector()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+
+	collector.ObserveDeviation(0.004)
+	collector.ObserveDeviation(0.15)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(families) != 1 || families[0].GetName() != "smartnode_rpl_price_deviation" {
+		t.Fatalf("gathered %d metric families, want only smartnode_rpl_price_deviation", len(families))
+	}
+	histogram := families[0].GetMetric()[0].GetHistogram()
+	if histogram.GetSampleCount() != 2 {
+		t.Errorf("sample count = %d, want 2", histogram.GetSampleCount())
+	}
+	for _, bucket := range histogram.GetBucket() {
+		var want uint64
+		switch {
+		case bucket.GetUpperBound() >= 0.15:
+			want = 2
+		case bucket.GetUpperBound() >= 0.004:
+			want = 1
+		}
+		if bucket.GetCumulativeCount() != want {
+			t.Errorf("bucket %g has %d samples, want %d", bucket.GetUpperBound(), bucket.GetCumulativeCount(), want)
+		}
+	}
+}
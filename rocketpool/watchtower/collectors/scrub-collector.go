@@ -41,6 +41,12 @@ type ScrubCollector struct {
 	// The number of minipools that were scrubbed for safety because they failed the sanity checks
 	safetyScrubsDesc *prometheus.Desc
 
+	// The number of minipools that were scrubbed because their withdrawal credentials had the wrong prefix
+	wrongPrefixScrubsDesc *prometheus.Desc
+
+	// The number of minipools that were scrubbed because their withdrawal credentials had the wrong address
+	wrongAddressScrubsDesc *prometheus.Desc
+
 	// The time of the latest block that the check was run against
 	latestBlockTimeDesc *prometheus.Desc
 
@@ -55,6 +61,8 @@ type ScrubCollector struct {
 	DepositlessMinipools  float64
 	UncoveredMinipools    float64
 	SafetyScrubs          float64
+	WrongPrefixScrubs     float64
+	WrongAddressScrubs    float64
 	LatestBlockTime       float64
 
 	// Mutex
@@ -105,6 +113,14 @@ func NewScrubCollector() *ScrubCollector {
 			"The number of minipools that were scrubbed for safety because they failed the sanity checks",
 			nil, nil,
 		),
+		wrongPrefixScrubsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "wrong_prefix_scrubs"),
+			"The number of minipools that were scrubbed because their withdrawal credentials had the wrong prefix",
+			nil, nil,
+		),
+		wrongAddressScrubsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "wrong_address_scrubs"),
+			"The number of minipools that were scrubbed because their withdrawal credentials had the wrong address",
+			nil, nil,
+		),
 		latestBlockTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latest_block_time"),
 			"The time of the latest block that the check was run against",
 			nil, nil,
@@ -124,6 +140,8 @@ func (collector *ScrubCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.poolsWithoutDepositsDesc
 	channel <- collector.uncoveredMinipoolsDesc
 	channel <- collector.safetyScrubsDesc
+	channel <- collector.wrongPrefixScrubsDesc
+	channel <- collector.wrongAddressScrubsDesc
 }
 
 // Collect the latest metric values and pass them to Prometheus
@@ -154,6 +172,10 @@ func (collector *ScrubCollector) Collect(channel chan<- prometheus.Metric) {
 		collector.uncoveredMinipoolsDesc, prometheus.GaugeValue, collector.UncoveredMinipools)
 	channel <- prometheus.MustNewConstMetric(
 		collector.safetyScrubsDesc, prometheus.GaugeValue, collector.SafetyScrubs)
+	channel <- prometheus.MustNewConstMetric(
+		collector.wrongPrefixScrubsDesc, prometheus.GaugeValue, collector.WrongPrefixScrubs)
+	channel <- prometheus.MustNewConstMetric(
+		collector.wrongAddressScrubsDesc, prometheus.GaugeValue, collector.WrongAddressScrubs)
 	channel <- prometheus.MustNewConstMetric(
 		collector.latestBlockTimeDesc, prometheus.GaugeValue, collector.LatestBlockTime)
 
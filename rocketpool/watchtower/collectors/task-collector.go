@@ -0,0 +1,40 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the watchtower task metrics
+type TaskCollector struct {
+
+	// The time spent in each run of a task
+	duration *prometheus.HistogramVec
+}
+
+// Create a new TaskCollector instance
+func NewTaskCollector() *TaskCollector {
+	return &TaskCollector{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "smartnode_watchtower_task_duration_seconds",
+			Help:    "The time spent in each run of a watchtower task",
+			Buckets: []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		}, []string{"task"}),
+	}
+}
+
+// Record how long a run of a task took
+func (collector *TaskCollector) ObserveDuration(task string, duration time.Duration) {
+	collector.duration.WithLabelValues(task).Observe(duration.Seconds())
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *TaskCollector) Describe(channel chan<- *prometheus.Desc) {
+	collector.duration.Describe(channel)
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *TaskCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.duration.Collect(channel)
+}
@@ -0,0 +1,37 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestTaskCollectorObserveDuration(t *testing.T) {
+	collector := NewTaskCollector()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+
+	collector.ObserveDuration("submitRplPrice", 2*time.Second)
+	collector.ObserveDuration("submitRplPrice", 200*time.Millisecond)
+	collector.ObserveDuration("respondChallenges", time.Second)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(families) != 1 || families[0].GetName() != "smartnode_watchtower_task_duration_seconds" {
+		t.Fatalf("gathered %d metric families, want only smartnode_watchtower_task_duration_seconds", len(families))
+	}
+	counts := map[string]uint64{}
+	for _, metric := range families[0].GetMetric() {
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "task" {
+				counts[label.GetValue()] = metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	if counts["submitRplPrice"] != 2 || counts["respondChallenges"] != 1 {
+		t.Errorf("sample counts by task = %v, want 2 for submitRplPrice and 1 for respondChallenges", counts)
+	}
+}
@@ -0,0 +1,67 @@
+package watchtower
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Tracks when the blocks the Oracle DAO last reached consensus on advance, so the submission tasks don't evaluate the
+// next checkpoint until the cooldown after an advance has passed
+type consensusCooldown struct {
+	duration      time.Duration
+	lastBlocks    []uint64
+	lastAdvanceAt time.Time
+}
+
+// Create a new consensus cooldown; a duration of 0 disables it
+func newConsensusCooldown(duration time.Duration) *consensusCooldown {
+	return &consensusCooldown{
+		duration: duration,
+	}
+}
+
+// Record the latest consensus blocks and get the time left in the cooldown, if any. An advance in any of the blocks
+// starts the cooldown. The first blocks seen are only recorded, since there's no way to know when they were reached.
+func (c *consensusCooldown) check(consensusBlocks ...uint64) time.Duration {
+	if c.duration == 0 {
+		return 0
+	}
+
+	now := time.Now()
+	if len(c.lastBlocks) == len(consensusBlocks) {
+		for i, block := range consensusBlocks {
+			if block > c.lastBlocks[i] {
+				c.lastAdvanceAt = now
+			}
+		}
+	}
+	c.lastBlocks = append([]uint64{}, consensusBlocks...)
+
+	if c.lastAdvanceAt.IsZero() {
+		return 0
+	}
+	remaining := c.lastAdvanceAt.Add(c.duration).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Get the time left in the cooldown after the latest price or balance consensus
+func (c *consensusCooldown) update(rp *rocketpool.RocketPool) (time.Duration, error) {
+	if c.duration == 0 {
+		return 0, nil
+	}
+	pricesBlock, err := network.GetPricesBlock(rp, nil)
+	if err != nil {
+		return 0, fmt.Errorf("Error getting the latest prices block: %w", err)
+	}
+	balancesBlock, err := network.GetBalancesBlock(rp, nil)
+	if err != nil {
+		return 0, fmt.Errorf("Error getting the latest balances block: %w", err)
+	}
+	return c.check(pricesBlock, balancesBlock), nil
+}
@@ -0,0 +1,72 @@
+package watchtower
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsensusCooldownDisabled(t *testing.T) {
+	cooldown := newConsensusCooldown(0)
+	cooldown.check(100)
+	if remaining := cooldown.check(200); remaining != 0 {
+		t.Errorf("check() = %s with the cooldown disabled, want 0", remaining)
+	}
+}
+
+func TestConsensusCooldown(t *testing.T) {
+	const duration = 200 * time.Millisecond
+	cooldown := newConsensusCooldown(duration)
+
+	// The first block seen doesn't start a cooldown, and neither does seeing it again
+	if remaining := cooldown.check(100); remaining != 0 {
+		t.Fatalf("check() = %s for the first block, want 0", remaining)
+	}
+	if remaining := cooldown.check(100); remaining != 0 {
+		t.Fatalf("check() = %s for an unchanged block, want 0", remaining)
+	}
+
+	// An advance starts the cooldown
+	remaining := cooldown.check(200)
+	if remaining <= 0 || remaining > duration {
+		t.Fatalf("check() = %s after consensus advanced, want (0, %s]", remaining, duration)
+	}
+
+	// It keeps counting down from the advance while the block stays the same
+	time.Sleep(duration / 2)
+	if next := cooldown.check(200); next <= 0 || next >= remaining {
+		t.Fatalf("check() = %s halfway through the cooldown, want (0, %s)", next, remaining)
+	}
+
+	// And ends once the duration has passed
+	time.Sleep(duration)
+	if remaining := cooldown.check(200); remaining != 0 {
+		t.Errorf("check() = %s after the cooldown, want 0", remaining)
+	}
+}
+
+func TestConsensusCooldownSharedBlocks(t *testing.T) {
+	const duration = time.Minute
+	tests := []struct {
+		name   string
+		first  []uint64
+		second []uint64
+		want   bool
+	}{
+		{"no change", []uint64{100, 200}, []uint64{100, 200}, false},
+		{"prices advanced", []uint64{100, 200}, []uint64{150, 200}, true},
+		{"balances advanced", []uint64{100, 200}, []uint64{100, 250}, true},
+		{"both advanced", []uint64{100, 200}, []uint64{150, 250}, true},
+		{"block went back", []uint64{100, 200}, []uint64{90, 200}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cooldown := newConsensusCooldown(duration)
+			if remaining := cooldown.check(test.first...); remaining != 0 {
+				t.Fatalf("check() = %s for the first blocks, want 0", remaining)
+			}
+			if remaining := cooldown.check(test.second...); (remaining > 0) != test.want {
+				t.Errorf("check() = %s, want a cooldown %t", remaining, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package watchtower
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpstate "github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// The last time an alert was sent, and how many times it has been suppressed since
+type alertRecord struct {
+	Message    string    `yaml:"message"`
+	FirstSent  time.Time `yaml:"firstSent"`
+	LastSent   time.Time `yaml:"lastSent"`
+	Suppressed uint64    `yaml:"suppressed"`
+}
+
+// Wraps an alerter to suppress repeats of the same alert, persisting when each alert was last sent
+// so a restart doesn't cause the whole set to be sent again
+type dedupeAlerter struct {
+	alerter          *alerting.Alerter
+	store            rpstate.StateStore
+	cooldown         time.Duration
+	reminderInterval time.Duration
+	records          map[string]*alertRecord
+	lock             sync.Mutex
+}
+
+// The decision for an alert that's been raised
+type alertAction int
+
+const (
+	alertSuppress alertAction = iota
+	alertSend
+	alertRemind
+)
+
+// Create a new dedupe alerter
+func newDedupeAlerter(cfg *config.RocketPoolConfig, store rpstate.StateStore) (*dedupeAlerter, error) {
+
+	a := &dedupeAlerter{
+		alerter:          alerting.NewAlerter(cfg),
+		store:            store,
+		cooldown:         time.Duration(cfg.Smartnode.AlertCooldown.Value.(uint64)) * time.Minute,
+		reminderInterval: time.Duration(cfg.Smartnode.AlertReminderInterval.Value.(uint64)) * time.Minute,
+		records:          map[string]*alertRecord{},
+	}
+
+	// Load the previous state if there is one
+	data, exists, err := store.Get(config.WatchtowerAlertStateFile)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading alert state: %w", err)
+	}
+	if !exists {
+		return a, nil
+	}
+	if err := yaml.Unmarshal(data, &a.records); err != nil {
+		return nil, fmt.Errorf("Error deserializing alert state: %w", err)
+	}
+	if a.records == nil {
+		a.records = map[string]*alertRecord{}
+	}
+	return a, nil
+
+}
+
+// Decide what to do with an alert given the previous record for its key.
+// Anything inside the cooldown is suppressed; after that, a changed message is sent right away while an
+// unchanged one waits for the reminder interval.
+func getAlertAction(record *alertRecord, message string, now time.Time, cooldown time.Duration, reminderInterval time.Duration) alertAction {
+	if record == nil {
+		return alertSend
+	}
+	sinceLast := now.Sub(record.LastSent)
+	if sinceLast < cooldown {
+		return alertSuppress
+	}
+	if message != record.Message {
+		return alertSend
+	}
+	if sinceLast < reminderInterval {
+		return alertSuppress
+	}
+	return alertRemind
+}
+
+// Raise an alert for the given key
+func (a *dedupeAlerter) Alert(key string, title string, message string) error {
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := time.Now().UTC()
+	record := a.records[key]
+	switch getAlertAction(record, message, now, a.cooldown, a.reminderInterval) {
+	case alertSuppress:
+		record.Suppressed++
+		return a.save()
+
+	case alertSend:
+		if err := a.alerter.Alert(title, message); err != nil {
+			return err
+		}
+		a.records[key] = &alertRecord{
+			Message:   message,
+			FirstSent: now,
+			LastSent:  now,
+		}
+
+	case alertRemind:
+		reminder := fmt.Sprintf("%s (still failing since %s, %d repeats suppressed)", message, record.FirstSent.Format(time.RFC1123), record.Suppressed)
+		if err := a.alerter.Alert(fmt.Sprintf("Still failing: %s", title), reminder); err != nil {
+			return err
+		}
+		record.LastSent = now
+		record.Suppressed = 0
+	}
+
+	return a.save()
+
+}
+
+// Send an alert straight to the webhook, bypassing the dedupe checks and without recording it
+func (a *dedupeAlerter) AlertNow(title string, message string) error {
+	if !a.alerter.IsEnabled() {
+		return fmt.Errorf("No alert webhook is configured.")
+	}
+	return a.alerter.Alert(title, message)
+}
+
+// Clear an alert once the problem it reported has been resolved, so it is sent immediately if it happens again
+func (a *dedupeAlerter) Resolve(key string) error {
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if _, exists := a.records[key]; !exists {
+		return nil
+	}
+	delete(a.records, key)
+	return a.save()
+
+}
+
+// Forget the alerts that haven't been sent since a cutoff, returning the number removed
+func (a *dedupeAlerter) prune(olderThan time.Time) (int, error) {
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	pruned := 0
+	for key, record := range a.records {
+		if record.LastSent.Before(olderThan) {
+			delete(a.records, key)
+			pruned++
+		}
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+	return pruned, a.save()
+
+}
+
+// Save the alert records to the store
+func (a *dedupeAlerter) save() error {
+
+	data, err := yaml.Marshal(a.records)
+	if err != nil {
+		return fmt.Errorf("Error serializing alert state: %w", err)
+	}
+	return a.store.Set(config.WatchtowerAlertStateFile, data)
+
+}
+
+// Send a sample notification through the configured alerter to check the webhook works
+func sendTestAlert(c *cli.Context) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	store, err := services.GetStateStore(c)
+	if err != nil {
+		return err
+	}
+	alerter, err := newDedupeAlerter(cfg, store)
+	if err != nil {
+		return err
+	}
+
+	// Send the alert
+	message := fmt.Sprintf("This is a test alert from the %s watchtower, sent at %s.", cfg.Smartnode.Network.Value, time.Now().UTC().Format(time.RFC1123))
+	if err := alerter.AlertNow("Test alert", message); err != nil {
+		return fmt.Errorf("Could not send the test alert: %w", err)
+	}
+	fmt.Println("Test alert sent successfully.")
+	return nil
+
+}
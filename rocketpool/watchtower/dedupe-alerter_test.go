@@ -0,0 +1,141 @@
+package watchtower
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpstate "github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Create a dedupe alerter that sends to the given webhook and keeps its state in the given folder
+func newTestDedupeAlerter(webhookUrl string, dir string) (*dedupeAlerter, error) {
+	cfg := config.NewRocketPoolConfig("", false)
+	cfg.Smartnode.AlertWebhookUrl.Value = webhookUrl
+	return newDedupeAlerter(cfg, rpstate.NewFilesystemStateStore(dir))
+}
+
+func TestGetAlertAction(t *testing.T) {
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	cooldown := 15 * time.Minute
+	reminderInterval := time.Hour
+	sentAgo := func(d time.Duration) *alertRecord {
+		return &alertRecord{
+			Message:   "message",
+			FirstSent: now.Add(-2 * d),
+			LastSent:  now.Add(-d),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		record  *alertRecord
+		message string
+		want    alertAction
+	}{
+		{"first alert", nil, "message", alertSend},
+		{"repeat inside the cooldown", sentAgo(5 * time.Minute), "message", alertSuppress},
+		{"changed message inside the cooldown", sentAgo(5 * time.Minute), "other", alertSuppress},
+		{"changed message after the cooldown", sentAgo(20 * time.Minute), "other", alertSend},
+		{"repeat before the reminder", sentAgo(30 * time.Minute), "message", alertSuppress},
+		{"repeat at the reminder", sentAgo(time.Hour), "message", alertRemind},
+		{"repeat after the reminder", sentAgo(2 * time.Hour), "message", alertRemind},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getAlertAction(test.record, test.message, now, cooldown, reminderInterval); got != test.want {
+				t.Errorf("getAlertAction() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDedupeAlerterCooldown(t *testing.T) {
+	var sent int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&sent, 1)
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "dedupe-alerter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	alerter, err := newTestDedupeAlerter(server.URL, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := alerter.Alert("ec", "EC offline", "The EC is offline"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := atomic.LoadInt32(&sent); got != 1 {
+		t.Fatalf("sent %d alerts for repeats inside the cooldown, want 1", got)
+	}
+
+	// The cooldown survives a restart
+	restarted, err := newTestDedupeAlerter(server.URL, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := restarted.Alert("ec", "EC offline", "The EC is offline"); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&sent); got != 1 {
+		t.Fatalf("sent %d alerts after a restart inside the cooldown, want 1", got)
+	}
+
+	// A resolved alert is sent again right away
+	if err := restarted.Resolve("ec"); err != nil {
+		t.Fatal(err)
+	}
+	if err := restarted.Alert("ec", "EC offline", "The EC is offline"); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&sent); got != 2 {
+		t.Fatalf("sent %d alerts after the alert was resolved, want 2", got)
+	}
+}
+
+func TestDedupeAlerterAlertNow(t *testing.T) {
+	var sent int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&sent, 1)
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "dedupe-alerter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Test alerts are sent every time, even inside the cooldown
+	alerter, err := newTestDedupeAlerter(server.URL, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := alerter.AlertNow("Test alert", "This is a test alert"); err != nil {
+			t.Fatalf("AlertNow() returned an error: %s", err)
+		}
+	}
+	if got := atomic.LoadInt32(&sent); got != 2 {
+		t.Errorf("sent %d test alerts, want 2", got)
+	}
+
+	// Without a webhook it's an error rather than a silent no-op
+	disabled, err := newTestDedupeAlerter("", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := disabled.AlertNow("Test alert", "This is a test alert"); err == nil {
+		t.Error("AlertNow() didn't return an error without a webhook")
+	}
+}
@@ -0,0 +1,39 @@
+package watchtower
+
+import (
+	"math"
+	"math/big"
+)
+
+// The number of parts-per-million in a whole
+const ppmPerUnit int64 = 1000000
+
+// Get the relative deviation of a value from a reference as an exact fraction of the reference, |a - b| / b.
+// A zero reference has no relative scale, so any non-zero value is treated as deviating from it entirely.
+func relativeDeviation(a *big.Int, b *big.Int) *big.Rat {
+	diff := big.NewInt(0).Sub(a, b)
+	diff.Abs(diff)
+	if b.Sign() == 0 {
+		if diff.Sign() == 0 {
+			return big.NewRat(0, 1)
+		}
+		return big.NewRat(1, 1)
+	}
+	reference := big.NewInt(0).Abs(b)
+	return new(big.Rat).SetFrac(diff, reference)
+}
+
+// Check if a value deviates from a reference by more than the threshold, in parts-per-million
+func deviationExceeds(a *big.Int, b *big.Int, thresholdPpm uint64) bool {
+	deviationPpm := new(big.Rat).Mul(relativeDeviation(a, b), big.NewRat(ppmPerUnit, 1))
+	threshold := new(big.Rat).SetInt(new(big.Int).SetUint64(thresholdPpm))
+	return deviationPpm.Cmp(threshold) > 0
+}
+
+// Convert a percentage to parts-per-million, rounding to the nearest part
+func percentToPpm(percent float64) uint64 {
+	if percent <= 0 {
+		return 0
+	}
+	return uint64(math.Round(percent * float64(ppmPerUnit) / 100))
+}
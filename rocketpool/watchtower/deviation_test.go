@@ -0,0 +1,76 @@
+package watchtower
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+func TestRelativeDeviation(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *big.Int
+		b    *big.Int
+		want *big.Rat
+	}{
+		{"equal", big.NewInt(500), big.NewInt(500), big.NewRat(0, 1)},
+		{"higher", big.NewInt(510), big.NewInt(500), big.NewRat(1, 50)},
+		{"lower", big.NewInt(490), big.NewInt(500), big.NewRat(1, 50)},
+		{"zero reference", big.NewInt(10), big.NewInt(0), big.NewRat(1, 1)},
+		{"both zero", big.NewInt(0), big.NewInt(0), big.NewRat(0, 1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := relativeDeviation(test.a, test.b); got.Cmp(test.want) != 0 {
+				t.Errorf("relativeDeviation(%s, %s) = %s, want %s", test.a, test.b, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDeviationExceeds(t *testing.T) {
+	reference := eth.EthToWei(0.01)
+	onePercentHigher := eth.EthToWei(0.0101)
+	tests := []struct {
+		name         string
+		a            *big.Int
+		thresholdPpm uint64
+		want         bool
+	}{
+		{"exactly at the threshold", onePercentHigher, 10000, false},
+		{"one wei past the threshold", big.NewInt(0).Add(onePercentHigher, big.NewInt(1)), 10000, true},
+		{"below the threshold", onePercentHigher, 10001, false},
+		{"above the threshold", onePercentHigher, 9999, true},
+		{"no deviation allowed", big.NewInt(0).Add(reference, big.NewInt(1)), 0, true},
+		{"no deviation", reference, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := deviationExceeds(test.a, reference, test.thresholdPpm); got != test.want {
+				t.Errorf("deviationExceeds(%s, %s, %d) = %t, want %t", test.a, reference, test.thresholdPpm, got, test.want)
+			}
+		})
+	}
+}
+
+func TestPercentToPpm(t *testing.T) {
+	tests := []struct {
+		percent float64
+		want    uint64
+	}{
+		{0, 0},
+		{-1, 0},
+		{1, 10000},
+		{0.1, 1000},
+		{2.5, 25000},
+		{0.00004, 0},
+		{0.00005, 1},
+		{100, 1000000},
+	}
+	for _, test := range tests {
+		if got := percentToPpm(test.percent); got != test.want {
+			t.Errorf("percentToPpm(%g) = %d, want %d", test.percent, got, test.want)
+		}
+	}
+}
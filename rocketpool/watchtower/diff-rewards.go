@@ -0,0 +1,184 @@
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How a node's allocation differs between two rewards trees
+type rewardTreeDiffKind string
+
+const (
+	rewardTreeDiffOnlyLocal     rewardTreeDiffKind = "only in local tree"
+	rewardTreeDiffOnlyConsensus rewardTreeDiffKind = "only in consensus tree"
+	rewardTreeDiffChanged       rewardTreeDiffKind = "amounts differ"
+)
+
+// A node whose allocation differs between the local and consensus rewards trees.
+// The deltas are local minus consensus, treating a missing node as having no rewards.
+type rewardTreeDiff struct {
+	Address               common.Address
+	Kind                  rewardTreeDiffKind
+	CollateralRplDelta    *big.Int
+	OracleDaoRplDelta     *big.Int
+	SmoothingPoolEthDelta *big.Int
+}
+
+// Compare the node allocations of a locally generated rewards tree against the consensus one, returning the
+// nodes whose RPL or ETH amounts differ sorted by address
+func diffRewardTrees(local *rprewards.RewardsFile, consensus *rprewards.RewardsFile) []rewardTreeDiff {
+
+	// Collect every node in either tree
+	addresses := map[common.Address]bool{}
+	for address := range local.NodeRewards {
+		addresses[address] = true
+	}
+	for address := range consensus.NodeRewards {
+		addresses[address] = true
+	}
+
+	diffs := []rewardTreeDiff{}
+	for address := range addresses {
+		localRewards, inLocal := local.NodeRewards[address]
+		if !inLocal {
+			localRewards = &rprewards.NodeRewardsInfo{}
+		}
+		consensusRewards, inConsensus := consensus.NodeRewards[address]
+		if !inConsensus {
+			consensusRewards = &rprewards.NodeRewardsInfo{}
+		}
+
+		diff := rewardTreeDiff{
+			Address:               address,
+			CollateralRplDelta:    big.NewInt(0).Sub(getRewardAmount(localRewards.CollateralRpl), getRewardAmount(consensusRewards.CollateralRpl)),
+			OracleDaoRplDelta:     big.NewInt(0).Sub(getRewardAmount(localRewards.OracleDaoRpl), getRewardAmount(consensusRewards.OracleDaoRpl)),
+			SmoothingPoolEthDelta: big.NewInt(0).Sub(getRewardAmount(localRewards.SmoothingPoolEth), getRewardAmount(consensusRewards.SmoothingPoolEth)),
+		}
+		switch {
+		case !inConsensus:
+			diff.Kind = rewardTreeDiffOnlyLocal
+		case !inLocal:
+			diff.Kind = rewardTreeDiffOnlyConsensus
+		case diff.CollateralRplDelta.Sign() != 0 || diff.OracleDaoRplDelta.Sign() != 0 || diff.SmoothingPoolEthDelta.Sign() != 0:
+			diff.Kind = rewardTreeDiffChanged
+		default:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return bytes.Compare(diffs[i].Address.Bytes(), diffs[j].Address.Bytes()) < 0
+	})
+	return diffs
+
+}
+
+// Get a reward amount from a node's allocation, treating a missing amount as zero
+func getRewardAmount(amount *rprewards.QuotedBigInt) *big.Int {
+	if amount == nil {
+		return big.NewInt(0)
+	}
+	return &amount.Int
+}
+
+// Generate the rewards tree for an interval locally and print every node whose allocation differs from the
+// consensus tree, which is loaded from a file if one is given and downloaded from IPFS otherwise
+func diffRewards(c *cli.Context, interval uint64, consensusPath string, cid string) error {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+
+	// Find the event for this interval
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, interval)
+	if err != nil {
+		return fmt.Errorf("Error getting event for interval %d: %w", interval, err)
+	}
+
+	// Load the consensus tree
+	var consensus *rprewards.RewardsFile
+	if consensusPath != "" {
+		consensus, err = rprewards.LoadRewardsFile(consensusPath)
+		if err != nil {
+			return err
+		}
+	} else {
+		if cid == "" {
+			cid = rewardsEvent.MerkleTreeCID
+		}
+		data, err := rprewards.FetchRewardsFile(cfg, interval, cid, true)
+		if err != nil {
+			return fmt.Errorf("Error downloading the consensus tree for interval %d: %w", interval, err)
+		}
+		consensus = &rprewards.RewardsFile{}
+		if err := json.Unmarshal(data, consensus); err != nil {
+			return fmt.Errorf("Error deserializing the consensus tree for interval %d: %w", interval, err)
+		}
+	}
+
+	// Generate the local tree
+	logger := log.NewColorLogger(SubmitRewardsTreeColor)
+	logPrefix := fmt.Sprintf("[Interval %d Tree]", interval)
+	elBlockHeader, err := ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return fmt.Errorf("Error getting execution block: %w", err)
+	}
+	treegen, err := rprewards.NewTreeGenerator(logger, logPrefix, rp, cfg, bc, interval, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64())
+	if err != nil {
+		return fmt.Errorf("Error creating Merkle tree generator: %w", err)
+	}
+	local, err := treegen.GenerateTree()
+	if err != nil {
+		return fmt.Errorf("Error generating Merkle tree: %w", err)
+	}
+
+	// Print the differences
+	fmt.Printf("Local root:     %s\n", local.MerkleRoot)
+	fmt.Printf("Consensus root: %s\n", consensus.MerkleRoot)
+	diffs := diffRewardTrees(local, consensus)
+	if len(diffs) == 0 {
+		fmt.Println("All node allocations match.")
+		return nil
+	}
+	fmt.Printf("%d node allocation(s) differ (deltas are local minus consensus):\n", len(diffs))
+	for _, diff := range diffs {
+		fmt.Printf("%s (%s): collateral RPL %+.6f, oDAO RPL %+.6f, smoothing pool ETH %+.6f\n",
+			diff.Address.Hex(),
+			diff.Kind,
+			eth.WeiToEth(diff.CollateralRplDelta),
+			eth.WeiToEth(diff.OracleDaoRplDelta),
+			eth.WeiToEth(diff.SmoothingPoolEthDelta))
+	}
+	return nil
+
+}
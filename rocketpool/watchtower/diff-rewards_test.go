@@ -0,0 +1,61 @@
+package watchtower
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+)
+
+// Create a node's rewards with the given amounts
+func newTestNodeRewards(collateralRpl int64, oracleDaoRpl int64, smoothingPoolEth int64) *rprewards.NodeRewardsInfo {
+	return &rprewards.NodeRewardsInfo{
+		CollateralRpl:    rprewards.NewQuotedBigInt(collateralRpl),
+		OracleDaoRpl:     rprewards.NewQuotedBigInt(oracleDaoRpl),
+		SmoothingPoolEth: rprewards.NewQuotedBigInt(smoothingPoolEth),
+	}
+}
+
+func TestDiffRewardTrees(t *testing.T) {
+	same := common.HexToAddress("0x01")
+	changed := common.HexToAddress("0x02")
+	onlyLocal := common.HexToAddress("0x03")
+	onlyConsensus := common.HexToAddress("0x04")
+	local := &rprewards.RewardsFile{NodeRewards: map[common.Address]*rprewards.NodeRewardsInfo{
+		same:      newTestNodeRewards(10, 0, 5),
+		changed:   newTestNodeRewards(10, 3, 5),
+		onlyLocal: newTestNodeRewards(1, 0, 0),
+	}}
+	consensus := &rprewards.RewardsFile{NodeRewards: map[common.Address]*rprewards.NodeRewardsInfo{
+		same:          newTestNodeRewards(10, 0, 5),
+		changed:       newTestNodeRewards(12, 3, 4),
+		onlyConsensus: {CollateralRpl: rprewards.NewQuotedBigInt(7)},
+	}}
+
+	diffs := diffRewardTrees(local, consensus)
+	want := []struct {
+		address       common.Address
+		kind          rewardTreeDiffKind
+		collateralRpl int64
+		oracleDaoRpl  int64
+		smoothingEth  int64
+	}{
+		{changed, rewardTreeDiffChanged, -2, 0, 1},
+		{onlyLocal, rewardTreeDiffOnlyLocal, 1, 0, 0},
+		{onlyConsensus, rewardTreeDiffOnlyConsensus, -7, 0, 0},
+	}
+	if len(diffs) != len(want) {
+		t.Fatalf("diffRewardTrees() returned %d diffs, want %d", len(diffs), len(want))
+	}
+	for i, w := range want {
+		diff := diffs[i]
+		if diff.Address != w.address || diff.Kind != w.kind {
+			t.Errorf("diff %d is %s for %s, want %s for %s", i, diff.Kind, diff.Address.Hex(), w.kind, w.address.Hex())
+			continue
+		}
+		if diff.CollateralRplDelta.Int64() != w.collateralRpl || diff.OracleDaoRplDelta.Int64() != w.oracleDaoRpl || diff.SmoothingPoolEthDelta.Int64() != w.smoothingEth {
+			t.Errorf("diff for %s has deltas (%s, %s, %s), want (%d, %d, %d)", diff.Address.Hex(), diff.CollateralRplDelta, diff.OracleDaoRplDelta, diff.SmoothingPoolEthDelta, w.collateralRpl, w.oracleDaoRpl, w.smoothingEth)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package watchtower
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -21,11 +22,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
 )
 
-// Settings
-const MinipoolStatusBatchSize = 20
-
 // Dissolve timed out minipools task
 type dissolveTimedOutMinipools struct {
 	c   *cli.Context
@@ -165,40 +164,31 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools() ([]*minipool.Minipool
 	}
 
 	// Load minipool statuses in batches
-	statuses := make([]minipool.StatusDetails, len(minipools))
-	for bsi := 0; bsi < len(minipools); bsi += MinipoolStatusBatchSize {
-
-		// Get batch start & end index
-		msi := bsi
-		mei := bsi + MinipoolStatusBatchSize
-		if mei > len(minipools) {
-			mei = len(minipools)
-		}
-
-		// Load statuses
-		var wg errgroup.Group
-		for mi := msi; mi < mei; mi++ {
-			mi := mi
-			wg.Go(func() error {
-				mp := minipools[mi]
-				status, err := mp.GetStatusDetails(nil)
-				if err == nil {
-					statuses[mi] = status
-				}
-				return err
-			})
-		}
-		if err := wg.Wait(); err != nil {
-			return []*minipool.Minipool{}, err
+	mc, err := newMultiCaller(t.cfg, t.rp)
+	if err != nil {
+		return []*minipool.Minipool{}, err
+	}
+	statuses := make([]uint8, len(minipools))
+	statusTimes := make([]*big.Int, len(minipools))
+	loadErrs, err := multicallInBatches(mc, len(minipools), getMinipoolBatchSize(t.cfg), func(mi int) []multicall.Call {
+		return []multicall.Call{
+			{Contract: minipools[mi].Contract, Method: "getStatus", Output: &statuses[mi]},
+			{Contract: minipools[mi].Contract, Method: "getStatusTime", Output: &statusTimes[mi]},
 		}
-
+	})
+	if err != nil {
+		return []*minipool.Minipool{}, err
 	}
 
 	// Filter minipools by status
 	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 	timedOutMinipools := []*minipool.Minipool{}
 	for mi, mp := range minipools {
-		if statuses[mi].Status == rptypes.Prelaunch && latestBlockTime.Sub(statuses[mi].StatusTime) >= launchTimeout {
+		if loadErrs[mi] != nil {
+			return []*minipool.Minipool{}, fmt.Errorf("Could not get minipool %s status: %w", mp.Address.Hex(), loadErrs[mi])
+		}
+		statusTime := time.Unix(statusTimes[mi].Int64(), 0)
+		if rptypes.MinipoolStatus(statuses[mi]) == rptypes.Prelaunch && latestBlockTime.Sub(statusTime) >= launchTimeout {
 			timedOutMinipools = append(timedOutMinipools, mp)
 		}
 	}
@@ -0,0 +1,87 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A source of the max fee to use for a transaction
+type GasPriceSource interface {
+	// The name of the source, used for logging
+	Name() string
+
+	// Get the max fee, in wei
+	GetMaxFee() (*big.Int, error)
+}
+
+// Always uses the watchtower's fixed max fee
+type fixedGasPriceSource struct{}
+
+func (s *fixedGasPriceSource) Name() string {
+	return "fixed"
+}
+
+func (s *fixedGasPriceSource) GetMaxFee() (*big.Int, error) {
+	return eth.GweiToWei(WatchtowerMaxFee), nil
+}
+
+// Uses the gas price suggested by the Execution client
+type ecGasPriceSource struct {
+	ec rocketpool.ExecutionClient
+}
+
+func (s *ecGasPriceSource) Name() string {
+	return "Execution client"
+}
+
+func (s *ecGasPriceSource) GetMaxFee() (*big.Int, error) {
+	return s.ec.SuggestGasPrice(context.Background())
+}
+
+// Uses the gas station APIs the node's automatic transactions use
+type gasStationGasPriceSource struct{}
+
+func (s *gasStationGasPriceSource) Name() string {
+	return "gas station"
+}
+
+func (s *gasStationGasPriceSource) GetMaxFee() (*big.Int, error) {
+	return rpgas.GetHeadlessMaxFeeWei()
+}
+
+// Get the gas price source selected in the config
+func getGasPriceSource(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient) (GasPriceSource, error) {
+	switch source := cfg.Smartnode.PriceGasSource.Value.(cfgtypes.GasPriceSource); source {
+	case cfgtypes.GasPriceSource_Fixed:
+		return &fixedGasPriceSource{}, nil
+	case cfgtypes.GasPriceSource_ExecutionClient:
+		return &ecGasPriceSource{ec: ec}, nil
+	case cfgtypes.GasPriceSource_GasStation:
+		return &gasStationGasPriceSource{}, nil
+	default:
+		return nil, fmt.Errorf("Unknown gas price source [%v]", source)
+	}
+}
+
+// Get the max fee from a source, falling back to the fixed max fee if the source fails
+func getMaxFeeWithFallback(source GasPriceSource, logger log.ColorLogger) *big.Int {
+	maxFee, err := source.GetMaxFee()
+	if err == nil && maxFee != nil && maxFee.Sign() > 0 {
+		return maxFee
+	}
+	if err != nil {
+		logger.Printlnf("WARNING: couldn't get the max fee from the %s source, using the fixed max fee instead: %s", source.Name(), err.Error())
+	} else {
+		logger.Printlnf("WARNING: the %s source didn't return a max fee, using the fixed max fee instead.", source.Name())
+	}
+	return eth.GweiToWei(WatchtowerMaxFee)
+}
@@ -0,0 +1,78 @@
+package watchtower
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A gas price source that returns a fixed max fee or error
+type fakeGasPriceSource struct {
+	maxFee *big.Int
+	err    error
+}
+
+func (s *fakeGasPriceSource) Name() string {
+	return "fake"
+}
+
+func (s *fakeGasPriceSource) GetMaxFee() (*big.Int, error) {
+	return s.maxFee, s.err
+}
+
+func TestGetGasPriceSource(t *testing.T) {
+	tests := []struct {
+		source   cfgtypes.GasPriceSource
+		wantName string
+	}{
+		{cfgtypes.GasPriceSource_Fixed, "fixed"},
+		{cfgtypes.GasPriceSource_ExecutionClient, "Execution client"},
+		{cfgtypes.GasPriceSource_GasStation, "gas station"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.source), func(t *testing.T) {
+			cfg := config.NewRocketPoolConfig("", true)
+			cfg.Smartnode.PriceGasSource.Value = test.source
+			source, err := getGasPriceSource(cfg, nil)
+			if err != nil {
+				t.Fatalf("getGasPriceSource() returned an error: %s", err)
+			}
+			if source.Name() != test.wantName {
+				t.Errorf("getGasPriceSource() returned the %s source, want %s", source.Name(), test.wantName)
+			}
+		})
+	}
+
+	cfg := config.NewRocketPoolConfig("", true)
+	cfg.Smartnode.PriceGasSource.Value = cfgtypes.GasPriceSource("unknown")
+	if _, err := getGasPriceSource(cfg, nil); err == nil {
+		t.Error("getGasPriceSource() didn't return an error for an unknown source")
+	}
+}
+
+func TestGetMaxFeeWithFallback(t *testing.T) {
+	fixed := eth.GweiToWei(WatchtowerMaxFee)
+	tests := []struct {
+		name   string
+		source *fakeGasPriceSource
+		want   *big.Int
+	}{
+		{"source fee", &fakeGasPriceSource{maxFee: eth.GweiToWei(25)}, eth.GweiToWei(25)},
+		{"source error", &fakeGasPriceSource{err: errors.New("unavailable")}, fixed},
+		{"no fee", &fakeGasPriceSource{}, fixed},
+		{"zero fee", &fakeGasPriceSource{maxFee: big.NewInt(0)}, fixed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getMaxFeeWithFallback(test.source, log.NewColorLogger(WarningColor)); got.Cmp(test.want) != 0 {
+				t.Errorf("getMaxFeeWithFallback() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -16,7 +16,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -171,7 +170,7 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 			archiveEcUrl := t.cfg.Smartnode.ArchiveECUrl.Value.(string)
 			if archiveEcUrl != "" {
 				t.log.Printlnf("%s Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", generationPrefix, elBlockHeader.Number.Uint64(), archiveEcUrl)
-				ec, err := ethclient.Dial(archiveEcUrl)
+				ec, err := services.DialEc(t.cfg, archiveEcUrl)
 				if err != nil {
 					t.handleError(fmt.Errorf("Error connecting to archive EC: %w", err))
 					return
@@ -252,12 +251,12 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 	// Write the files
 	path := t.cfg.Smartnode.GetRewardsTreePath(index, true)
 	minipoolPerformancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(index, true)
-	err = ioutil.WriteFile(minipoolPerformancePath, minipoolPerformanceBytes, 0644)
+	err = rprewards.WriteTreeFile(minipoolPerformancePath, minipoolPerformanceBytes, t.cfg.Smartnode.CompressRewardTrees.Value == true)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error saving minipool performance file to %s: %w", generationPrefix, minipoolPerformancePath, err))
 		return
 	}
-	err = ioutil.WriteFile(path, wrapperBytes, 0644)
+	err = rprewards.WriteTreeFile(path, wrapperBytes, t.cfg.Smartnode.CompressRewardTrees.Value == true)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error saving rewards file to %s: %w", generationPrefix, path, err))
 		return
@@ -0,0 +1,171 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+var headSubscriptionReconnectDelay, _ = time.ParseDuration("30s")
+
+// A source of triggers for the watchtower task loop
+type headSource interface {
+	// Block until the task loop should run again, returning the block number that triggered it (0 if unknown)
+	next() uint64
+}
+
+// Decides which task loop iterations run every task. New heads can arrive every slot, so a head only runs the
+// checkpoint tasks unless the full task loop hasn't run for at least the minimum interval.
+type loopThrottle struct {
+	minInterval time.Duration
+	lastFullRun time.Time
+}
+
+// Create a new loop throttle
+func newLoopThrottle(minInterval time.Duration) *loopThrottle {
+	return &loopThrottle{
+		minInterval: minInterval,
+	}
+}
+
+// Check if the iteration starting now should run every task, recording it as a full run if so
+func (t *loopThrottle) isFullRun(now time.Time) bool {
+	if !t.lastFullRun.IsZero() && now.Sub(t.lastFullRun) < t.minInterval {
+		return false
+	}
+	t.lastFullRun = now
+	return true
+}
+
+// Triggers the task loop on a randomized timer
+type pollingHeadSource struct{}
+
+// Create a new polling head source
+func newPollingHeadSource() *pollingHeadSource {
+	return &pollingHeadSource{}
+}
+
+// Get a random interval between the min and max task intervals
+func (s *pollingHeadSource) interval() time.Duration {
+	secondsDelta := (maxTasksInterval - minTasksInterval).Seconds()
+	randomSeconds := rand.Intn(int(secondsDelta))
+	return time.Duration(randomSeconds)*time.Second + minTasksInterval
+}
+
+func (s *pollingHeadSource) next() uint64 {
+	time.Sleep(s.interval())
+	return 0
+}
+
+// Triggers the task loop on each new head from an EC Websocket subscription, falling back to polling while disconnected
+type subscriptionHeadSource struct {
+	url       string
+	cfg       *config.RocketPoolConfig
+	log       log.ColorLogger
+	fallback  *pollingHeadSource
+	heads     chan uint64
+	connected bool
+	lock      sync.Mutex
+}
+
+// Create a new subscription head source and start subscribing in the background
+func newSubscriptionHeadSource(cfg *config.RocketPoolConfig, url string, logger log.ColorLogger) *subscriptionHeadSource {
+	s := &subscriptionHeadSource{
+		url:      url,
+		cfg:      cfg,
+		log:      logger,
+		fallback: newPollingHeadSource(),
+		heads:    make(chan uint64, 1),
+	}
+	go s.subscribeLoop()
+	return s
+}
+
+func (s *subscriptionHeadSource) next() uint64 {
+	timeout := time.After(s.fallback.interval())
+	for {
+		select {
+		case blockNumber := <-s.heads:
+			return blockNumber
+		case <-timeout:
+			if !s.isConnected() {
+				// Fall back to polling
+				return 0
+			}
+			timeout = time.After(s.fallback.interval())
+		}
+	}
+}
+
+// Keep the subscription alive, reconnecting whenever it drops
+func (s *subscriptionHeadSource) subscribeLoop() {
+	for {
+		err := s.subscribe()
+		s.log.Printlnf("New block subscription dropped (%s), polling until it reconnects in %s...", err.Error(), headSubscriptionReconnectDelay)
+		time.Sleep(headSubscriptionReconnectDelay)
+	}
+}
+
+// Subscribe to new heads, blocking until the subscription fails
+func (s *subscriptionHeadSource) subscribe() error {
+
+	client, err := services.DialEc(s.cfg, s.url)
+	if err != nil {
+		return fmt.Errorf("error connecting to %s: %w", s.url, err)
+	}
+	defer client.Close()
+
+	headers := make(chan *types.Header)
+	sub, err := client.SubscribeNewHead(context.Background(), headers)
+	if err != nil {
+		return fmt.Errorf("error subscribing to new heads: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	s.setConnected(true)
+	defer s.setConnected(false)
+	s.log.Printlnf("Subscribed to new blocks at %s.", s.url)
+
+	for {
+		select {
+		case err := <-sub.Err():
+			if err == nil {
+				err = fmt.Errorf("subscription closed")
+			}
+			return err
+		case header := <-headers:
+			s.push(header.Number.Uint64())
+		}
+	}
+
+}
+
+// Queue a head for the task loop, replacing any head it hasn't picked up yet
+func (s *subscriptionHeadSource) push(blockNumber uint64) {
+	select {
+	case <-s.heads:
+	default:
+	}
+	s.heads <- blockNumber
+}
+
+func (s *subscriptionHeadSource) isConnected() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.connected
+}
+
+func (s *subscriptionHeadSource) setConnected(connected bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.connected = connected
+}
@@ -0,0 +1,79 @@
+package watchtower
+
+import (
+	"testing"
+	"time"
+)
+
+// Create a subscription head source that isn't subscribed to anything, so heads can be pushed by the test
+func newTestSubscriptionHeadSource() *subscriptionHeadSource {
+	return &subscriptionHeadSource{
+		fallback: newPollingHeadSource(),
+		heads:    make(chan uint64, 1),
+	}
+}
+
+func TestSubscriptionHeadSourceEmitsOncePerHead(t *testing.T) {
+	s := newTestSubscriptionHeadSource()
+	s.setConnected(true)
+	for _, block := range []uint64{100, 101, 102} {
+		s.push(block)
+		if got := s.next(); got != block {
+			t.Fatalf("next() = %d, want %d", got, block)
+		}
+		if pending := len(s.heads); pending != 0 {
+			t.Fatalf("%d heads still pending after block %d was emitted", pending, block)
+		}
+	}
+}
+
+func TestSubscriptionHeadSourceKeepsLatestHead(t *testing.T) {
+	s := newTestSubscriptionHeadSource()
+	s.setConnected(true)
+
+	// Heads that arrive while the task loop is busy are replaced by the newest one
+	s.push(100)
+	s.push(101)
+	s.push(102)
+	if got := s.next(); got != 102 {
+		t.Fatalf("next() = %d, want 102", got)
+	}
+	if pending := len(s.heads); pending != 0 {
+		t.Fatalf("%d heads still pending after the latest head was emitted", pending)
+	}
+}
+
+func TestPollingHeadSourceInterval(t *testing.T) {
+	s := newPollingHeadSource()
+	for i := 0; i < 100; i++ {
+		interval := s.interval()
+		if interval < minTasksInterval || interval >= maxTasksInterval {
+			t.Fatalf("interval() = %s, want between %s and %s", interval, minTasksInterval, maxTasksInterval)
+		}
+	}
+}
+
+func TestLoopThrottle(t *testing.T) {
+	const minInterval = 4 * time.Minute
+	start := time.Unix(1700000000, 0)
+	tests := []struct {
+		name  string
+		after time.Duration
+		want  bool
+	}{
+		{"first iteration", 0, true},
+		{"head right after a full run", 12 * time.Second, false},
+		{"head just before the interval", 3*time.Minute + 59*time.Second, false},
+		{"head once the interval has passed", 4*time.Minute + 12*time.Second, true},
+		{"head after the new full run", 4*time.Minute + 24*time.Second, false},
+		{"polling interval", 8*time.Minute + 12*time.Second, true},
+	}
+
+	// The cases run in order against the same throttle, since each full run moves the next one back
+	throttle := newLoopThrottle(minInterval)
+	for _, test := range tests {
+		if got := throttle.isFullRun(start.Add(test.after)); got != test.want {
+			t.Errorf("%s: isFullRun() = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package watchtower
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Get the reportable checkpoint blocks in a range, inclusive
+func getCheckpointBlocks(fromBlock uint64, toBlock uint64, frequency uint64) []uint64 {
+	checkpoints := []uint64{}
+	if frequency == 0 || fromBlock > toBlock {
+		return checkpoints
+	}
+	first := (fromBlock + frequency - 1) / frequency * frequency
+	for block := first; block <= toBlock; block += frequency {
+		checkpoints = append(checkpoints, block)
+	}
+	return checkpoints
+}
+
+// Get the RPL price the Oracle DAO reached consensus on for a checkpoint.
+// This reads the network prices just before the next checkpoint, so it returns false if consensus wasn't
+// reached for the checkpoint before then or if the next checkpoint hasn't been reached yet.
+func getConsensusPrice(rp *rocketpool.RocketPool, checkpoint uint64, frequency uint64, latestBlock uint64) (*big.Int, bool, error) {
+
+	readBlock := checkpoint + frequency - 1
+	if readBlock > latestBlock {
+		return nil, false, nil
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(readBlock),
+	}
+
+	// Make sure the prices at that block are for this checkpoint
+	pricesBlock, err := network.GetPricesBlock(rp, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	if pricesBlock != checkpoint {
+		return nil, false, nil
+	}
+
+	price, err := network.GetRPLPrice(rp, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	return price, true, nil
+
+}
@@ -0,0 +1,32 @@
+package watchtower
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetCheckpointBlocks(t *testing.T) {
+	tests := []struct {
+		name      string
+		fromBlock uint64
+		toBlock   uint64
+		frequency uint64
+		want      []uint64
+	}{
+		{"aligned range", 100, 300, 100, []uint64{100, 200, 300}},
+		{"unaligned range", 101, 399, 100, []uint64{200, 300}},
+		{"no checkpoints in range", 101, 199, 100, []uint64{}},
+		{"single block on a checkpoint", 200, 200, 100, []uint64{200}},
+		{"from genesis", 0, 250, 100, []uint64{0, 100, 200}},
+		{"reversed range", 300, 100, 100, []uint64{}},
+		{"zero frequency", 100, 300, 0, []uint64{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := getCheckpointBlocks(test.fromBlock, test.toBlock, test.frequency)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("getCheckpointBlocks(%d, %d, %d) = %v, want %v", test.fromBlock, test.toBlock, test.frequency, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package watchtower
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	rpstate "github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The state store key for the leader's lease
+const leaderLeaseKey string = "leader.lease"
+
+// Elects a single leader among redundant watchtowers that share a state store.
+// The leader renews its lease in the background every third of the lease time, so a long task loop can't let it lapse;
+// if the leader stops renewing, the lease expires and the first standby to renew takes it over.
+// Note that the filesystem state store's lock isn't safe across hosts, so it only provides failover between
+// watchtowers running on the same machine.
+type leaderElector struct {
+	store   rpstate.StateStore
+	id      []byte
+	ttl     time.Duration
+	log     log.ColorLogger
+	leading bool
+	lock    sync.Mutex
+	stop    chan struct{}
+}
+
+// Create a new leader elector with a unique ID for this process
+func newLeaderElector(store rpstate.StateStore, ttl time.Duration, logger log.ColorLogger) (*leaderElector, error) {
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("Error generating leader election ID: %w", err)
+	}
+
+	return &leaderElector{
+		store: store,
+		id:    []byte(fmt.Sprintf("%s-%s", hostname, hex.EncodeToString(suffix))),
+		ttl:   ttl,
+		log:   logger,
+		stop:  make(chan struct{}),
+	}, nil
+
+}
+
+// Run the first election and keep renewing the lease in the background until the elector is stopped
+func (e *leaderElector) start() {
+	e.renewOrLog()
+	go func() {
+		ticker := time.NewTicker(e.ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.renewOrLog()
+			case <-e.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop renewing the lease, letting it expire so a standby can take over
+func (e *leaderElector) stopRenewing() {
+	close(e.stop)
+}
+
+// Check if this watchtower was the leader as of the last renewal
+func (e *leaderElector) isLeader() bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.leading
+}
+
+// Renew the lease right before a transaction is sent, returning an error if this watchtower isn't the leader.
+// This catches a lease that was lost after the task checked isLeader, so a standby that took over and a stale
+// leader never both submit.
+func (e *leaderElector) confirm() error {
+	leading, err := e.renew()
+	if err != nil {
+		return err
+	}
+	if !leading {
+		return fmt.Errorf("This watchtower (%s) is no longer the leader", string(e.id))
+	}
+	return nil
+}
+
+// Renew the lease, logging any error and standing by until the next renewal
+func (e *leaderElector) renewOrLog() {
+	if _, err := e.renew(); err != nil {
+		e.log.Printlnf("WARNING: %s", err.Error())
+	}
+}
+
+// Renew this watchtower's lease if it holds it or try to acquire it if it's free, returning whether it's the leader
+func (e *leaderElector) renew() (bool, error) {
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	// Renew the lease if it's ours
+	leading, err := e.store.Refresh(leaderLeaseKey, e.id, e.ttl)
+	if err != nil {
+		err = fmt.Errorf("Error renewing leader lease: %w", err)
+	}
+
+	// Try to take the lease if it's free
+	if err == nil && !leading {
+		leading, err = e.store.SetIfAbsent(leaderLeaseKey, e.id, e.ttl)
+		if err != nil {
+			err = fmt.Errorf("Error acquiring leader lease: %w", err)
+		}
+	}
+
+	// Stand by if the lease couldn't be checked, since another watchtower may have taken it
+	if err != nil {
+		leading = false
+	}
+
+	// Log any change in leadership
+	if leading && !e.leading {
+		e.log.Printlnf("This watchtower (%s) is now the leader and will submit transactions.", string(e.id))
+	} else if !leading && e.leading {
+		e.log.Printlnf("This watchtower (%s) is no longer the leader and will stand by.", string(e.id))
+	}
+	e.leading = leading
+	return leading, err
+
+}
@@ -0,0 +1,123 @@
+package watchtower
+
+import (
+	"testing"
+	"time"
+
+	rpstate "github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Create a leader elector on a shared store
+func newTestLeaderElector(t *testing.T, store rpstate.StateStore, ttl time.Duration) *leaderElector {
+	t.Helper()
+	elector, err := newLeaderElector(store, ttl, log.NewColorLogger(WarningColor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return elector
+}
+
+// Renew an elector's lease, failing the test if its leadership isn't the expected one
+func requireLeader(t *testing.T, elector *leaderElector, want bool) {
+	t.Helper()
+	leading, err := elector.renew()
+	if err != nil {
+		t.Fatalf("renew() returned an error: %s", err)
+	}
+	if leading != want {
+		t.Fatalf("renew() = %t, want %t", leading, want)
+	}
+}
+
+func TestLeaderElectorAcquisition(t *testing.T) {
+	store := rpstate.NewMemoryStateStore()
+	first := newTestLeaderElector(t, store, time.Minute)
+	second := newTestLeaderElector(t, store, time.Minute)
+
+	requireLeader(t, first, true)
+	requireLeader(t, second, false)
+	requireLeader(t, first, true)
+	requireLeader(t, second, false)
+}
+
+func TestLeaderElectorRenewal(t *testing.T) {
+	const ttl = 200 * time.Millisecond
+	store := rpstate.NewMemoryStateStore()
+	leader := newTestLeaderElector(t, store, ttl)
+	standby := newTestLeaderElector(t, store, ttl)
+
+	// Checking renews the lease, so the leader keeps it for longer than a single TTL
+	requireLeader(t, leader, true)
+	for i := 0; i < 4; i++ {
+		time.Sleep(ttl / 2)
+		requireLeader(t, leader, true)
+		requireLeader(t, standby, false)
+	}
+}
+
+func TestLeaderElectorFailover(t *testing.T) {
+	const ttl = 100 * time.Millisecond
+	store := rpstate.NewMemoryStateStore()
+	leader := newTestLeaderElector(t, store, ttl)
+	standby := newTestLeaderElector(t, store, ttl)
+
+	requireLeader(t, leader, true)
+	requireLeader(t, standby, false)
+
+	// The standby takes over once the leader stops renewing, and the old leader stands by when it comes back
+	time.Sleep(ttl * 3 / 2)
+	requireLeader(t, standby, true)
+	requireLeader(t, leader, false)
+}
+
+func TestLeaderElectorBackgroundRenewal(t *testing.T) {
+	const ttl = 150 * time.Millisecond
+	store := rpstate.NewMemoryStateStore()
+	leader := newTestLeaderElector(t, store, ttl)
+	standby := newTestLeaderElector(t, store, ttl)
+
+	// The leader keeps its lease without being checked, as long as it's renewing in the background
+	leader.start()
+	if !leader.isLeader() {
+		t.Fatal("isLeader() = false after the first election, want true")
+	}
+	for i := 0; i < 5; i++ {
+		time.Sleep(ttl / 2)
+		requireLeader(t, standby, false)
+	}
+
+	// Once it stops renewing, the standby takes over
+	leader.stopRenewing()
+	time.Sleep(ttl * 3 / 2)
+	requireLeader(t, standby, true)
+}
+
+func TestLeaderElectorConfirm(t *testing.T) {
+	const ttl = 100 * time.Millisecond
+	store := rpstate.NewMemoryStateStore()
+	leader := newTestLeaderElector(t, store, ttl)
+	standby := newTestLeaderElector(t, store, ttl)
+
+	requireLeader(t, leader, true)
+	requireLeader(t, standby, false)
+	if err := leader.confirm(); err != nil {
+		t.Fatalf("confirm() returned an error for the leader: %s", err)
+	}
+	if err := standby.confirm(); err == nil {
+		t.Fatal("confirm() didn't return an error for the standby")
+	}
+
+	// A leader that lost its lease since it last checked still thinks it's leading, but can't confirm it
+	time.Sleep(ttl * 3 / 2)
+	requireLeader(t, standby, true)
+	if !leader.isLeader() {
+		t.Fatal("isLeader() = false before the old leader renewed, want its last known state")
+	}
+	if err := leader.confirm(); err == nil {
+		t.Fatal("confirm() didn't return an error for a leader that lost its lease")
+	}
+	if leader.isLeader() {
+		t.Error("isLeader() = true after confirm() found the lease was lost")
+	}
+}
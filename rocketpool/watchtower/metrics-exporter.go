@@ -12,7 +12,7 @@ import (
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, priceCollector *collectors.PriceCollector, participationCollector *collectors.ParticipationCollector, accountCollector *collectors.AccountCollector, taskCollector *collectors.TaskCollector, clientCollector *collectors.ClientCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -26,8 +26,12 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	}
 
 	// Set up Prometheus
+	labels, err := services.GetMetricLabels(c)
+	if err != nil {
+		return err
+	}
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(scrubCollector)
+	prometheus.WrapRegistererWith(labels, registry).MustRegister(scrubCollector, priceCollector, participationCollector, accountCollector, taskCollector, clientCollector)
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -35,8 +39,9 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	metricsPort := c.GlobalUint("metricsPort")
 	logger.Printlnf("Starting metrics exporter on %s:%d.", metricsAddress, metricsPort)
 	metricsPath := "/metrics"
-	http.Handle(metricsPath, handler)
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, handler)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`<html>
             <head><title>Rocket Pool Watchtower Metrics Exporter</title></head>
             <body>
@@ -46,7 +51,7 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
             </html>`,
 		))
 	})
-	err = http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), nil)
+	err = http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), mux)
 	if err != nil {
 		return fmt.Errorf("Error running HTTP server: %w", err)
 	}
@@ -0,0 +1,94 @@
+package watchtower
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
+)
+
+// Get the configured number of minipools to load at the same time
+func getMinipoolBatchSize(cfg *config.RocketPoolConfig) int {
+	batchSize := int(cfg.Smartnode.MinipoolBatchSize.Value.(uint64))
+	if batchSize < 1 {
+		return 1
+	}
+	return batchSize
+}
+
+// Get the start and end indices of each batch when splitting count items into batches of batchSize
+func getBatchBounds(count int, batchSize int) [][2]int {
+	bounds := [][2]int{}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	for start := 0; start < count; start += batchSize {
+		end := start + batchSize
+		if end > count {
+			end = count
+		}
+		bounds = append(bounds, [2]int{start, end})
+	}
+	return bounds
+}
+
+// Call load for every index from 0 to count, running each batch concurrently and waiting for it to finish before
+// starting the next one so the number of requests in flight stays bounded. Stops at the first batch with an error.
+func loadInBatches(count int, batchSize int, load func(index int) error) error {
+	for _, bounds := range getBatchBounds(count, batchSize) {
+		var wg errgroup.Group
+		for i := bounds[0]; i < bounds[1]; i++ {
+			i := i
+			wg.Go(func() error {
+				return load(i)
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create a multicaller for the network's Multicall3 contract
+func newMultiCaller(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool) (*multicall.MultiCaller, error) {
+	return multicall.NewMultiCaller(rp.Client, common.HexToAddress(cfg.Smartnode.GetMulticallAddress()))
+}
+
+// Collect the calls for every item in a batch, along with the index of the item each call belongs to
+func getBatchCalls(bounds [2]int, getCalls func(index int) []multicall.Call) ([]multicall.Call, []int) {
+	calls := []multicall.Call{}
+	owners := []int{}
+	for i := bounds[0]; i < bounds[1]; i++ {
+		for _, call := range getCalls(i) {
+			calls = append(calls, call)
+			owners = append(owners, i)
+		}
+	}
+	return calls, owners
+}
+
+// Run the calls for every index from 0 to count through the multicall contract, with the calls for batchSize items
+// in each eth_call so a batch costs one RPC round trip no matter how many calls each item needs.
+// Returns the first error from each item's calls (nil if they all succeeded), or an error if a batch couldn't be run.
+func multicallInBatches(mc *multicall.MultiCaller, count int, batchSize int, getCalls func(index int) []multicall.Call) ([]error, error) {
+	itemErrs := make([]error, count)
+	for _, bounds := range getBatchBounds(count, batchSize) {
+		calls, owners := getBatchCalls(bounds, getCalls)
+		if len(calls) == 0 {
+			continue
+		}
+		callErrs, err := mc.Execute(calls, nil)
+		if err != nil {
+			return nil, err
+		}
+		for ci, callErr := range callErrs {
+			if callErr != nil && itemErrs[owners[ci]] == nil {
+				itemErrs[owners[ci]] = callErr
+			}
+		}
+	}
+	return itemErrs, nil
+}
@@ -0,0 +1,97 @@
+package watchtower
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
+)
+
+func TestGetBatchBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		batchSize int
+		want      [][2]int
+	}{
+		{"empty", 0, 10, [][2]int{}},
+		{"single batch", 3, 10, [][2]int{{0, 3}}},
+		{"exact batches", 4, 2, [][2]int{{0, 2}, {2, 4}}},
+		{"partial last batch", 5, 2, [][2]int{{0, 2}, {2, 4}, {4, 5}}},
+		{"batch size below one", 2, 0, [][2]int{{0, 1}, {1, 2}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getBatchBounds(test.count, test.batchSize); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("getBatchBounds(%d, %d) = %v, want %v", test.count, test.batchSize, got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadInBatches(t *testing.T) {
+	loaded := make([]int32, 5)
+	if err := loadInBatches(len(loaded), 2, func(index int) error {
+		atomic.AddInt32(&loaded[index], 1)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for i, count := range loaded {
+		if count != 1 {
+			t.Errorf("item %d was loaded %d times, want 1", i, count)
+		}
+	}
+
+	// A failed batch stops the batches after it
+	var calls int32
+	loadErr := errors.New("load failed")
+	err := loadInBatches(6, 2, func(index int) error {
+		atomic.AddInt32(&calls, 1)
+		if index == 2 {
+			return loadErr
+		}
+		return nil
+	})
+	if err != loadErr {
+		t.Errorf("loadInBatches() returned %v, want the load error", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 4 {
+		t.Errorf("loaded %d items, want 4", got)
+	}
+}
+
+func TestGetBatchCalls(t *testing.T) {
+	// Item i has i calls, so item 0 contributes nothing
+	getCalls := func(index int) []multicall.Call {
+		calls := make([]multicall.Call, index)
+		for i := range calls {
+			calls[i] = multicall.Call{Method: "call", Args: []interface{}{index, i}}
+		}
+		return calls
+	}
+
+	// Every call across the batches belongs to the right item, in order
+	assembled := [][]interface{}{}
+	for _, bounds := range getBatchBounds(5, 2) {
+		calls, owners := getBatchCalls(bounds, getCalls)
+		if len(calls) != len(owners) {
+			t.Fatalf("batch %v has %d calls but %d owners", bounds, len(calls), len(owners))
+		}
+		for ci, call := range calls {
+			if owners[ci] < bounds[0] || owners[ci] >= bounds[1] {
+				t.Errorf("batch %v has a call owned by item %d", bounds, owners[ci])
+			}
+			if call.Args[0] != owners[ci] {
+				t.Errorf("call for item %v is owned by item %d", call.Args[0], owners[ci])
+			}
+			assembled = append(assembled, call.Args)
+		}
+	}
+	want := [][]interface{}{{1, 0}, {2, 0}, {2, 1}, {3, 0}, {3, 1}, {3, 2}, {4, 0}, {4, 1}, {4, 2}, {4, 3}}
+	if !reflect.DeepEqual(assembled, want) {
+		t.Errorf("assembled calls = %v, want %v", assembled, want)
+	}
+}
@@ -0,0 +1,153 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The number of checkpoints to read at the same time when scanning for missed submissions
+const missedBlocksBatchSize int = 50
+
+// The submission state of a member for a single checkpoint
+type checkpointSubmission struct {
+	Block     uint64
+	Time      uint64
+	Submitted bool
+}
+
+// Get the checkpoints a member didn't submit for while they were a member, along with the number of checkpoints they
+// were eligible for. Checkpoints from before the member joined are ignored.
+func getMissedBlocks(submissions []checkpointSubmission, joinedTime uint64) ([]uint64, int) {
+	missed := []uint64{}
+	eligible := 0
+	for _, submission := range submissions {
+		if submission.Time < joinedTime {
+			continue
+		}
+		eligible++
+		if !submission.Submitted {
+			missed = append(missed, submission.Block)
+		}
+	}
+	return missed, eligible
+}
+
+// Print the reportable blocks in a range that a node didn't submit prices or balances for while it was an Oracle DAO
+// member. Only checkpoints up to the latest one that reached consensus are checked, since later ones may still be
+// submitted for.
+func printMissedBlocks(c *cli.Context, fromBlock uint64, toBlock uint64, addressString string) error {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the node address, defaulting to the node wallet
+	nodeAddress, err := getNodeAddressArg(c, addressString)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the node is a member and get when it joined
+	isMember, err := trustednode.GetMemberExists(rp, nodeAddress, nil)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return fmt.Errorf("Node %s is not a member of the Oracle DAO.", nodeAddress.Hex())
+	}
+	joinedTime, err := trustednode.GetMemberJoinedTime(rp, nodeAddress, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the time node %s joined the Oracle DAO: %w", nodeAddress.Hex(), err)
+	}
+
+	// Get the submission frequencies and the latest blocks that reached consensus
+	pricesFrequency, err := protocol.GetSubmitPricesFrequency(rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting prices submission frequency: %w", err)
+	}
+	balancesFrequency, err := protocol.GetSubmitBalancesFrequency(rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting balances submission frequency: %w", err)
+	}
+	if err := checkSubmitFrequency(pricesFrequency); err != nil {
+		return err
+	}
+	if err := checkSubmitFrequency(balancesFrequency); err != nil {
+		return err
+	}
+	pricesBlock, err := network.GetPricesBlock(rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the latest prices block: %w", err)
+	}
+	balancesBlock, err := network.GetBalancesBlock(rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the latest balances block: %w", err)
+	}
+
+	// Print
+	fmt.Printf("Node: %s\n\n", nodeAddress.Hex())
+	if err := printMissedSubmissions(rp, "Prices", nodeAddress, joinedTime, getCheckpointBlocks(fromBlock, getScanEndBlock(toBlock, pricesBlock), pricesFrequency), rputils.SubmittedPricesStorageKey); err != nil {
+		return err
+	}
+	fmt.Println()
+	return printMissedSubmissions(rp, "Balances", nodeAddress, joinedTime, getCheckpointBlocks(fromBlock, getScanEndBlock(toBlock, balancesBlock), balancesFrequency), rputils.SubmittedBalancesStorageKey)
+
+}
+
+// Get the last block to scan: the requested end block, capped at the latest block that reached consensus
+func getScanEndBlock(toBlock uint64, consensusBlock uint64) uint64 {
+	if toBlock == 0 || toBlock > consensusBlock {
+		return consensusBlock
+	}
+	return toBlock
+}
+
+// Read whether a node submitted for each checkpoint and print the ones it missed
+func printMissedSubmissions(rp *rocketpool.RocketPool, name string, nodeAddress common.Address, joinedTime uint64, checkpoints []uint64, getStorageKey func(common.Address, uint64) common.Hash) error {
+
+	submissions := make([]checkpointSubmission, len(checkpoints))
+	err := loadInBatches(len(checkpoints), missedBlocksBatchSize, func(i int) error {
+		block := checkpoints[i]
+		header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(block))
+		if err != nil {
+			return fmt.Errorf("Error getting header for block %d: %w", block, err)
+		}
+		submitted, err := rp.RocketStorage.GetBool(nil, getStorageKey(nodeAddress, block))
+		if err != nil {
+			return fmt.Errorf("Error checking submission for block %d: %w", block, err)
+		}
+		submissions[i] = checkpointSubmission{
+			Block:     block,
+			Time:      header.Time,
+			Submitted: submitted,
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	missed, eligible := getMissedBlocks(submissions, joinedTime)
+	fmt.Printf("%s: missed %d of %d eligible checkpoints\n", name, len(missed), eligible)
+	for _, block := range missed {
+		fmt.Printf("\tBlock %d\n", block)
+	}
+	return nil
+
+}
@@ -0,0 +1,65 @@
+package watchtower
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetMissedBlocks(t *testing.T) {
+	submissions := []checkpointSubmission{
+		{Block: 100, Time: 1000, Submitted: false},
+		{Block: 200, Time: 2000, Submitted: true},
+		{Block: 300, Time: 3000, Submitted: false},
+		{Block: 400, Time: 4000, Submitted: true},
+		{Block: 500, Time: 5000, Submitted: false},
+	}
+	tests := []struct {
+		name         string
+		submissions  []checkpointSubmission
+		joinedTime   uint64
+		wantMissed   []uint64
+		wantEligible int
+	}{
+		{"member for every checkpoint", submissions, 0, []uint64{100, 300, 500}, 5},
+		{"joined partway through", submissions, 2500, []uint64{300, 500}, 3},
+		{"joined at a checkpoint", submissions, 3000, []uint64{300, 500}, 3},
+		{"joined after every checkpoint", submissions, 6000, []uint64{}, 0},
+		{"no checkpoints", []checkpointSubmission{}, 0, []uint64{}, 0},
+		{"nothing missed", []checkpointSubmission{
+			{Block: 100, Time: 1000, Submitted: true},
+			{Block: 200, Time: 2000, Submitted: true},
+		}, 0, []uint64{}, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			missed, eligible := getMissedBlocks(test.submissions, test.joinedTime)
+			if !reflect.DeepEqual(missed, test.wantMissed) {
+				t.Errorf("missed = %v, want %v", missed, test.wantMissed)
+			}
+			if eligible != test.wantEligible {
+				t.Errorf("eligible = %d, want %d", eligible, test.wantEligible)
+			}
+		})
+	}
+}
+
+func TestGetScanEndBlock(t *testing.T) {
+	tests := []struct {
+		name           string
+		toBlock        uint64
+		consensusBlock uint64
+		want           uint64
+	}{
+		{"no end block", 0, 500, 500},
+		{"before consensus", 300, 500, 300},
+		{"at consensus", 500, 500, 500},
+		{"after consensus", 700, 500, 500},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getScanEndBlock(test.toBlock, test.consensusBlock); got != test.want {
+				t.Errorf("getScanEndBlock(%d, %d) = %d, want %d", test.toBlock, test.consensusBlock, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package watchtower
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Get a mux with the pprof handlers registered.
+// The handlers are registered on their own mux rather than the default one so they're never served by the metrics server.
+func newPprofMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// Serve the pprof debug endpoints if they're enabled
+func runPprofServer(cfg *config.RocketPoolConfig, logger log.ColorLogger) error {
+
+	address := cfg.Smartnode.GetPprofListenAddress()
+	if address == "" {
+		return nil
+	}
+
+	logger.Printlnf("Starting pprof debug server on %s.", address)
+	if err := http.ListenAndServe(address, newPprofMux()); err != nil {
+		return fmt.Errorf("Error running pprof debug server: %w", err)
+	}
+	return nil
+
+}
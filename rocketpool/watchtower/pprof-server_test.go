@@ -0,0 +1,35 @@
+package watchtower
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewPprofMux(t *testing.T) {
+	server := httptest.NewServer(newPprofMux())
+	defer server.Close()
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/debug/pprof/", http.StatusOK},
+		{"/debug/pprof/cmdline", http.StatusOK},
+		{"/debug/pprof/goroutine?debug=1", http.StatusOK},
+		{"/metrics", http.StatusNotFound},
+		{"/", http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			response, err := http.Get(server.URL + test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response.Body.Close()
+			if response.StatusCode != test.status {
+				t.Errorf("GET %s returned %d, want %d", test.path, response.StatusCode, test.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package watchtower
+
+import (
+	"sync"
+)
+
+// A cache of the RPL price candidates for each block, so tasks that need the price for the same block in one
+// iteration of the task loop only query the oracles once. It's cleared at the start of every iteration.
+type priceCache struct {
+	candidates map[uint64][]priceCandidate
+	lock       sync.Mutex
+}
+
+// Create a new price cache
+func newPriceCache() *priceCache {
+	return &priceCache{
+		candidates: map[uint64][]priceCandidate{},
+	}
+}
+
+// Get the price candidates for a block, fetching them if they aren't cached yet. Errors aren't cached so the next
+// caller retries.
+func (p *priceCache) get(blockNumber uint64, fetch func() ([]priceCandidate, error)) ([]priceCandidate, error) {
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if candidates, exists := p.candidates[blockNumber]; exists {
+		return candidates, nil
+	}
+	candidates, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	p.candidates[blockNumber] = candidates
+	return candidates, nil
+
+}
+
+// Clear the cache
+func (p *priceCache) clear() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.candidates = map[uint64][]priceCandidate{}
+}
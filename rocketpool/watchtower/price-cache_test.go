@@ -0,0 +1,50 @@
+package watchtower
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestPriceCache(t *testing.T) {
+	cache := newPriceCache()
+	fetches := 0
+	fetch := func() ([]priceCandidate, error) {
+		fetches++
+		return []priceCandidate{{Source: OneInchPriceSource, Price: big.NewInt(int64(fetches))}}, nil
+	}
+
+	// Each block is only fetched once
+	for i := 0; i < 2; i++ {
+		if _, err := cache.get(100, fetch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := cache.get(101, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if fetches != 2 {
+		t.Errorf("fetched %d times, want 2", fetches)
+	}
+
+	// Errors aren't cached
+	if _, err := cache.get(102, func() ([]priceCandidate, error) { return nil, errors.New("oracle down") }); err == nil {
+		t.Fatal("get() didn't return the fetch error")
+	}
+	candidates, err := cache.get(102, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetches != 3 || candidates[0].Price.Int64() != 3 {
+		t.Errorf("block 102 wasn't refetched after an error")
+	}
+
+	// Clearing the cache fetches again
+	cache.clear()
+	if _, err := cache.get(100, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if fetches != 4 {
+		t.Errorf("fetched %d times after clearing, want 4", fetches)
+	}
+}
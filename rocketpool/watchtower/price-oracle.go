@@ -0,0 +1,950 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// Price source names, as used in the priceSources setting
+const (
+	OneInchPriceSource   string = "1inch"
+	ChainlinkPriceSource string = "chainlink"
+	UniswapPriceSource   string = "uniswap"
+	ContractPriceSource  string = "contract"
+)
+
+// The parts of the Chainlink aggregator ABI used by the watchtower
+const chainlinkAggregatorAbi string = `[
+    {
+      "inputs": [],
+      "name": "decimals",
+      "outputs": [{"internalType": "uint8", "name": "", "type": "uint8"}],
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "inputs": [],
+      "name": "latestRoundData",
+      "outputs": [
+        {"internalType": "uint80", "name": "roundId", "type": "uint80"},
+        {"internalType": "int256", "name": "answer", "type": "int256"},
+        {"internalType": "uint256", "name": "startedAt", "type": "uint256"},
+        {"internalType": "uint256", "name": "updatedAt", "type": "uint256"},
+        {"internalType": "uint80", "name": "answeredInRound", "type": "uint80"}
+      ],
+      "stateMutability": "view",
+      "type": "function"
+    }
+  ]`
+
+// The methods a price feed contract can report its answer with
+const priceFeedAbi string = `[
+    {
+      "inputs": [],
+      "name": "latestAnswer",
+      "outputs": [{"internalType": "int256", "name": "", "type": "int256"}],
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "inputs": [],
+      "name": "latestRoundData",
+      "outputs": [
+        {"internalType": "uint80", "name": "roundId", "type": "uint80"},
+        {"internalType": "int256", "name": "answer", "type": "int256"},
+        {"internalType": "uint256", "name": "startedAt", "type": "uint256"},
+        {"internalType": "uint256", "name": "updatedAt", "type": "uint256"},
+        {"internalType": "uint80", "name": "answeredInRound", "type": "uint80"}
+      ],
+      "stateMutability": "view",
+      "type": "function"
+    }
+  ]`
+
+// The parts of the Uniswap V3 pool ABI used by the watchtower
+const uniswapPoolAbi string = `[
+    {
+      "inputs": [],
+      "name": "token0",
+      "outputs": [{"internalType": "address", "name": "", "type": "address"}],
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "inputs": [],
+      "name": "token1",
+      "outputs": [{"internalType": "address", "name": "", "type": "address"}],
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "inputs": [{"internalType": "uint32[]", "name": "secondsAgos", "type": "uint32[]"}],
+      "name": "observe",
+      "outputs": [
+        {"internalType": "int56[]", "name": "tickCumulatives", "type": "int56[]"},
+        {"internalType": "uint160[]", "name": "secondsPerLiquidityCumulativeX128s", "type": "uint160[]"}
+      ],
+      "stateMutability": "view",
+      "type": "function"
+    }
+  ]`
+
+// A source of the RPL / ETH exchange rate
+type PriceOracle interface {
+	// The name of the source, used for logging
+	Name() string
+
+	// Get the amount of ETH (in wei) that 1 RPL is worth at the block specified in opts, along with when the source
+	// last updated it. Sources that don't report this return the zero time.
+	GetRate(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*big.Int, time.Time, error)
+}
+
+// A price oracle with prerequisites that have to be met before it can be queried
+type requiredPriceOracle interface {
+	PriceOracle
+
+	// Make sure the oracle's prerequisites are met
+	Require(c *cli.Context) error
+}
+
+// The 1inch off-chain oracle; each of the oracle contracts is tried in order until one returns a plausible rate, so
+// a deprecated deployment doesn't break the source
+type oneInchPriceOracle struct {
+	oracleAddresses []common.Address
+	rplAddress      common.Address
+	quoteToken      common.Address
+	usesDefault     bool
+}
+
+// Create a new 1inch price oracle
+func newOneInchPriceOracle(cfg *config.RocketPoolConfig) (*oneInchPriceOracle, error) {
+	oracleAddresses, err := getOneInchOracleAddresses(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &oneInchPriceOracle{
+		oracleAddresses: oracleAddresses,
+		rplAddress:      common.HexToAddress(cfg.Smartnode.GetRplTokenAddress()),
+		quoteToken:      common.HexToAddress(cfg.Smartnode.PriceQuoteToken.Value.(string)),
+		usesDefault:     (cfg.Smartnode.OneInchOracleAddresses.Value.(string) == ""),
+	}, nil
+}
+
+// Get the 1inch oracle contracts to query, defaulting to the network's oracle if none are set
+func getOneInchOracleAddresses(cfg *config.RocketPoolConfig) ([]common.Address, error) {
+	addresses := []common.Address{}
+	for _, address := range strings.Split(cfg.Smartnode.OneInchOracleAddresses.Value.(string), ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("Invalid 1inch oracle address '%s'", address)
+		}
+		addresses = append(addresses, common.HexToAddress(address))
+	}
+	if len(addresses) == 0 {
+		addresses = append(addresses, common.HexToAddress(cfg.Smartnode.GetOneInchOracleAddress()))
+	}
+	return addresses, nil
+}
+
+func (o *oneInchPriceOracle) Name() string {
+	return OneInchPriceSource
+}
+
+// The network's default oracle has to be deployed; a custom list of oracles isn't checked since the source skips any
+// of them that aren't deployed
+func (o *oneInchPriceOracle) Require(c *cli.Context) error {
+	if !o.usesDefault {
+		return nil
+	}
+	return services.RequireOneInchOracle(c)
+}
+
+func (o *oneInchPriceOracle) GetRate(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*big.Int, time.Time, error) {
+
+	errMessages := []string{}
+	for _, oracleAddress := range o.oracleAddresses {
+
+		// Generate an OIO wrapper using the client
+		oio, err := contracts.NewOneInchOracle(oracleAddress, client)
+		if err != nil {
+			errMessages = append(errMessages, fmt.Sprintf("%s: %s", oracleAddress.Hex(), err.Error()))
+			continue
+		}
+
+		// Get RPL price; the rate is computed from the DEX pools at the block so it's always current
+		rate, err := getOneInchRate(oio, opts, o.rplAddress, o.quoteToken)
+		if err != nil {
+			errMessages = append(errMessages, fmt.Sprintf("%s: %s", oracleAddress.Hex(), err.Error()))
+			continue
+		}
+		if rate == nil || rate.Sign() <= 0 {
+			errMessages = append(errMessages, fmt.Sprintf("%s: returned a non-positive rate", oracleAddress.Hex()))
+			continue
+		}
+		return rate, time.Time{}, nil
+
+	}
+	return nil, time.Time{}, fmt.Errorf("Could not get RPL price from any of the 1inch oracles: %s", strings.Join(errMessages, "; "))
+
+}
+
+// The 1inch oracle calls used to price RPL
+type oneInchRateSource interface {
+	GetRate(opts *bind.CallOpts, srcToken common.Address, dstToken common.Address, useWrappers bool) (*big.Int, error)
+	GetRateToEth(opts *bind.CallOpts, srcToken common.Address, useSrcWrappers bool) (*big.Int, error)
+}
+
+// Get the RPL rate from a 1inch oracle, quoted in ETH unless a quote token is set
+func getOneInchRate(oio oneInchRateSource, opts *bind.CallOpts, rplAddress common.Address, quoteToken common.Address) (*big.Int, error) {
+	if quoteToken == (common.Address{}) {
+		return oio.GetRateToEth(opts, rplAddress, true)
+	}
+	return oio.GetRate(opts, rplAddress, quoteToken, true)
+}
+
+// The Chainlink price feeds; there's no RPL / ETH feed so the rate is derived from the RPL / USD and ETH / USD feeds
+type chainlinkPriceOracle struct {
+	rplUsdAddress common.Address
+	ethUsdAddress common.Address
+}
+
+// Create a new Chainlink price oracle
+func newChainlinkPriceOracle(cfg *config.RocketPoolConfig) (*chainlinkPriceOracle, error) {
+	rplUsdAddress := cfg.Smartnode.GetChainlinkRplUsdFeedAddress()
+	ethUsdAddress := cfg.Smartnode.GetChainlinkEthUsdFeedAddress()
+	if rplUsdAddress == "" || ethUsdAddress == "" {
+		return nil, fmt.Errorf("The %s price source is not available on the %s network", ChainlinkPriceSource, cfg.Smartnode.Network.Value)
+	}
+	return &chainlinkPriceOracle{
+		rplUsdAddress: common.HexToAddress(rplUsdAddress),
+		ethUsdAddress: common.HexToAddress(ethUsdAddress),
+	}, nil
+}
+
+func (o *chainlinkPriceOracle) Name() string {
+	return ChainlinkPriceSource
+}
+
+func (o *chainlinkPriceOracle) GetRate(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*big.Int, time.Time, error) {
+
+	// Get the USD prices
+	rplUsd, rplUpdatedAt, err := o.getFeedAnswer(client, o.rplUsdAddress, opts)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Could not get the RPL / USD price from Chainlink: %w", err)
+	}
+	ethUsd, ethUpdatedAt, err := o.getFeedAnswer(client, o.ethUsdAddress, opts)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Could not get the ETH / USD price from Chainlink: %w", err)
+	}
+
+	// The rate is only as fresh as the older of the two feeds
+	updatedAt := rplUpdatedAt
+	if ethUpdatedAt.Before(updatedAt) {
+		updatedAt = ethUpdatedAt
+	}
+
+	// RPL / ETH = (RPL / USD) / (ETH / USD), scaled to wei
+	rate := big.NewInt(0).Mul(rplUsd, big.NewInt(1e18))
+	return rate.Quo(rate, ethUsd), updatedAt, nil
+
+}
+
+// Get the latest answer from a Chainlink feed normalized to 18 decimal places, along with when it was last updated
+func (o *chainlinkPriceOracle) getFeedAnswer(client rocketpool.ExecutionClient, address common.Address, opts *bind.CallOpts) (*big.Int, time.Time, error) {
+
+	parsed, err := abi.JSON(strings.NewReader(chainlinkAggregatorAbi))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Error decoding Chainlink aggregator ABI: %w", err)
+	}
+	feed := bind.NewBoundContract(address, parsed, client, client, client)
+
+	// Get the answer and its precision
+	var out []interface{}
+	if err := feed.Call(opts, &out, "decimals"); err != nil {
+		return nil, time.Time{}, err
+	}
+	decimals := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+	out = nil
+	if err := feed.Call(opts, &out, "latestRoundData"); err != nil {
+		return nil, time.Time{}, err
+	}
+	answer := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	updatedAt := *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	if answer.Sign() <= 0 {
+		return nil, time.Time{}, fmt.Errorf("feed %s returned a non-positive answer (%s)", address.Hex(), answer.String())
+	}
+
+	// Normalize the answer
+	return scaleToWei(answer, uint64(decimals)), time.Unix(updatedAt.Int64(), 0), nil
+
+}
+
+// Scale a fixed-point value with the given number of decimal places to 18 decimal places
+func scaleToWei(value *big.Int, decimals uint64) *big.Int {
+	if decimals < 18 {
+		scale := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(18-decimals)), nil)
+		return big.NewInt(0).Mul(value, scale)
+	}
+	scale := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(decimals-18)), nil)
+	return big.NewInt(0).Quo(value, scale)
+}
+
+// A price feed contract deployed by the operator that reports the RPL / ETH price directly, read with either the
+// Chainlink-style latestRoundData method or a plain latestAnswer method
+type contractPriceOracle struct {
+	feedAddress common.Address
+	method      cfgtypes.PriceFeedMethod
+	decimals    uint64
+}
+
+// Create a new contract price oracle
+func newContractPriceOracle(cfg *config.RocketPoolConfig) (*contractPriceOracle, error) {
+	feedAddress := cfg.Smartnode.PriceFeedAddress.Value.(string)
+	if feedAddress == "" {
+		return nil, fmt.Errorf("The %s price source requires a price feed address to be set", ContractPriceSource)
+	}
+	if !common.IsHexAddress(feedAddress) {
+		return nil, fmt.Errorf("Invalid price feed address '%s'", feedAddress)
+	}
+	method := cfg.Smartnode.PriceFeedMethod.Value.(cfgtypes.PriceFeedMethod)
+	if method != cfgtypes.PriceFeedMethod_LatestRoundData && method != cfgtypes.PriceFeedMethod_LatestAnswer {
+		return nil, fmt.Errorf("Unknown price feed method '%s'", method)
+	}
+	return &contractPriceOracle{
+		feedAddress: common.HexToAddress(feedAddress),
+		method:      method,
+		decimals:    cfg.Smartnode.PriceFeedDecimals.Value.(uint64),
+	}, nil
+}
+
+func (o *contractPriceOracle) Name() string {
+	return ContractPriceSource
+}
+
+func (o *contractPriceOracle) GetRate(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*big.Int, time.Time, error) {
+
+	parsed, err := abi.JSON(strings.NewReader(priceFeedAbi))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Error decoding price feed ABI: %w", err)
+	}
+	feed := bind.NewBoundContract(o.feedAddress, parsed, client, client, client)
+
+	// Get the answer
+	var out []interface{}
+	if err := feed.Call(opts, &out, string(o.method)); err != nil {
+		return nil, time.Time{}, fmt.Errorf("Could not call %s on price feed %s: %w", o.method, o.feedAddress.Hex(), err)
+	}
+	answer, updatedAt, err := decodePriceFeedAnswer(o.method, out)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Invalid answer from price feed %s: %w", o.feedAddress.Hex(), err)
+	}
+	return scaleToWei(answer, o.decimals), updatedAt, nil
+
+}
+
+// Decode the answer and update time from the outputs of a price feed method; latestAnswer has no update time
+func decodePriceFeedAnswer(method cfgtypes.PriceFeedMethod, out []interface{}) (*big.Int, time.Time, error) {
+
+	var answer *big.Int
+	updatedAt := time.Time{}
+	switch method {
+	case cfgtypes.PriceFeedMethod_LatestRoundData:
+		if len(out) < 5 {
+			return nil, time.Time{}, fmt.Errorf("expected 5 outputs from %s but got %d", method, len(out))
+		}
+		answer = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+		updatedAtSeconds := *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+		updatedAt = time.Unix(updatedAtSeconds.Int64(), 0)
+	case cfgtypes.PriceFeedMethod_LatestAnswer:
+		if len(out) < 1 {
+			return nil, time.Time{}, fmt.Errorf("%s returned no outputs", method)
+		}
+		answer = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	default:
+		return nil, time.Time{}, fmt.Errorf("unknown method '%s'", method)
+	}
+
+	if answer.Sign() <= 0 {
+		return nil, time.Time{}, fmt.Errorf("non-positive answer (%s)", answer.String())
+	}
+	return answer, updatedAt, nil
+
+}
+
+// A Uniswap V3 RPL / WETH pool, priced by its time-weighted average over a window so the price can't be moved within
+// a single block; its price is rejected if the pool didn't have enough in-range liquidity over the window to make it
+// expensive to manipulate
+type uniswapPriceOracle struct {
+	poolAddress  common.Address
+	rplAddress   common.Address
+	wethAddress  common.Address
+	twapSeconds  uint32
+	minLiquidity *big.Int
+}
+
+// Create a new Uniswap price oracle
+func newUniswapPriceOracle(cfg *config.RocketPoolConfig) (*uniswapPriceOracle, error) {
+	poolAddress := cfg.Smartnode.UniswapPoolAddress.Value.(string)
+	if poolAddress == "" {
+		return nil, fmt.Errorf("The %s price source requires a Uniswap pool address to be set", UniswapPriceSource)
+	}
+	if !common.IsHexAddress(poolAddress) {
+		return nil, fmt.Errorf("Invalid Uniswap pool address '%s'", poolAddress)
+	}
+	twapSeconds := cfg.Smartnode.UniswapTwapSeconds.Value.(uint64)
+	if twapSeconds == 0 || twapSeconds > math.MaxUint32 {
+		return nil, fmt.Errorf("Invalid Uniswap TWAP window %d; it must be between 1 and %d seconds", twapSeconds, uint32(math.MaxUint32))
+	}
+	return &uniswapPriceOracle{
+		poolAddress:  common.HexToAddress(poolAddress),
+		rplAddress:   common.HexToAddress(cfg.Smartnode.GetRplTokenAddress()),
+		wethAddress:  common.HexToAddress(cfg.Smartnode.GetWethAddress()),
+		twapSeconds:  uint32(twapSeconds),
+		minLiquidity: eth.EthToWei(cfg.Smartnode.MinLiquidity.Value.(float64)),
+	}, nil
+}
+
+func (o *uniswapPriceOracle) Name() string {
+	return UniswapPriceSource
+}
+
+func (o *uniswapPriceOracle) GetRate(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*big.Int, time.Time, error) {
+
+	parsed, err := abi.JSON(strings.NewReader(uniswapPoolAbi))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Error decoding Uniswap pool ABI: %w", err)
+	}
+	pool := bind.NewBoundContract(o.poolAddress, parsed, client, client, client)
+
+	// Make sure the pool is an RPL / WETH pool and get its token order
+	var out []interface{}
+	if err := pool.Call(opts, &out, "token0"); err != nil {
+		return nil, time.Time{}, fmt.Errorf("Could not get token0 of Uniswap pool %s: %w", o.poolAddress.Hex(), err)
+	}
+	token0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	out = nil
+	if err := pool.Call(opts, &out, "token1"); err != nil {
+		return nil, time.Time{}, fmt.Errorf("Could not get token1 of Uniswap pool %s: %w", o.poolAddress.Hex(), err)
+	}
+	token1 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	rplIsToken0, err := getUniswapTokenOrder(token0, token1, o.rplAddress, o.wethAddress)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Uniswap pool %s %w", o.poolAddress.Hex(), err)
+	}
+
+	// Get the cumulative tick and liquidity at the start and end of the window
+	out = nil
+	if err := pool.Call(opts, &out, "observe", []uint32{o.twapSeconds, 0}); err != nil {
+		return nil, time.Time{}, fmt.Errorf("Could not get the %d second TWAP from Uniswap pool %s: %w", o.twapSeconds, o.poolAddress.Hex(), err)
+	}
+	tickCumulatives := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+	secondsPerLiquidityCumulatives := *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int)
+	if len(tickCumulatives) != 2 || len(secondsPerLiquidityCumulatives) != 2 {
+		return nil, time.Time{}, fmt.Errorf("Uniswap pool %s returned an unexpected number of observations", o.poolAddress.Hex())
+	}
+
+	// Get the average price and liquidity over the window
+	tick := getUniswapMeanTick(tickCumulatives[0], tickCumulatives[1], o.twapSeconds)
+	sqrtPriceX96, err := getUniswapSqrtPriceAtTick(tick)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Uniswap pool %s: %w", o.poolAddress.Hex(), err)
+	}
+	liquidity := getUniswapHarmonicMeanLiquidity(secondsPerLiquidityCumulatives[0], secondsPerLiquidityCumulatives[1], o.twapSeconds)
+
+	// Reject the price if the pool is too thin
+	ethLiquidity := getUniswapEthLiquidity(liquidity, sqrtPriceX96, rplIsToken0)
+	if !hasSufficientLiquidity(ethLiquidity, o.minLiquidity) {
+		return nil, time.Time{}, fmt.Errorf("in-range liquidity of Uniswap pool %s is %.6f ETH, below the minimum of %.6f ETH", o.poolAddress.Hex(), eth.WeiToEth(ethLiquidity), eth.WeiToEth(o.minLiquidity))
+	}
+
+	return getUniswapRate(sqrtPriceX96, rplIsToken0), time.Time{}, nil
+
+}
+
+// Check that a Uniswap pool's tokens are RPL and WETH, returning whether RPL is token0
+func getUniswapTokenOrder(token0 common.Address, token1 common.Address, rplAddress common.Address, wethAddress common.Address) (bool, error) {
+	switch {
+	case token0 == rplAddress && token1 == wethAddress:
+		return true, nil
+	case token0 == wethAddress && token1 == rplAddress:
+		return false, nil
+	default:
+		return false, fmt.Errorf("is not an RPL / WETH pool (tokens %s and %s)", token0.Hex(), token1.Hex())
+	}
+}
+
+// Get the arithmetic mean tick over a window from the cumulative ticks at its start and end, rounded towards negative
+// infinity like Uniswap's OracleLibrary
+func getUniswapMeanTick(startCumulative *big.Int, endCumulative *big.Int, seconds uint32) int64 {
+	delta := big.NewInt(0).Sub(endCumulative, startCumulative)
+	window := big.NewInt(int64(seconds))
+	tick, remainder := big.NewInt(0).QuoRem(delta, window, big.NewInt(0))
+	if delta.Sign() < 0 && remainder.Sign() != 0 {
+		tick.Sub(tick, big.NewInt(1))
+	}
+	return tick.Int64()
+}
+
+// Get the harmonic mean in-range liquidity over a window from the cumulative seconds per liquidity at its start and
+// end, like Uniswap's OracleLibrary. The cumulative values are uint160s that wrap around.
+func getUniswapHarmonicMeanLiquidity(startCumulativeX128 *big.Int, endCumulativeX128 *big.Int, seconds uint32) *big.Int {
+	delta := big.NewInt(0).Sub(endCumulativeX128, startCumulativeX128)
+	delta.Mod(delta, big.NewInt(0).Lsh(big.NewInt(1), 160))
+	if delta.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	secondsX160 := big.NewInt(0).Mul(big.NewInt(int64(seconds)), big.NewInt(0).Sub(big.NewInt(0).Lsh(big.NewInt(1), 160), big.NewInt(1)))
+	return secondsX160.Quo(secondsX160, delta.Lsh(delta, 32))
+}
+
+// The bit multipliers Uniswap's TickMath uses to compute 1 / sqrt(1.0001)^tick as a Q128.128 number
+var uniswapTickRatios = []string{
+	"fff97272373d413259a46990580e213a",
+	"fff2e50f5f656932ef12357cf3c7fdcc",
+	"ffe5caca7e10e4e61c3624eaa0941cd0",
+	"ffcb9843d60f6159c9db58835c926644",
+	"ff973b41fa98c081472e6896dfb254c0",
+	"ff2ea16466c96a3843ec78b326b52861",
+	"fe5dee046a99a2a811c461f1969c3053",
+	"fcbe86c7900a88aedcffc83b479aa3a4",
+	"f987a7253ac413176f2b074cf7815e54",
+	"f3392b0822b70005940c7a398e4b70f3",
+	"e7159475a2c29b7443b29c7fa6e889d9",
+	"d097f3bdfd2022b8845ad8f792aa5825",
+	"a9f746462d870fdf8a65dc1f90e061e5",
+	"70d869a156d2a1b890bb3df62baf32f7",
+	"31be135f97d08fd981231505542fcfa6",
+	"9aa508b5b7a84e1c677de54f3e99bc9",
+	"5d6af8dedb81196699c329225ee604",
+	"2216e584f5fa1ea926041bedfe98",
+	"48a170391f7dc42444e8fa2",
+}
+
+// The largest tick a Uniswap V3 pool supports
+const uniswapMaxTick int64 = 887272
+
+// Get the sqrt price of a tick as a Q64.96 number, like Uniswap's TickMath.getSqrtRatioAtTick
+func getUniswapSqrtPriceAtTick(tick int64) (*big.Int, error) {
+
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+	if absTick > uniswapMaxTick {
+		return nil, fmt.Errorf("tick %d is out of range", tick)
+	}
+
+	ratio := big.NewInt(0).Lsh(big.NewInt(1), 128)
+	if absTick&1 != 0 {
+		ratio.SetString("fffcb933bd6fad37aa2d162d1a594001", 16)
+	}
+	for i, multiplier := range uniswapTickRatios {
+		if absTick&(2<<i) != 0 {
+			m, _ := big.NewInt(0).SetString(multiplier, 16)
+			ratio.Mul(ratio, m).Rsh(ratio, 128)
+		}
+	}
+	if tick > 0 {
+		maxUint256 := big.NewInt(0).Sub(big.NewInt(0).Lsh(big.NewInt(1), 256), big.NewInt(1))
+		ratio.Quo(maxUint256, ratio)
+	}
+
+	// Round up when converting from Q128.128 to Q64.96
+	sqrtPriceX96 := big.NewInt(0).Rsh(ratio, 32)
+	if big.NewInt(0).And(ratio, big.NewInt(0xffffffff)).Sign() != 0 {
+		sqrtPriceX96.Add(sqrtPriceX96, big.NewInt(1))
+	}
+	return sqrtPriceX96, nil
+
+}
+
+// Get the amount of ETH (in wei) that 1 RPL is worth from a Uniswap V3 pool's sqrt price
+func getUniswapRate(sqrtPriceX96 *big.Int, rplIsToken0 bool) *big.Int {
+
+	// The pool price is token1 / token0 = sqrtPriceX96^2 / 2^192
+	priceX192 := big.NewInt(0).Mul(sqrtPriceX96, sqrtPriceX96)
+	if rplIsToken0 {
+		rate := big.NewInt(0).Mul(priceX192, big.NewInt(1e18))
+		return rate.Rsh(rate, 192)
+	}
+	rate := big.NewInt(0).Lsh(big.NewInt(1e18), 192)
+	return rate.Quo(rate, priceX192)
+
+}
+
+// Get the ETH side (in wei) of a Uniswap V3 pool's in-range liquidity, as the virtual reserve at the given price
+func getUniswapEthLiquidity(liquidity *big.Int, sqrtPriceX96 *big.Int, rplIsToken0 bool) *big.Int {
+
+	// The virtual reserves are token0 = L * 2^96 / sqrtPriceX96 and token1 = L * sqrtPriceX96 / 2^96
+	if rplIsToken0 {
+		reserve := big.NewInt(0).Mul(liquidity, sqrtPriceX96)
+		return reserve.Rsh(reserve, 96)
+	}
+	reserve := big.NewInt(0).Lsh(liquidity, 96)
+	return reserve.Quo(reserve, sqrtPriceX96)
+
+}
+
+// Check if a pool has enough liquidity for its price to be used; a minimum of zero disables the check
+func hasSufficientLiquidity(liquidity *big.Int, minLiquidity *big.Int) bool {
+	return minLiquidity.Sign() == 0 || liquidity.Cmp(minLiquidity) >= 0
+}
+
+// Check if a price last updated at the given time is too old to use for a block with the given time.
+// Prices without an update time, and any price when the max staleness is 0, are never stale.
+func isPriceStale(updatedAt time.Time, blockTime time.Time, maxStaleness time.Duration) bool {
+	if maxStaleness == 0 || updatedAt.IsZero() {
+		return false
+	}
+	return blockTime.Sub(updatedAt) > maxStaleness
+}
+
+// Get the names of the price sources enabled in the config, defaulting to 1inch if none are set
+func getPriceSourceNames(cfg *config.RocketPoolConfig) []string {
+	names := []string{}
+	for _, name := range strings.Split(cfg.Smartnode.PriceSources.Value.(string), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		names = append(names, OneInchPriceSource)
+	}
+	return names
+}
+
+// Get the price oracles the watchtower is configured to use
+func getPriceOracles(cfg *config.RocketPoolConfig) ([]PriceOracle, error) {
+	oracles := []PriceOracle{}
+	seen := map[string]bool{}
+	for _, name := range getPriceSourceNames(cfg) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		switch name {
+		case OneInchPriceSource:
+			oracle, err := newOneInchPriceOracle(cfg)
+			if err != nil {
+				return nil, err
+			}
+			oracles = append(oracles, oracle)
+		case ChainlinkPriceSource:
+			oracle, err := newChainlinkPriceOracle(cfg)
+			if err != nil {
+				return nil, err
+			}
+			oracles = append(oracles, oracle)
+		case UniswapPriceSource:
+			oracle, err := newUniswapPriceOracle(cfg)
+			if err != nil {
+				return nil, err
+			}
+			oracles = append(oracles, oracle)
+		case ContractPriceSource:
+			oracle, err := newContractPriceOracle(cfg)
+			if err != nil {
+				return nil, err
+			}
+			oracles = append(oracles, oracle)
+		default:
+			return nil, fmt.Errorf("Unknown RPL price source [%s]; supported sources are %s, %s, %s and %s", name, OneInchPriceSource, ChainlinkPriceSource, UniswapPriceSource, ContractPriceSource)
+		}
+	}
+	return oracles, nil
+}
+
+// Make sure the prerequisites of the configured price oracles are met; sources that aren't enabled aren't checked
+func requirePriceOracles(c *cli.Context, oracles []PriceOracle) error {
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return err
+	}
+	for _, oracle := range oracles {
+		if required, ok := oracle.(requiredPriceOracle); ok {
+			if err := required.Require(c); err != nil {
+				return fmt.Errorf("The %s price source is unavailable: %w", oracle.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// A price reported by one of the oracles
+type priceCandidate struct {
+	Source string
+	Price  *big.Int
+}
+
+// Get the RPL price from each of the oracles, skipping any that fail or are stale as long as at least one succeeds.
+// The oracles are queried concurrently, but the candidates are always returned in the order the oracles were
+// configured in so logs and tie-breaking don't depend on which one responds first.
+func getRplPriceCandidates(client rocketpool.ExecutionClient, oracles []PriceOracle, opts *bind.CallOpts, blockTime time.Time, maxStaleness time.Duration, minRate *big.Int, maxRate *big.Int, timeout time.Duration, printMessage func(string)) ([]priceCandidate, error) {
+
+	// Query the oracles, storing each result at the oracle's index
+	prices := make([]*big.Int, len(oracles))
+	updatedAts := make([]time.Time, len(oracles))
+	errs := make([]error, len(oracles))
+	var wg sync.WaitGroup
+	for i, oracle := range oracles {
+		wg.Add(1)
+		go func(i int, oracle PriceOracle) {
+			defer wg.Done()
+			prices[i], updatedAts[i], errs[i] = getRateWithTimeout(oracle, client, opts, timeout)
+		}(i, oracle)
+	}
+	wg.Wait()
+
+	// Collect the results in order
+	candidates := []priceCandidate{}
+	errMessages := []string{}
+	for i, oracle := range oracles {
+		if errs[i] != nil {
+			printMessage(fmt.Sprintf("WARNING: could not get the RPL price from %s: %s", oracle.Name(), errs[i].Error()))
+			errMessages = append(errMessages, errs[i].Error())
+			continue
+		}
+		if isRateTooLow(prices[i], minRate) {
+			lowErr := fmt.Sprintf("%s returned a rate of %s wei, below the minimum of %s wei", oracle.Name(), prices[i].String(), minRate.String())
+			printMessage(fmt.Sprintf("WARNING: excluding the RPL price from %s because it can't price RPL: %s", oracle.Name(), lowErr))
+			errMessages = append(errMessages, lowErr)
+			continue
+		}
+		if isRateTooHigh(prices[i], maxRate) {
+			highErr := fmt.Sprintf("%s returned a rate of %s wei, above the maximum of %s wei", oracle.Name(), prices[i].String(), maxRate.String())
+			printMessage(fmt.Sprintf("WARNING: excluding the RPL price from %s because it is implausibly high: %s", oracle.Name(), highErr))
+			errMessages = append(errMessages, highErr)
+			continue
+		}
+		if isPriceStale(updatedAts[i], blockTime, maxStaleness) {
+			staleErr := fmt.Sprintf("%s price was last updated at %s, more than %s before the block", oracle.Name(), updatedAts[i].Format(time.RFC1123), maxStaleness)
+			printMessage(fmt.Sprintf("WARNING: excluding the RPL price from %s because it is stale: %s", oracle.Name(), staleErr))
+			errMessages = append(errMessages, staleErr)
+			continue
+		}
+		candidates = append(candidates, priceCandidate{
+			Source: oracle.Name(),
+			Price:  prices[i],
+		})
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("none of the price sources returned a price: %s", strings.Join(errMessages, "; "))
+	}
+	return candidates, nil
+
+}
+
+// Make sure enough of the price sources returned a usable price that no single source decides the submitted price
+func checkSuccessfulSources(candidates []priceCandidate, minSuccessful uint64) error {
+	if uint64(len(candidates)) < minSuccessful {
+		return fmt.Errorf("only %d of the price sources returned a usable price, fewer than the minimum of %d", len(candidates), minSuccessful)
+	}
+	return nil
+}
+
+// Get the rate from an oracle, giving up once the timeout passes; a timeout of 0 waits for as long as the oracle takes.
+// The call is cancelled through the context in the call options, and a source that doesn't stop when it's cancelled
+// is left to finish in the background so it can't hold up the caller.
+func getRateWithTimeout(oracle PriceOracle, client rocketpool.ExecutionClient, opts *bind.CallOpts, timeout time.Duration) (*big.Int, time.Time, error) {
+
+	if timeout == 0 {
+		return oracle.GetRate(client, opts)
+	}
+
+	parent := context.Background()
+	if opts.Context != nil {
+		parent = opts.Context
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+	sourceOpts := *opts
+	sourceOpts.Context = ctx
+
+	type rateResult struct {
+		rate      *big.Int
+		updatedAt time.Time
+		err       error
+	}
+	results := make(chan rateResult, 1)
+	go func() {
+		rate, updatedAt, err := oracle.GetRate(client, &sourceOpts)
+		results <- rateResult{rate, updatedAt, err}
+	}()
+
+	select {
+	case result := <-results:
+		return result.rate, result.updatedAt, result.err
+	case <-ctx.Done():
+		return nil, time.Time{}, fmt.Errorf("%s did not return a price within %s", oracle.Name(), timeout)
+	}
+
+}
+
+// Get the configured time each price source has to return a price
+func getOracleSourceTimeout(cfg *config.RocketPoolConfig) time.Duration {
+	return time.Duration(cfg.Smartnode.OracleSourceTimeout.Value.(uint64)) * time.Second
+}
+
+// Check if an oracle rate is zero or below the configured minimum, which oracles return when they can't price the pair
+func isRateTooLow(rate *big.Int, minRate *big.Int) bool {
+	if rate == nil || rate.Sign() <= 0 {
+		return true
+	}
+	return minRate != nil && rate.Cmp(minRate) < 0
+}
+
+// Get the lowest oracle rate to accept, in wei
+func getMinOracleRate(cfg *config.RocketPoolConfig) *big.Int {
+	return eth.EthToWei(cfg.Smartnode.MinOracleRate.Value.(float64))
+}
+
+// Check if an oracle rate is above the configured maximum, which means the oracle is faulty; a maximum of 0 accepts
+// any rate
+func isRateTooHigh(rate *big.Int, maxRate *big.Int) bool {
+	return maxRate != nil && maxRate.Sign() > 0 && rate.Cmp(maxRate) > 0
+}
+
+// Get the highest oracle rate to accept, in wei
+func getMaxOracleRate(cfg *config.RocketPoolConfig) *big.Int {
+	return eth.EthToWei(cfg.Smartnode.MaxOracleRate.Value.(float64))
+}
+
+// Get the median of a set of prices; with an even number of prices, the tie-break decides how the two in the middle
+// are combined so every node using the same setting agrees on the result
+func medianPrice(candidates []priceCandidate, tieBreak cfgtypes.MedianTieBreak) *big.Int {
+
+	prices := make([]*big.Int, len(candidates))
+	for i, candidate := range candidates {
+		prices[i] = candidate.Price
+	}
+	sort.SliceStable(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+
+	middle := len(prices) / 2
+	if len(prices)%2 == 1 {
+		return big.NewInt(0).Set(prices[middle])
+	}
+	switch tieBreak {
+	case cfgtypes.MedianTieBreak_Lower:
+		return big.NewInt(0).Set(prices[middle-1])
+	case cfgtypes.MedianTieBreak_Higher:
+		return big.NewInt(0).Set(prices[middle])
+	default:
+		median := big.NewInt(0).Add(prices[middle-1], prices[middle])
+		return median.Quo(median, big.NewInt(2))
+	}
+
+}
+
+// Get the configured way of choosing the median of an even number of prices
+func getMedianTieBreak(cfg *config.RocketPoolConfig) cfgtypes.MedianTieBreak {
+	return cfg.Smartnode.MedianTieBreak.Value.(cfgtypes.MedianTieBreak)
+}
+
+// Get the RPL price to submit from the oracle prices: their median, truncated to the configured precision
+func getSubmissionPrice(candidates []priceCandidate, cfg *config.RocketPoolConfig) *big.Int {
+	return truncateToSigFigs(medianPrice(candidates, getMedianTieBreak(cfg)), int(cfg.Smartnode.PriceSigFigs.Value.(uint64)))
+}
+
+// Truncate a value to a number of significant figures, zeroing the digits after them; a count of 0 or less leaves the
+// value unchanged
+func truncateToSigFigs(value *big.Int, n int) *big.Int {
+	truncated := big.NewInt(0).Set(value)
+	if n <= 0 {
+		return truncated
+	}
+	digits := len(big.NewInt(0).Abs(value).String())
+	if digits <= n {
+		return truncated
+	}
+	factor := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(digits-n)), nil)
+	truncated.Quo(truncated, factor)
+	return truncated.Mul(truncated, factor)
+}
+
+// Get the RPL price at a block from the configured oracles
+func getRplPriceAtBlock(c *cli.Context, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, blockNumber uint64, printMessage func(string)) (*big.Int, error) {
+	candidates, err := getRplPriceCandidatesAtBlock(c, rp, cfg, blockNumber, printMessage)
+	if err != nil {
+		return nil, err
+	}
+	return getSubmissionPrice(candidates, cfg), nil
+}
+
+// Get the RPL price at a block from each of the configured oracles
+func getRplPriceCandidatesAtBlock(c *cli.Context, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, blockNumber uint64, printMessage func(string)) ([]priceCandidate, error) {
+
+	// Get the oracles
+	oracles, err := getPriceOracles(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := requirePriceOracles(c, oracles); err != nil {
+		return nil, err
+	}
+
+	// Initialize call options
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(int64(blockNumber)),
+	}
+
+	// Get a client with the block number available
+	client, err := eth1.GetBestApiClient(rp, cfg, printMessage, opts.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireArchiveAt(client, blockNumber); err != nil {
+		return nil, err
+	}
+
+	// Get the block time to check the prices' staleness against
+	header, err := client.Client.HeaderByNumber(context.Background(), opts.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting header for block %d: %w", blockNumber, err)
+	}
+	blockTime := time.Unix(int64(header.Time), 0)
+	maxStaleness := time.Duration(cfg.Smartnode.MaxStalenessSeconds.Value.(uint64)) * time.Second
+
+	// Get RPL price
+	candidates, err := getRplPriceCandidates(client.Client, oracles, opts, blockTime, maxStaleness, getMinOracleRate(cfg), getMaxOracleRate(cfg), getOracleSourceTimeout(cfg), printMessage)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get RPL price at block %d: %w", blockNumber, err)
+	}
+	if err := checkSuccessfulSources(candidates, cfg.Smartnode.MinSuccessfulSources.Value.(uint64)); err != nil {
+		return nil, fmt.Errorf("Could not get RPL price at block %d: %w", blockNumber, err)
+	}
+	if len(candidates) > 1 {
+		for _, candidate := range candidates {
+			printMessage(fmt.Sprintf("RPL price from %s: %s wei", candidate.Source, candidate.Price.String()))
+		}
+	}
+
+	// Return
+	return candidates, nil
+
+}
@@ -0,0 +1,758 @@
+package watchtower
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func TestGetPriceSourceNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources string
+		want    []string
+	}{
+		{name: "empty defaults to 1inch", sources: "", want: []string{OneInchPriceSource}},
+		{name: "blank entries default to 1inch", sources: " , ,", want: []string{OneInchPriceSource}},
+		{name: "single source", sources: ChainlinkPriceSource, want: []string{ChainlinkPriceSource}},
+		{name: "trimmed and lowercased", sources: " 1INCH , Chainlink ", want: []string{OneInchPriceSource, ChainlinkPriceSource}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.NewRocketPoolConfig("", true)
+			cfg.Smartnode.PriceSources.Value = test.sources
+			if got := getPriceSourceNames(cfg); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("getPriceSourceNames(%q) = %v, want %v", test.sources, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetPriceOracles(t *testing.T) {
+	cfg := config.NewRocketPoolConfig("", true)
+
+	// Duplicates only create one oracle
+	cfg.Smartnode.PriceSources.Value = "1inch,1inch"
+	oracles, err := getPriceOracles(cfg)
+	if err != nil {
+		t.Fatalf("getPriceOracles() returned an error: %s", err)
+	}
+	if len(oracles) != 1 || oracles[0].Name() != OneInchPriceSource {
+		t.Fatalf("getPriceOracles() returned %d oracles, want only %s", len(oracles), OneInchPriceSource)
+	}
+
+	// Unknown sources are rejected by name
+	cfg.Smartnode.PriceSources.Value = "1inch,uniswap-v1"
+	if _, err := getPriceOracles(cfg); err == nil {
+		t.Fatal("getPriceOracles() didn't return an error for an unknown source")
+	} else if !strings.Contains(err.Error(), "uniswap-v1") {
+		t.Errorf("getPriceOracles() error %q doesn't name the unknown source", err)
+	}
+}
+
+// Create price candidates from a list of prices
+func newTestPriceCandidates(prices ...int64) []priceCandidate {
+	candidates := make([]priceCandidate, len(prices))
+	for i, price := range prices {
+		candidates[i] = priceCandidate{Source: fmt.Sprintf("source%d", i), Price: big.NewInt(price)}
+	}
+	return candidates
+}
+
+func TestMedianPrice(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []priceCandidate
+		tieBreak   cfgtypes.MedianTieBreak
+		want       int64
+	}{
+		{"single price", newTestPriceCandidates(100), cfgtypes.MedianTieBreak_Average, 100},
+		{"odd number of prices", newTestPriceCandidates(300, 100, 200), cfgtypes.MedianTieBreak_Lower, 200},
+		{"average", newTestPriceCandidates(400, 100, 200, 300), cfgtypes.MedianTieBreak_Average, 250},
+		{"average rounds down", newTestPriceCandidates(100, 201), cfgtypes.MedianTieBreak_Average, 150},
+		{"lower", newTestPriceCandidates(400, 100, 200, 300), cfgtypes.MedianTieBreak_Lower, 200},
+		{"higher", newTestPriceCandidates(400, 100, 200, 300), cfgtypes.MedianTieBreak_Higher, 300},
+		{"unknown averages", newTestPriceCandidates(100, 200), cfgtypes.MedianTieBreak_Unknown, 150},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := medianPrice(test.candidates, test.tieBreak); got.Int64() != test.want {
+				t.Errorf("medianPrice(%s) = %s, want %d", test.tieBreak, got, test.want)
+			}
+		})
+	}
+}
+
+// A price oracle that returns a fixed price or error after a delay
+type fakePriceOracle struct {
+	name      string
+	price     *big.Int
+	updatedAt time.Time
+	err       error
+	delay     time.Duration
+}
+
+func (o *fakePriceOracle) Name() string {
+	return o.name
+}
+
+func (o *fakePriceOracle) GetRate(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*big.Int, time.Time, error) {
+	time.Sleep(o.delay)
+	return o.price, o.updatedAt, o.err
+}
+
+// Get the price candidates from a set of oracles with the checks that don't apply to the test disabled
+func getTestPriceCandidates(oracles []PriceOracle) ([]priceCandidate, error) {
+	return getTestPriceCandidatesWithChecks(oracles, testPriceChecks{})
+}
+
+func TestGetRplPriceCandidatesOrder(t *testing.T) {
+	// The first oracle responds last, but its candidate still comes first
+	oracles := []PriceOracle{
+		&fakePriceOracle{name: "slow", price: big.NewInt(1), delay: 150 * time.Millisecond},
+		&fakePriceOracle{name: "failing", err: errors.New("unavailable"), delay: 50 * time.Millisecond},
+		&fakePriceOracle{name: "fast", price: big.NewInt(2)},
+		&fakePriceOracle{name: "medium", price: big.NewInt(3), delay: 100 * time.Millisecond},
+	}
+
+	start := time.Now()
+	candidates, err := getTestPriceCandidates(oracles)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("getRplPriceCandidates() returned an error: %s", err)
+	}
+
+	want := []string{"slow", "fast", "medium"}
+	if len(candidates) != len(want) {
+		t.Fatalf("getRplPriceCandidates() returned %d candidates, want %d", len(candidates), len(want))
+	}
+	for i, candidate := range candidates {
+		if candidate.Source != want[i] {
+			t.Errorf("candidate %d is from %s, want %s", i, candidate.Source, want[i])
+		}
+	}
+
+	// The oracles were queried at the same time rather than one after the other
+	if elapsed >= 250*time.Millisecond {
+		t.Errorf("getRplPriceCandidates() took %s, want the oracles to be queried concurrently", elapsed)
+	}
+}
+
+func TestGetRplPriceCandidatesAllFail(t *testing.T) {
+	oracles := []PriceOracle{
+		&fakePriceOracle{name: "first", err: errors.New("first failed")},
+		&fakePriceOracle{name: "second", err: errors.New("second failed")},
+	}
+	if _, err := getTestPriceCandidates(oracles); err == nil {
+		t.Fatal("getRplPriceCandidates() didn't return an error when every oracle failed")
+	}
+}
+
+// Checks applied to the oracle prices in tests; the zero value disables all of them
+type testPriceChecks struct {
+	blockTime    time.Time
+	maxStaleness time.Duration
+	minRate      *big.Int
+	maxRate      *big.Int
+	timeout      time.Duration
+}
+
+// Get the price candidates from a set of oracles with the given checks
+func getTestPriceCandidatesWithChecks(oracles []PriceOracle, checks testPriceChecks) ([]priceCandidate, error) {
+	return getRplPriceCandidates(nil, oracles, &bind.CallOpts{}, checks.blockTime, checks.maxStaleness, checks.minRate, checks.maxRate, checks.timeout, func(string) {})
+}
+
+func TestIsPriceStale(t *testing.T) {
+	blockTime := time.Unix(1660000000, 0)
+	tests := []struct {
+		name         string
+		updatedAt    time.Time
+		maxStaleness time.Duration
+		want         bool
+	}{
+		{"fresh", blockTime.Add(-time.Minute), time.Hour, false},
+		{"at the limit", blockTime.Add(-time.Hour), time.Hour, false},
+		{"stale", blockTime.Add(-time.Hour - time.Second), time.Hour, true},
+		{"updated after the block", blockTime.Add(time.Minute), time.Hour, false},
+		{"no update time", time.Time{}, time.Hour, false},
+		{"check disabled", blockTime.Add(-24 * time.Hour), 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isPriceStale(test.updatedAt, blockTime, test.maxStaleness); got != test.want {
+				t.Errorf("isPriceStale() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetRplPriceCandidatesExcludesStalePrices(t *testing.T) {
+	blockTime := time.Unix(1660000000, 0)
+	oracles := []PriceOracle{
+		&fakePriceOracle{name: "stale", price: big.NewInt(1), updatedAt: blockTime.Add(-2 * time.Hour)},
+		&fakePriceOracle{name: "fresh", price: big.NewInt(2), updatedAt: blockTime.Add(-time.Minute)},
+		&fakePriceOracle{name: "no update time", price: big.NewInt(3)},
+	}
+	checks := testPriceChecks{blockTime: blockTime, maxStaleness: time.Hour}
+
+	candidates, err := getTestPriceCandidatesWithChecks(oracles, checks)
+	if err != nil {
+		t.Fatalf("getRplPriceCandidates() returned an error: %s", err)
+	}
+	if len(candidates) != 2 || candidates[0].Source != "fresh" || candidates[1].Source != "no update time" {
+		t.Errorf("getRplPriceCandidates() returned %+v, want the fresh prices only", candidates)
+	}
+
+	// Only stale prices is an error
+	if _, err := getTestPriceCandidatesWithChecks(oracles[:1], checks); err == nil {
+		t.Error("getRplPriceCandidates() didn't return an error when every price was stale")
+	}
+}
+
+// Get a Uniswap sqrt price in Q64.96 format from a multiple of 1
+func getTestSqrtPriceX96(numerator int64, shift uint) *big.Int {
+	sqrtPrice := big.NewInt(0).Lsh(big.NewInt(numerator), 96)
+	return sqrtPrice.Rsh(sqrtPrice, shift)
+}
+
+func TestGetUniswapRate(t *testing.T) {
+	tests := []struct {
+		name         string
+		sqrtPriceX96 *big.Int
+		rplIsToken0  bool
+		want         *big.Int
+	}{
+		{"parity, RPL is token0", getTestSqrtPriceX96(1, 0), true, eth.EthToWei(1)},
+		{"parity, RPL is token1", getTestSqrtPriceX96(1, 0), false, eth.EthToWei(1)},
+		{"token1 worth 4 token0, RPL is token0", getTestSqrtPriceX96(2, 0), true, eth.EthToWei(4)},
+		{"token1 worth 4 token0, RPL is token1", getTestSqrtPriceX96(2, 0), false, eth.EthToWei(0.25)},
+		{"token1 worth 0.25 token0, RPL is token0", getTestSqrtPriceX96(1, 1), true, eth.EthToWei(0.25)},
+		{"token1 worth 0.25 token0, RPL is token1", getTestSqrtPriceX96(1, 1), false, eth.EthToWei(4)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getUniswapRate(test.sqrtPriceX96, test.rplIsToken0); got.Cmp(test.want) != 0 {
+				t.Errorf("getUniswapRate() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetUniswapEthLiquidity(t *testing.T) {
+	liquidity := eth.EthToWei(1)
+	tests := []struct {
+		name         string
+		sqrtPriceX96 *big.Int
+		rplIsToken0  bool
+		want         *big.Int
+	}{
+		{"parity, RPL is token0", getTestSqrtPriceX96(1, 0), true, eth.EthToWei(1)},
+		{"parity, RPL is token1", getTestSqrtPriceX96(1, 0), false, eth.EthToWei(1)},
+		{"token1 worth 4 token0, ETH is token1", getTestSqrtPriceX96(2, 0), true, eth.EthToWei(2)},
+		{"token1 worth 4 token0, ETH is token0", getTestSqrtPriceX96(2, 0), false, eth.EthToWei(0.5)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getUniswapEthLiquidity(liquidity, test.sqrtPriceX96, test.rplIsToken0); got.Cmp(test.want) != 0 {
+				t.Errorf("getUniswapEthLiquidity() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestHasSufficientLiquidity(t *testing.T) {
+	tests := []struct {
+		name         string
+		liquidity    *big.Int
+		minLiquidity *big.Int
+		want         bool
+	}{
+		{"below the minimum", eth.EthToWei(99), eth.EthToWei(100), false},
+		{"at the minimum", eth.EthToWei(100), eth.EthToWei(100), true},
+		{"above the minimum", eth.EthToWei(5000), eth.EthToWei(100), true},
+		{"check disabled", big.NewInt(0), big.NewInt(0), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := hasSufficientLiquidity(test.liquidity, test.minLiquidity); got != test.want {
+				t.Errorf("hasSufficientLiquidity(%s, %s) = %t, want %t", test.liquidity, test.minLiquidity, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetOneInchOracleAddresses(t *testing.T) {
+	cfg := config.NewRocketPoolConfig("", true)
+	defaultAddress := common.HexToAddress(cfg.Smartnode.GetOneInchOracleAddress())
+	first := common.HexToAddress("0x07D91f5fb9Bf7798734C3f606dB065549F6893bb")
+	second := common.HexToAddress("0x0AdDd25a91563696D8567Df78D5A01C9a991F9B8")
+
+	tests := []struct {
+		name      string
+		addresses string
+		want      []common.Address
+		wantErr   bool
+	}{
+		{name: "blank uses the default", addresses: "", want: []common.Address{defaultAddress}},
+		{name: "blank entries use the default", addresses: " , ", want: []common.Address{defaultAddress}},
+		{name: "custom list keeps its order", addresses: second.Hex() + ", " + first.Hex(), want: []common.Address{second, first}},
+		{name: "invalid address", addresses: first.Hex() + ",0x1234", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg.Smartnode.OneInchOracleAddresses.Value = test.addresses
+			got, err := getOneInchOracleAddresses(cfg)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("getOneInchOracleAddresses(%q) didn't return an error", test.addresses)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getOneInchOracleAddresses(%q) returned an error: %s", test.addresses, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("getOneInchOracleAddresses(%q) = %v, want %v", test.addresses, got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsRateTooLow(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    *big.Int
+		minRate *big.Int
+		want    bool
+	}{
+		{"nil rate", nil, nil, true},
+		{"zero rate", big.NewInt(0), nil, true},
+		{"negative rate", big.NewInt(-1), nil, true},
+		{"positive rate without a minimum", big.NewInt(1), nil, false},
+		{"below the minimum", big.NewInt(99), big.NewInt(100), true},
+		{"at the minimum", big.NewInt(100), big.NewInt(100), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isRateTooLow(test.rate, test.minRate); got != test.want {
+				t.Errorf("isRateTooLow(%v, %v) = %t, want %t", test.rate, test.minRate, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetRplPriceCandidatesExcludesLowRates(t *testing.T) {
+	oracles := []PriceOracle{
+		&fakePriceOracle{name: "zero", price: big.NewInt(0)},
+		&fakePriceOracle{name: "dust", price: big.NewInt(5)},
+		&fakePriceOracle{name: "plausible", price: big.NewInt(2e16)},
+	}
+	candidates, err := getTestPriceCandidatesWithChecks(oracles, testPriceChecks{minRate: big.NewInt(1e15)})
+	if err != nil {
+		t.Fatalf("getRplPriceCandidates() returned an error: %s", err)
+	}
+	if len(candidates) != 1 || candidates[0].Source != "plausible" {
+		t.Errorf("getRplPriceCandidates() returned %+v, want the plausible rate only", candidates)
+	}
+}
+
+func TestScaleToWei(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    int64
+		decimals uint64
+		want     string
+	}{
+		{"8 decimals", 2000000, 8, "20000000000000000"},
+		{"18 decimals", 20000000000000000, 18, "20000000000000000"},
+		{"20 decimals", 2000000000000000000, 20, "20000000000000000"},
+		{"0 decimals", 1, 0, "1000000000000000000"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := scaleToWei(big.NewInt(test.value), test.decimals); got.String() != test.want {
+				t.Errorf("scaleToWei(%d, %d) = %s, want %s", test.value, test.decimals, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodePriceFeedAnswer(t *testing.T) {
+	roundData := func(answer int64, updatedAt int64) []interface{} {
+		return []interface{}{big.NewInt(1), big.NewInt(answer), big.NewInt(updatedAt - 10), big.NewInt(updatedAt), big.NewInt(1)}
+	}
+	tests := []struct {
+		name          string
+		method        cfgtypes.PriceFeedMethod
+		out           []interface{}
+		wantAnswer    int64
+		wantUpdatedAt time.Time
+		wantErr       bool
+	}{
+		{name: "latestRoundData", method: cfgtypes.PriceFeedMethod_LatestRoundData, out: roundData(2000000, 1660000000), wantAnswer: 2000000, wantUpdatedAt: time.Unix(1660000000, 0)},
+		{name: "latestAnswer", method: cfgtypes.PriceFeedMethod_LatestAnswer, out: []interface{}{big.NewInt(2000000)}, wantAnswer: 2000000},
+		{name: "missing outputs", method: cfgtypes.PriceFeedMethod_LatestRoundData, out: []interface{}{big.NewInt(1)}, wantErr: true},
+		{name: "no outputs", method: cfgtypes.PriceFeedMethod_LatestAnswer, out: nil, wantErr: true},
+		{name: "zero answer", method: cfgtypes.PriceFeedMethod_LatestAnswer, out: []interface{}{big.NewInt(0)}, wantErr: true},
+		{name: "negative answer", method: cfgtypes.PriceFeedMethod_LatestRoundData, out: roundData(-1, 1660000000), wantErr: true},
+		{name: "unknown method", method: cfgtypes.PriceFeedMethod_Unknown, out: []interface{}{big.NewInt(1)}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer, updatedAt, err := decodePriceFeedAnswer(test.method, test.out)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("decodePriceFeedAnswer() returned %s instead of an error", answer)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodePriceFeedAnswer() returned an error: %s", err)
+			}
+			if answer.Int64() != test.wantAnswer || !updatedAt.Equal(test.wantUpdatedAt) {
+				t.Errorf("decodePriceFeedAnswer() = (%s, %s), want (%d, %s)", answer, updatedAt, test.wantAnswer, test.wantUpdatedAt)
+			}
+		})
+	}
+}
+
+func TestOneInchPriceOracleRequire(t *testing.T) {
+	// A custom list of 1inch oracles has nothing to check, so it doesn't need the CLI context
+	oracle := &oneInchPriceOracle{usesDefault: false}
+	if err := oracle.Require(nil); err != nil {
+		t.Errorf("Require() returned an error for a custom oracle list: %s", err)
+	}
+
+	// Only the sources with prerequisites are checked
+	var source PriceOracle = oracle
+	if _, ok := source.(requiredPriceOracle); !ok {
+		t.Error("the 1inch price source doesn't have prerequisites")
+	}
+	source = &fakePriceOracle{name: "fake"}
+	if _, ok := source.(requiredPriceOracle); ok {
+		t.Error("a source without prerequisites is checked")
+	}
+}
+
+func TestGetRateWithTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		delay   time.Duration
+		timeout time.Duration
+		wantErr bool
+	}{
+		{"no timeout", 20 * time.Millisecond, 0, false},
+		{"within the timeout", 0, time.Second, false},
+		{"past the timeout", time.Second, 20 * time.Millisecond, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oracle := &fakePriceOracle{name: "fake", price: big.NewInt(100), delay: test.delay}
+			start := time.Now()
+			rate, _, err := getRateWithTimeout(oracle, nil, &bind.CallOpts{}, test.timeout)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("getRateWithTimeout() didn't return an error")
+				}
+				if elapsed := time.Since(start); elapsed >= test.delay {
+					t.Errorf("getRateWithTimeout() took %s, want it to give up before the oracle returned", elapsed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getRateWithTimeout() returned an error: %s", err)
+			}
+			if rate.Int64() != 100 {
+				t.Errorf("getRateWithTimeout() = %s, want 100", rate)
+			}
+		})
+	}
+}
+
+func TestGetRplPriceCandidatesExcludesSlowSources(t *testing.T) {
+	oracles := []PriceOracle{
+		&fakePriceOracle{name: "slow", price: big.NewInt(1), delay: time.Second},
+		&fakePriceOracle{name: "fast", price: big.NewInt(2)},
+	}
+	candidates, err := getTestPriceCandidatesWithChecks(oracles, testPriceChecks{timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("getRplPriceCandidates() returned an error: %s", err)
+	}
+	if len(candidates) != 1 || candidates[0].Source != "fast" {
+		t.Errorf("getRplPriceCandidates() returned %+v, want the fast source only", candidates)
+	}
+}
+
+// A 1inch oracle that records which rate it was asked for
+type fakeOneInchRateSource struct {
+	dstToken common.Address
+}
+
+func (s *fakeOneInchRateSource) GetRate(opts *bind.CallOpts, srcToken common.Address, dstToken common.Address, useWrappers bool) (*big.Int, error) {
+	s.dstToken = dstToken
+	return big.NewInt(2), nil
+}
+
+func (s *fakeOneInchRateSource) GetRateToEth(opts *bind.CallOpts, srcToken common.Address, useSrcWrappers bool) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func TestGetOneInchRate(t *testing.T) {
+	rplAddress := common.HexToAddress("0xD33526068D116cE69F19A9ee46F0bd304F21A51f")
+	quoteToken := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+
+	// Without a quote token the rate is in ETH
+	source := &fakeOneInchRateSource{}
+	rate, err := getOneInchRate(source, &bind.CallOpts{}, rplAddress, common.Address{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate.Int64() != 1 {
+		t.Errorf("getOneInchRate() without a quote token = %s, want the ETH rate", rate)
+	}
+
+	// Otherwise it's in the quote token
+	rate, err = getOneInchRate(source, &bind.CallOpts{}, rplAddress, quoteToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate.Int64() != 2 || source.dstToken != quoteToken {
+		t.Errorf("getOneInchRate() with a quote token = %s quoted in %s, want the rate in %s", rate, source.dstToken.Hex(), quoteToken.Hex())
+	}
+}
+
+func TestCheckSuccessfulSources(t *testing.T) {
+	tests := []struct {
+		name          string
+		candidates    []priceCandidate
+		minSuccessful uint64
+		wantErr       bool
+	}{
+		{"no minimum", nil, 0, false},
+		{"enough sources", newTestPriceCandidates(100, 200), 2, false},
+		{"too few sources", newTestPriceCandidates(100), 2, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkSuccessfulSources(test.candidates, test.minSuccessful); (err != nil) != test.wantErr {
+				t.Errorf("checkSuccessfulSources() = %v, want an error: %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestTruncateToSigFigs(t *testing.T) {
+	tests := []struct {
+		name  string
+		value *big.Int
+		n     int
+		want  *big.Int
+	}{
+		{"truncated", big.NewInt(123456789), 3, big.NewInt(123000000)},
+		{"rounded down, not to nearest", big.NewInt(129999), 2, big.NewInt(120000)},
+		{"one figure", big.NewInt(987), 1, big.NewInt(900)},
+		{"exactly n digits", big.NewInt(12345), 5, big.NewInt(12345)},
+		{"fewer than n digits", big.NewInt(42), 5, big.NewInt(42)},
+		{"zero figures leaves the value unchanged", big.NewInt(123456), 0, big.NewInt(123456)},
+		{"negative figures leaves the value unchanged", big.NewInt(123456), -1, big.NewInt(123456)},
+		{"zero", big.NewInt(0), 3, big.NewInt(0)},
+		{"negative value", big.NewInt(-123456), 2, big.NewInt(-120000)},
+		{"wei price", eth.EthToWei(0.0123456789), 4, eth.EthToWei(0.01234)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value := big.NewInt(0).Set(test.value)
+			got := truncateToSigFigs(value, test.n)
+			if got.Cmp(test.want) != 0 {
+				t.Errorf("truncateToSigFigs(%s, %d) = %s, want %s", test.value, test.n, got, test.want)
+			}
+			if value.Cmp(test.value) != 0 {
+				t.Errorf("truncateToSigFigs(%s, %d) modified its input", test.value, test.n)
+			}
+		})
+	}
+}
+
+func TestIsRateTooHigh(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    *big.Int
+		maxRate *big.Int
+		want    bool
+	}{
+		{"no maximum", big.NewInt(1e18), nil, false},
+		{"zero maximum", big.NewInt(1e18), big.NewInt(0), false},
+		{"below the maximum", big.NewInt(99), big.NewInt(100), false},
+		{"at the maximum", big.NewInt(100), big.NewInt(100), false},
+		{"above the maximum", big.NewInt(101), big.NewInt(100), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isRateTooHigh(test.rate, test.maxRate); got != test.want {
+				t.Errorf("isRateTooHigh(%v, %v) = %t, want %t", test.rate, test.maxRate, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetRplPriceCandidatesExcludesHighRates(t *testing.T) {
+	oracles := []PriceOracle{
+		&fakePriceOracle{name: "plausible", price: big.NewInt(2e16)},
+		&fakePriceOracle{name: "implausible", price: big.NewInt(5e18)},
+	}
+	candidates, err := getTestPriceCandidatesWithChecks(oracles, testPriceChecks{maxRate: big.NewInt(1e18)})
+	if err != nil {
+		t.Fatalf("getRplPriceCandidates() returned an error: %s", err)
+	}
+	if len(candidates) != 1 || candidates[0].Source != "plausible" {
+		t.Errorf("getRplPriceCandidates() returned %+v, want the plausible rate only", candidates)
+	}
+}
+
+func TestGetUniswapSqrtPriceAtTick(t *testing.T) {
+
+	// Values from Uniswap's TickMath
+	exact := []struct {
+		tick int64
+		want string
+	}{
+		{0, "79228162514264337593543950336"},
+		{-uniswapMaxTick, "4295128739"},
+		{uniswapMaxTick, "1461446703485210103287273052203988822378723970342"},
+	}
+	for _, test := range exact {
+		got, err := getUniswapSqrtPriceAtTick(test.tick)
+		if err != nil {
+			t.Fatalf("getUniswapSqrtPriceAtTick(%d) returned an error: %s", test.tick, err)
+		}
+		if got.String() != test.want {
+			t.Errorf("getUniswapSqrtPriceAtTick(%d) = %s, want %s", test.tick, got, test.want)
+		}
+	}
+
+	// Every bit multiplier matches sqrt(1.0001)^tick * 2^96
+	q96 := new(big.Float).SetInt(big.NewInt(0).Lsh(big.NewInt(1), 96))
+	for bit := int64(1); bit <= uniswapMaxTick; bit <<= 1 {
+		for _, tick := range []int64{bit, -bit} {
+			got, err := getUniswapSqrtPriceAtTick(tick)
+			if err != nil {
+				t.Fatalf("getUniswapSqrtPriceAtTick(%d) returned an error: %s", tick, err)
+			}
+			want, _ := new(big.Float).Mul(q96, big.NewFloat(math.Pow(1.0001, float64(tick)/2))).Float64()
+			gotFloat, _ := new(big.Float).SetInt(got).Float64()
+			if math.Abs(gotFloat-want)/want > 1e-9 {
+				t.Errorf("getUniswapSqrtPriceAtTick(%d) = %s, want about %e", tick, got, want)
+			}
+		}
+	}
+
+	for _, tick := range []int64{uniswapMaxTick + 1, -uniswapMaxTick - 1} {
+		if _, err := getUniswapSqrtPriceAtTick(tick); err == nil {
+			t.Errorf("getUniswapSqrtPriceAtTick(%d) should fail for an out of range tick", tick)
+		}
+	}
+
+}
+
+func TestGetUniswapMeanTick(t *testing.T) {
+	tests := []struct {
+		name  string
+		start int64
+		end   int64
+		secs  uint32
+		want  int64
+	}{
+		{"positive", 1000, 1000 + 1800*250, 1800, 250},
+		{"positive rounds down", 0, 1801, 1800, 1},
+		{"negative", 0, -1800 * 250, 1800, -250},
+		{"negative rounds down", 0, -1801, 1800, -2},
+		{"unchanged", 500, 500, 1800, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getUniswapMeanTick(big.NewInt(test.start), big.NewInt(test.end), test.secs); got != test.want {
+				t.Errorf("getUniswapMeanTick(%d, %d, %d) = %d, want %d", test.start, test.end, test.secs, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetUniswapHarmonicMeanLiquidity(t *testing.T) {
+
+	// A constant liquidity L over the window adds seconds * 2^128 / L to the cumulative value
+	liquidity := eth.EthToWei(1000)
+	seconds := uint32(1800)
+	delta := big.NewInt(0).Lsh(big.NewInt(int64(seconds)), 128)
+	delta.Quo(delta, liquidity)
+	maxUint160 := big.NewInt(0).Lsh(big.NewInt(1), 160)
+
+	tests := []struct {
+		name  string
+		start *big.Int
+	}{
+		{"from zero", big.NewInt(0)},
+		{"wrapping around", big.NewInt(0).Sub(maxUint160, big.NewInt(12345))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			end := big.NewInt(0).Add(test.start, delta)
+			end.Mod(end, maxUint160)
+			got := getUniswapHarmonicMeanLiquidity(test.start, end, seconds)
+			diff := big.NewInt(0).Sub(got, liquidity)
+			diff.Abs(diff)
+			if diff.Cmp(big.NewInt(1e12)) > 0 {
+				t.Errorf("getUniswapHarmonicMeanLiquidity() = %s, want about %s", got, liquidity)
+			}
+		})
+	}
+
+	if got := getUniswapHarmonicMeanLiquidity(big.NewInt(5), big.NewInt(5), seconds); got.Sign() != 0 {
+		t.Errorf("getUniswapHarmonicMeanLiquidity() with no change = %s, want 0", got)
+	}
+
+}
+
+func TestGetUniswapTokenOrder(t *testing.T) {
+	rpl := common.HexToAddress("0xD33526068D116cE69F19A9ee46F0bd304F21A51f")
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	tests := []struct {
+		name            string
+		token0          common.Address
+		token1          common.Address
+		wantRplIsToken0 bool
+		wantErr         bool
+	}{
+		{"RPL / WETH", rpl, weth, true, false},
+		{"WETH / RPL", weth, rpl, false, false},
+		{"RPL / USDC", rpl, usdc, false, true},
+		{"USDC / RPL", usdc, rpl, false, true},
+		{"WETH / USDC", weth, usdc, false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rplIsToken0, err := getUniswapTokenOrder(test.token0, test.token1, rpl, weth)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("getUniswapTokenOrder() error = %v, want error %t", err, test.wantErr)
+			}
+			if err == nil && rplIsToken0 != test.wantRplIsToken0 {
+				t.Errorf("getUniswapTokenOrder() = %t, want %t", rplIsToken0, test.wantRplIsToken0)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// The EIP-712 domain for price submissions
+const (
+	priceSubmissionDomainName    string = "Rocket Pool Network Prices"
+	priceSubmissionDomainVersion string = "1"
+	priceSubmissionPrimaryType   string = "PriceSubmission"
+)
+
+// A price submission signed as EIP-712 typed data, for a relay to submit on the node's behalf.
+// Submissions are sent as regular transactions from the node account unless a relay is used.
+type signedPriceSubmission struct {
+	Block             uint64             `json:"block"`
+	RplPrice          *big.Int           `json:"rplPrice"`
+	EffectiveRplStake *big.Int           `json:"effectiveRplStake"`
+	Signer            common.Address     `json:"signer"`
+	TypedData         apitypes.TypedData `json:"typedData"`
+	Signature         hexutil.Bytes      `json:"signature"`
+}
+
+// Get the EIP-712 typed data for a price submission to the given RocketNetworkPrices contract
+func getPriceSubmissionTypedData(chainId *big.Int, pricesAddress common.Address, block uint64, rplPrice *big.Int, effectiveRplStake *big.Int) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			priceSubmissionPrimaryType: []apitypes.Type{
+				{Name: "block", Type: "uint256"},
+				{Name: "rplPrice", Type: "uint256"},
+				{Name: "effectiveRplStake", Type: "uint256"},
+			},
+		},
+		PrimaryType: priceSubmissionPrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:              priceSubmissionDomainName,
+			Version:           priceSubmissionDomainVersion,
+			ChainId:           (*math.HexOrDecimal256)(big.NewInt(0).Set(chainId)),
+			VerifyingContract: pricesAddress.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"block":             big.NewInt(0).SetUint64(block).String(),
+			"rplPrice":          rplPrice.String(),
+			"effectiveRplStake": effectiveRplStake.String(),
+		},
+	}
+}
+
+// Sign a price submission for a block as EIP-712 typed data with the node key
+func (t *submitRplPrice) signPriceSubmission(block uint64, rplPrice *big.Int, effectiveRplStake *big.Int) (*signedPriceSubmission, error) {
+
+	// Get the node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the typed data
+	pricesAddress, err := t.rp.GetAddress("rocketNetworkPrices", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting RocketNetworkPrices address: %w", err)
+	}
+	typedData := getPriceSubmissionTypedData(t.w.GetChainID(), *pricesAddress, block, rplPrice, effectiveRplStake)
+
+	// Sign it
+	signature, err := t.w.SignTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signedPriceSubmission{
+		Block:             block,
+		RplPrice:          rplPrice,
+		EffectiveRplStake: effectiveRplStake,
+		Signer:            nodeAccount.Address,
+		TypedData:         typedData,
+		Signature:         signature,
+	}, nil
+
+}
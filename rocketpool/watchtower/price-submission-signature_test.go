@@ -0,0 +1,42 @@
+package watchtower
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestGetPriceSubmissionTypedData(t *testing.T) {
+	pricesAddress := common.HexToAddress("0x751826b107672360b764327631cC5764515fFC37")
+	typedData := getPriceSubmissionTypedData(big.NewInt(5), pricesAddress, 1000, big.NewInt(2e16), big.NewInt(3e18))
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		t.Fatalf("the typed data can't be hashed: %s", err)
+	}
+
+	// The hash is bound to the chain, the contract, and every value being submitted
+	tests := []struct {
+		name      string
+		typedData apitypes.TypedData
+	}{
+		{"chain", getPriceSubmissionTypedData(big.NewInt(1), pricesAddress, 1000, big.NewInt(2e16), big.NewInt(3e18))},
+		{"contract", getPriceSubmissionTypedData(big.NewInt(5), common.HexToAddress("0x01"), 1000, big.NewInt(2e16), big.NewInt(3e18))},
+		{"block", getPriceSubmissionTypedData(big.NewInt(5), pricesAddress, 1001, big.NewInt(2e16), big.NewInt(3e18))},
+		{"price", getPriceSubmissionTypedData(big.NewInt(5), pricesAddress, 1000, big.NewInt(2e16+1), big.NewInt(3e18))},
+		{"stake", getPriceSubmissionTypedData(big.NewInt(5), pricesAddress, 1000, big.NewInt(2e16), big.NewInt(3e18+1))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			otherHash, _, err := apitypes.TypedDataAndHash(test.typedData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Equal(hash, otherHash) {
+				t.Errorf("changing the %s doesn't change the hash", test.name)
+			}
+		})
+	}
+}
@@ -3,10 +3,7 @@ package watchtower
 import (
 	"bytes"
 	"fmt"
-	"io/ioutil"
 	"math/big"
-	"os"
-	"path/filepath"
 	"strconv"
 	"sync"
 	"time"
@@ -27,6 +24,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	rpstate "github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -51,6 +49,7 @@ type processPenalties struct {
 	maxPriorityFee *big.Int
 	gasLimit       uint64
 	beaconConfig   beacon.Eth2Config
+	store          rpstate.StateStore
 }
 
 type state struct {
@@ -80,6 +79,10 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	if err != nil {
 		return nil, err
 	}
+	store, err := services.GetStateStore(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
@@ -123,40 +126,29 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
 		beaconConfig:   beaconConfig,
+		store:          store,
 	}, nil
 }
 
-func stateFileExists(path string) bool {
-	// Check if file exists
-	_, err := os.Stat(path)
-	if os.IsNotExist(err) {
-		return false
-	}
-	if err != nil {
-		return false
-	}
-
-	return true
-}
-
-func (s *state) loadState(path string) (*state, error) {
+// Load the state from the store, returning false if it hasn't been saved yet
+func (s *state) loadState(store rpstate.StateStore) (bool, error) {
 
-	// Load file into memory
-	yamlFile, err := ioutil.ReadFile(path)
-	if err != nil {
-		return s, err
+	// Load the saved state
+	data, exists, err := store.Get(config.WatchtowerStateFile)
+	if err != nil || !exists {
+		return false, err
 	}
 
 	// Unmarshal into state object
-	err = yaml.Unmarshal(yamlFile, s)
+	err = yaml.Unmarshal(data, s)
 	if err != nil {
-		return s, err
+		return false, err
 	}
 
-	return s, nil
+	return true, nil
 }
 
-func (s *state) saveState(path string) error {
+func (s *state) saveState(store rpstate.StateStore) error {
 	// Marshal state object
 	data, err := yaml.Marshal(s)
 
@@ -164,13 +156,8 @@ func (s *state) saveState(path string) error {
 		return err
 	}
 
-	// Write to disk
-	watchtowerDir := filepath.Dir(path)
-	err = os.MkdirAll(watchtowerDir, 0755)
-	if err != nil {
-		return fmt.Errorf("error creating watchtower directory: %w", err)
-	}
-	return ioutil.WriteFile(path, data, 0644)
+	// Write to the store
+	return store.Set(config.WatchtowerStateFile, data)
 }
 
 // Process penalties
@@ -235,17 +222,15 @@ func (t *processPenalties) run() error {
 
 		currentSlot := head.Slot
 
-		// Read state from file or create if this is the first run
-		watchtowerStatePath := t.cfg.Smartnode.GetWatchtowerStatePath()
+		// Read state from the store or create if this is the first run
 		var s state
 
-		if stateFileExists(watchtowerStatePath) {
-			_, err := s.loadState(watchtowerStatePath)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s Error loading watchtower state: %w", checkPrefix, err))
-				return
-			}
-		} else {
+		stateExists, err := s.loadState(t.store)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error loading watchtower state: %w", checkPrefix, err))
+			return
+		}
+		if !stateExists {
 			// No state file so start from NewPenaltyScanBuffer slots ago
 			if currentSlot > NewPenaltyScanBuffer {
 				s.LatestPenaltySlot = currentSlot - NewPenaltyScanBuffer
@@ -275,7 +260,7 @@ func (t *processPenalties) run() error {
 				illegalFeeRecipientFound, err := t.processBlock(&block, smoothingPoolAddress)
 				if illegalFeeRecipientFound {
 					s.LatestPenaltySlot = block.Slot
-					saveErr := s.saveState(watchtowerStatePath)
+					saveErr := s.saveState(t.store)
 					if saveErr != nil {
 						t.handleError(fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, saveErr))
 						return
@@ -292,7 +277,7 @@ func (t *processPenalties) run() error {
 				t.log.Printlnf("\t%s At block %d of %d...", checkPrefix, i, currentSlot)
 				slotsSinceUpdate = 0
 				s.LatestPenaltySlot = block.Slot
-				err = s.saveState(watchtowerStatePath)
+				err = s.saveState(t.store)
 				if err != nil {
 					t.handleError(fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, err))
 					return
@@ -310,7 +295,7 @@ func (t *processPenalties) run() error {
 
 		// Update latest slot in state
 		s.LatestPenaltySlot = currentSlot
-		err = s.saveState(watchtowerStatePath)
+		err = s.saveState(t.store)
 		if err != nil {
 			t.handleError(fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, err))
 			return
@@ -344,7 +329,7 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 		return isIllegalFeeRecipient, nil
 	}
 
-	status, err := t.bc.GetValidatorStatusByIndex(strconv.FormatUint(block.ProposerIndex, 10), nil)
+	status, err := t.bc.GetValidatorStatusByIndex(strconv.FormatUint(block.ProposerIndex, 10), getValidatorStatusOptions(t.cfg))
 	if err != nil {
 		return isIllegalFeeRecipient, err
 	}
@@ -0,0 +1,20 @@
+package watchtower
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Make sure the deployed Rocket Pool contracts match the version the bindings were written for
+func checkProtocolVersion(c *cli.Context) error {
+	if err := services.RequireRocketStorage(c); err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	return rputils.CheckProtocolVersion(rp)
+}
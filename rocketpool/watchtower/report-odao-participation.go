@@ -0,0 +1,127 @@
+package watchtower
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Members submitting for less than this fraction of the window are reported as non-responsive
+const lowParticipationThreshold float64 = 0.5
+
+// Submission types, as used in the participation metric labels
+const (
+	pricesSubmissionType   string = "prices"
+	balancesSubmissionType string = "balances"
+)
+
+// Report Oracle DAO participation task
+type reportOdaoParticipation struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	rp            *rocketpool.RocketPool
+	coll          *collectors.ParticipationCollector
+	pricesBlock   uint64
+	balancesBlock uint64
+}
+
+// Create report Oracle DAO participation task
+func newReportOdaoParticipation(c *cli.Context, logger log.ColorLogger, coll *collectors.ParticipationCollector) (*reportOdaoParticipation, error) {
+
+	// Get services
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &reportOdaoParticipation{
+		c:    c,
+		log:  logger,
+		rp:   rp,
+		coll: coll,
+	}, nil
+
+}
+
+// Update the participation rates of the Oracle DAO members
+func (t *reportOdaoParticipation) run() error {
+
+	// Get the latest consensus blocks
+	pricesBlock, err := network.GetPricesBlock(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the latest prices block: %w", err)
+	}
+	balancesBlock, err := network.GetBalancesBlock(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the latest balances block: %w", err)
+	}
+
+	// Nothing has changed since the last update
+	if pricesBlock == t.pricesBlock && balancesBlock == t.balancesBlock {
+		return nil
+	}
+
+	// Get the members and when they joined
+	members, err := trustednode.GetMemberAddresses(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting Oracle DAO members: %w", err)
+	}
+	joinedTimes := make([]uint64, len(members))
+	var wg errgroup.Group
+	for i, member := range members {
+		i, member := i, member
+		wg.Go(func() error {
+			joinedTime, err := trustednode.GetMemberJoinedTime(t.rp, member, nil)
+			if err != nil {
+				return fmt.Errorf("Error getting join time for member %s: %w", member.Hex(), err)
+			}
+			joinedTimes[i] = joinedTime
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return err
+	}
+
+	// Get the participation for each type
+	participation, err := rputils.GetOdaoParticipation(t.rp, members, joinedTimes, pricesBlock, balancesBlock)
+	if err != nil {
+		return err
+	}
+
+	// Log any members that aren't keeping up
+	t.logLowParticipation(pricesSubmissionType, participation.Prices)
+	t.logLowParticipation(balancesSubmissionType, participation.Balances)
+
+	// Update the metrics
+	t.coll.UpdateLock.Lock()
+	t.coll.Participation = map[string]map[string]float64{
+		pricesSubmissionType:   participation.Prices,
+		balancesSubmissionType: participation.Balances,
+	}
+	t.coll.UpdateLock.Unlock()
+
+	t.pricesBlock = pricesBlock
+	t.balancesBlock = balancesBlock
+	return nil
+
+}
+
+// Log the members with a participation rate below the threshold
+func (t *reportOdaoParticipation) logLowParticipation(submissionType string, rates map[string]float64) {
+	for member, rate := range rates {
+		if rate < lowParticipationThreshold {
+			t.log.Printlnf("Oracle DAO member %s has only submitted %s for %.0f%% of the recent checkpoints it was a member for.", member, submissionType, rate*100)
+		}
+	}
+}
@@ -2,9 +2,11 @@ package watchtower
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
@@ -87,6 +89,22 @@ func (t *respondChallenges) run() error {
 		return nil
 	}
 
+	// Wait until the challenge is far enough into its window that it's unlikely to be withdrawn
+	timeKey, _ := challengeStorageKeys(nodeAccount.Address)
+	challengeTime, err := t.rp.RocketStorage.GetUint(nil, timeKey)
+	if err != nil {
+		return fmt.Errorf("Error getting challenge time: %w", err)
+	}
+	challengeWindow, err := tnsettings.GetChallengeWindow(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting challenge window: %w", err)
+	}
+	responseTime := getChallengeResponseTime(challengeTime.Uint64(), challengeWindow, t.cfg.Smartnode.ChallengeResponseEarliestFraction.Value.(float64), t.cfg.Smartnode.ChallengeResponseLatestMargin.Value.(uint64))
+	if remaining := time.Until(responseTime); remaining > 0 {
+		t.log.Printlnf("Node %s has an active challenge against it; waiting %s before responding (deadline %s).", nodeAccount.Address.Hex(), remaining.Round(time.Second), getChallengeDeadline(challengeTime.Uint64(), challengeWindow).Format(time.RFC1123))
+		return nil
+	}
+
 	// Log
 	t.log.Printlnf("Node %s has an active challenge against it, responding...", nodeAccount.Address.Hex())
 
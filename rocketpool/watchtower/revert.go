@@ -0,0 +1,111 @@
+package watchtower
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Explanations for the revert reasons the Oracle DAO submission contracts commonly produce
+var revertExplanations = map[string]string{
+	"Duplicate submission from node":                        "this node has already submitted these values for this block",
+	"Network prices for an equal or higher block are set":   "prices for this block were already set by Oracle DAO consensus",
+	"Network balances for an equal or higher block are set": "balances for this block were already set by Oracle DAO consensus",
+	"Submitting prices is currently disabled":               "price submission is currently disabled by the protocol",
+	"Submitting balances is currently disabled":             "balance submission is currently disabled by the protocol",
+}
+
+// Get the revert reason from an error returned by the execution client, if it has one
+func getRevertReason(err error) (string, bool) {
+
+	// Errors from eth_call and eth_estimateGas carry the ABI-encoded revert data
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, unpackErr := abi.UnpackRevert(common.FromHex(data)); unpackErr == nil {
+				return reason, true
+			}
+		}
+	}
+
+	// Fall back to the message some clients embed in the error string
+	const prefix = "execution reverted: "
+	if index := strings.Index(err.Error(), prefix); index >= 0 {
+		return err.Error()[index+len(prefix):], true
+	}
+	return "", false
+
+}
+
+// Decode the reason a submission failed, replaying the transaction with eth_call at the block before it was
+// included if it made it on-chain. The original error is returned unchanged if no reason can be found.
+func decodeRevert(ec rocketpool.ExecutionClient, from common.Address, hash common.Hash, err error) error {
+
+	reason, found := getRevertReason(err)
+	if !found && hash != (common.Hash{}) {
+		reason, found = replayForRevertReason(ec, from, hash)
+	}
+	if !found {
+		return err
+	}
+
+	if explanation, exists := revertExplanations[reason]; exists {
+		return fmt.Errorf("%w (reverted: %s - %s)", err, reason, explanation)
+	}
+	return fmt.Errorf("%w (reverted: %s)", err, reason)
+
+}
+
+// Simulate a contract call with eth_call at the latest block, returning the decoded revert reason if it would fail
+func simulateCall(ec rocketpool.ExecutionClient, from common.Address, contract *rocketpool.Contract, method string, params ...interface{}) error {
+
+	data, err := contract.ABI.Pack(method, params...)
+	if err != nil {
+		return fmt.Errorf("Error encoding %s call: %w", method, err)
+	}
+
+	_, err = ec.CallContract(context.Background(), ethereum.CallMsg{
+		From: from,
+		To:   contract.Address,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return decodeRevert(ec, from, common.Hash{}, err)
+	}
+	return nil
+
+}
+
+// Replay a mined transaction to get its revert reason
+func replayForRevertReason(ec rocketpool.ExecutionClient, from common.Address, hash common.Hash) (string, bool) {
+
+	tx, _, err := ec.TransactionByHash(context.Background(), hash)
+	if err != nil {
+		return "", false
+	}
+	receipt, err := ec.TransactionReceipt(context.Background(), hash)
+	if err != nil || receipt.BlockNumber == nil || receipt.BlockNumber.Sign() == 0 {
+		return "", false
+	}
+
+	_, err = ec.CallContract(context.Background(), ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}, big.NewInt(0).Sub(receipt.BlockNumber, big.NewInt(1)))
+	if err == nil {
+		return "", false
+	}
+	return getRevertReason(err)
+
+}
@@ -0,0 +1,142 @@
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An execution client error carrying revert data, like the ones returned by eth_call and eth_estimateGas
+type testDataError struct {
+	data string
+}
+
+func (e testDataError) Error() string { return "execution reverted" }
+
+func (e testDataError) ErrorData() interface{} { return e.data }
+
+// ABI-encode a revert reason the way Solidity's require does
+func encodeRevertReason(t *testing.T, reason string) string {
+	t.Helper()
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hexutil.Encode(append(common.FromHex("0x08c379a0"), packed...))
+}
+
+func TestGetRevertReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+		wantFound  bool
+	}{
+		{"revert data", testDataError{encodeRevertReason(t, "Duplicate submission from node")}, "Duplicate submission from node", true},
+		{"wrapped revert data", fmt.Errorf("estimating gas: %w", testDataError{encodeRevertReason(t, "Invalid block")}), "Invalid block", true},
+		{"reason in the message", errors.New("execution reverted: Submitting prices is currently disabled"), "Submitting prices is currently disabled", true},
+		{"undecodable revert data", testDataError{"0x1234"}, "", false},
+		{"not a revert", errors.New("connection refused"), "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reason, found := getRevertReason(test.err)
+			if reason != test.wantReason || found != test.wantFound {
+				t.Errorf("getRevertReason() = (%q, %t), want (%q, %t)", reason, found, test.wantReason, test.wantFound)
+			}
+		})
+	}
+}
+
+func TestDecodeRevert(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		contains []string
+	}{
+		{"known reason", testDataError{encodeRevertReason(t, "Duplicate submission from node")}, []string{"reverted: Duplicate submission from node", "already submitted"}},
+		{"unknown reason", errors.New("execution reverted: Something else"), []string{"reverted: Something else"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := decodeRevert(nil, common.Address{}, common.Hash{}, test.err)
+			if !errors.Is(err, test.err) {
+				t.Errorf("decodeRevert() = %q, doesn't wrap the original error", err)
+			}
+			for _, part := range test.contains {
+				if !strings.Contains(err.Error(), part) {
+					t.Errorf("decodeRevert() = %q, want it to contain %q", err, part)
+				}
+			}
+		})
+	}
+
+	// Errors without a reason are returned unchanged
+	original := errors.New("connection refused")
+	if err := decodeRevert(nil, common.Address{}, common.Hash{}, original); err != original {
+		t.Errorf("decodeRevert() = %q, want the original error", err)
+	}
+}
+
+// An execution client that answers eth_call with a fixed error.
+// Calling any other method panics.
+type fakeCallClient struct {
+	rocketpool.ExecutionClient
+	err  error
+	msgs []ethereum.CallMsg
+}
+
+func (c *fakeCallClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.msgs = append(c.msgs, msg)
+	return nil, c.err
+}
+
+func TestSimulateCall(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(`[{"name":"submitPrices","type":"function","inputs":[{"name":"_block","type":"uint256"},{"name":"_rplPrice","type":"uint256"}],"outputs":[]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	contract := &rocketpool.Contract{Address: &address, ABI: &contractAbi}
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	// A call that succeeds is sent to the contract with the encoded arguments
+	client := &fakeCallClient{}
+	if err := simulateCall(client, from, contract, "submitPrices", big.NewInt(100), big.NewInt(5)); err != nil {
+		t.Fatalf("simulateCall() returned an error: %s", err)
+	}
+	data, err := contractAbi.Pack("submitPrices", big.NewInt(100), big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(client.msgs) != 1 || client.msgs[0].From != from || *client.msgs[0].To != address || !bytes.Equal(client.msgs[0].Data, data) {
+		t.Errorf("simulateCall() sent %+v, want a call from %s to %s with the encoded arguments", client.msgs, from.Hex(), address.Hex())
+	}
+
+	// A call that would revert returns the decoded reason
+	client = &fakeCallClient{err: testDataError{encodeRevertReason(t, "Network prices for an equal or higher block are set")}}
+	err = simulateCall(client, from, contract, "submitPrices", big.NewInt(100), big.NewInt(5))
+	if err == nil || !strings.Contains(err.Error(), "already set by Oracle DAO consensus") {
+		t.Errorf("simulateCall() = %v, want the decoded revert reason", err)
+	}
+
+	// Arguments that can't be encoded fail without calling the client
+	client = &fakeCallClient{}
+	if err := simulateCall(client, from, contract, "submitPrices", big.NewInt(100)); err == nil || len(client.msgs) != 0 {
+		t.Errorf("simulateCall() = %v after %d calls, want an encoding error without a call", err, len(client.msgs))
+	}
+}
@@ -0,0 +1,76 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Returned when the reportable block is too old to submit data for, which means the execution client is behind
+type StaleReportableBlockError struct {
+	BlockNumber uint64
+	Age         time.Duration
+	MaxAge      time.Duration
+}
+
+func (e *StaleReportableBlockError) Error() string {
+	return fmt.Sprintf("reportable block %d is %s old, which is more than the maximum of %s; the execution client appears to be behind", e.BlockNumber, e.Age.Round(time.Second), e.MaxAge)
+}
+
+// An execution client that can report the latest finalized block
+type finalizedBlockSource interface {
+	FinalizedBlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Get the latest block that is safe from reorgs: the finalized block if the client reports it, otherwise the block
+// the confirmation depth behind the latest one
+func getSafeHeadBlock(ec rocketpool.ExecutionClient, confirmationDepth uint64) (uint64, error) {
+
+	if source, ok := ec.(finalizedBlockSource); ok {
+		if finalized, err := source.FinalizedBlockNumber(context.Background()); err == nil {
+			return finalized, nil
+		}
+	}
+
+	latestBlock, err := ec.BlockNumber(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("Error getting latest block: %w", err)
+	}
+	if latestBlock < confirmationDepth {
+		return 0, nil
+	}
+	return latestBlock - confirmationDepth, nil
+
+}
+
+// Get the latest checkpoint at or before a block
+func getReportableBlock(headBlock uint64, frequency uint64) uint64 {
+	return headBlock / frequency * frequency
+}
+
+// Get the maximum age of the reportable block, or 0 if it isn't limited
+func getMaxReportableBlockAge(cfg *config.RocketPoolConfig) time.Duration {
+	return time.Duration(cfg.Smartnode.MaxReportableBlockAge.Value.(uint64)) * time.Second
+}
+
+// Get how long ago a block was produced
+func getBlockAge(ec rocketpool.ExecutionClient, blockNumber uint64, now time.Time) (time.Duration, error) {
+	header, err := ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+	if err != nil {
+		return 0, fmt.Errorf("Error getting header for block %d: %w", blockNumber, err)
+	}
+	return now.Sub(time.Unix(int64(header.Time), 0)), nil
+}
+
+// Make sure the reportable block isn't older than the maximum age
+func checkReportableBlockAge(blockNumber uint64, age time.Duration, maxAge time.Duration) error {
+	if maxAge > 0 && age > maxAge {
+		return &StaleReportableBlockError{BlockNumber: blockNumber, Age: age, MaxAge: maxAge}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package watchtower
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An execution client with a fixed latest block. Calling any other method panics.
+type fakeHeadClient struct {
+	rocketpool.ExecutionClient
+	latestBlock uint64
+}
+
+func (c *fakeHeadClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.latestBlock, nil
+}
+
+// An execution client that also reports a finalized block, or fails to
+type fakeFinalizedHeadClient struct {
+	fakeHeadClient
+	finalizedBlock uint64
+	finalizedErr   error
+}
+
+func (c *fakeFinalizedHeadClient) FinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	return c.finalizedBlock, c.finalizedErr
+}
+
+func TestGetSafeHeadBlock(t *testing.T) {
+	tests := []struct {
+		name              string
+		ec                rocketpool.ExecutionClient
+		confirmationDepth uint64
+		want              uint64
+	}{
+		{"finalized block", &fakeFinalizedHeadClient{fakeHeadClient: fakeHeadClient{latestBlock: 1000}, finalizedBlock: 936}, 12, 936},
+		{"finalized block unavailable", &fakeFinalizedHeadClient{fakeHeadClient: fakeHeadClient{latestBlock: 1000}, finalizedErr: errors.New("not supported")}, 12, 988},
+		{"no finalized block support", &fakeHeadClient{latestBlock: 1000}, 12, 988},
+		{"chain shorter than the depth", &fakeHeadClient{latestBlock: 5}, 12, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := getSafeHeadBlock(test.ec, test.confirmationDepth)
+			if err != nil {
+				t.Fatalf("getSafeHeadBlock() returned an error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("getSafeHeadBlock() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetReportableBlock(t *testing.T) {
+	tests := []struct {
+		headBlock uint64
+		frequency uint64
+		want      uint64
+	}{
+		{1000, 100, 1000},
+		{1099, 100, 1000},
+		{99, 100, 0},
+		{15000123, 5760, 14999040},
+	}
+	for _, test := range tests {
+		if got := getReportableBlock(test.headBlock, test.frequency); got != test.want {
+			t.Errorf("getReportableBlock(%d, %d) = %d, want %d", test.headBlock, test.frequency, got, test.want)
+		}
+	}
+}
+
+func TestCheckReportableBlockAge(t *testing.T) {
+	tests := []struct {
+		name    string
+		age     time.Duration
+		maxAge  time.Duration
+		wantErr bool
+	}{
+		{"check disabled", 24 * time.Hour, 0, false},
+		{"recent block", time.Minute, time.Hour, false},
+		{"at the limit", time.Hour, time.Hour, false},
+		{"stale block", time.Hour + time.Second, time.Hour, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkReportableBlockAge(100, test.age, test.maxAge)
+			var staleErr *StaleReportableBlockError
+			if got := errors.As(err, &staleErr); got != test.wantErr {
+				t.Fatalf("checkReportableBlockAge() = %v, want a stale block error: %t", err, test.wantErr)
+			}
+			if test.wantErr && staleErr.BlockNumber != 100 {
+				t.Errorf("stale block error is for block %d, want 100", staleErr.BlockNumber)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpstate "github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The state store key for the safe mode state
+const safeModeKey string = "safe-mode"
+
+// The conditions that engage safe mode
+const (
+	ecSyncTripwire         string = "ec-sync"
+	chainIdTripwire        string = "chain-id"
+	clockSkewTripwire      string = "clock-skew"
+	priceOraclesTripwire   string = "price-oracles"
+	priceDeviationTripwire string = "price-deviation"
+)
+
+// The tripwires that have fired, and why
+type safeModeState struct {
+	EngagedAt time.Time         `yaml:"engagedAt"`
+	Reasons   map[string]string `yaml:"reasons"`
+}
+
+// Halts transaction submissions while any tripwire is firing.
+// The state lives in the state store so that a manual reset can be made from outside the daemon, and so that
+// safe mode survives a restart when it requires one.
+type safeMode struct {
+	store       rpstate.StateStore
+	manualReset bool
+	log         log.ColorLogger
+	loggedSince time.Time
+}
+
+// Create a new safe mode controller
+func newSafeMode(cfg *config.RocketPoolConfig, store rpstate.StateStore, logger log.ColorLogger) *safeMode {
+	return &safeMode{
+		store:       store,
+		manualReset: cfg.Smartnode.SafeModeManualReset.Value == true,
+		log:         logger,
+	}
+}
+
+// Check if safe mode is engaged, logging why the first time it is seen
+func (s *safeMode) isEngaged() (bool, error) {
+	state, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	if len(state.Reasons) == 0 {
+		return false, nil
+	}
+	if state.EngagedAt.Equal(s.loggedSince) {
+		return true, nil
+	}
+	s.loggedSince = state.EngagedAt
+	s.log.Printlnf("Safe mode has been engaged since %s, transactions won't be submitted (%s).", state.EngagedAt.Format(time.RFC1123), state.describe())
+	return true, nil
+}
+
+// Update a tripwire, engaging safe mode if it fired or clearing it if it didn't
+func (s *safeMode) update(tripwire string, err error) error {
+	if err != nil {
+		return s.trip(tripwire, err.Error())
+	}
+	return s.clear(tripwire)
+}
+
+// Record that a tripwire has fired
+func (s *safeMode) trip(tripwire string, reason string) error {
+
+	state, err := s.load()
+	if err != nil {
+		return err
+	}
+	if existing, exists := state.Reasons[tripwire]; exists && existing == reason {
+		return nil
+	}
+
+	if len(state.Reasons) == 0 {
+		state.EngagedAt = time.Now().UTC()
+		s.log.Printlnf("Engaging safe mode because of %s: %s", tripwire, reason)
+	} else {
+		s.log.Printlnf("Safe mode tripwire %s fired: %s", tripwire, reason)
+	}
+	state.Reasons[tripwire] = reason
+	return s.save(state)
+
+}
+
+// Record that a tripwire is no longer firing; when safe mode requires a manual reset the reason is kept until then
+func (s *safeMode) clear(tripwire string) error {
+
+	if s.manualReset {
+		return nil
+	}
+	state, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, exists := state.Reasons[tripwire]; !exists {
+		return nil
+	}
+
+	delete(state.Reasons, tripwire)
+	if len(state.Reasons) == 0 {
+		s.log.Printlnf("Safe mode tripwire %s cleared, disengaging safe mode.", tripwire)
+		return s.store.Delete(safeModeKey)
+	}
+	s.log.Printlnf("Safe mode tripwire %s cleared, but safe mode is still engaged (%s).", tripwire, state.describe())
+	return s.save(state)
+
+}
+
+// Load the safe mode state from the store
+func (s *safeMode) load() (*safeModeState, error) {
+	state := &safeModeState{
+		Reasons: map[string]string{},
+	}
+	data, exists, err := s.store.Get(safeModeKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading safe mode state: %w", err)
+	}
+	if !exists {
+		return state, nil
+	}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("Error deserializing safe mode state: %w", err)
+	}
+	if state.Reasons == nil {
+		state.Reasons = map[string]string{}
+	}
+	return state, nil
+}
+
+// Save the safe mode state to the store
+func (s *safeMode) save(state *safeModeState) error {
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("Error serializing safe mode state: %w", err)
+	}
+	return s.store.Set(safeModeKey, data)
+}
+
+// Get a description of the tripwires that have fired
+func (state *safeModeState) describe() string {
+	tripwires := make([]string, 0, len(state.Reasons))
+	for tripwire := range state.Reasons {
+		tripwires = append(tripwires, tripwire)
+	}
+	sort.Strings(tripwires)
+	descriptions := make([]string, len(tripwires))
+	for i, tripwire := range tripwires {
+		descriptions[i] = fmt.Sprintf("%s: %s", tripwire, state.Reasons[tripwire])
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// Check that the execution client is on the chain the config expects
+func checkChainId(ec *services.ExecutionClientManager, cfg *config.RocketPoolConfig) error {
+	chainId, err := ec.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("could not get the chain ID: %w", err)
+	}
+	expected := uint64(cfg.Smartnode.GetChainID())
+	if !chainId.IsUint64() || chainId.Uint64() != expected {
+		return fmt.Errorf("the Execution client is on chain %s but the %s network is chain %d", chainId.String(), cfg.Smartnode.Network.Value, expected)
+	}
+	return nil
+}
+
+// Get the RPL price from each configured source at the latest block
+func getLatestPriceCandidates(ec *services.ExecutionClientManager, cfg *config.RocketPoolConfig, prices *priceCache) ([]priceCandidate, error) {
+
+	oracles, err := getPriceOracles(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the latest block
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not get the latest block: %w", err)
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: header.Number,
+	}
+	blockTime := time.Unix(int64(header.Time), 0)
+	maxStaleness := time.Duration(cfg.Smartnode.MaxStalenessSeconds.Value.(uint64)) * time.Second
+
+	return prices.get(header.Number.Uint64(), func() ([]priceCandidate, error) {
+		return getRplPriceCandidates(ec, oracles, opts, blockTime, maxStaleness, getMinOracleRate(cfg), getMaxOracleRate(cfg), getOracleSourceTimeout(cfg), func(string) {})
+	})
+
+}
+
+// Check that the spread between the lowest and highest price is within the sanity bound, in percent
+func checkPriceDeviation(candidates []priceCandidate, maxDeviation float64) error {
+
+	if maxDeviation == 0 || len(candidates) < 2 {
+		return nil
+	}
+
+	lowest := candidates[0]
+	highest := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.Price.Cmp(lowest.Price) < 0 {
+			lowest = candidate
+		}
+		if candidate.Price.Cmp(highest.Price) > 0 {
+			highest = candidate
+		}
+	}
+	if deviationExceeds(highest.Price, lowest.Price, percentToPpm(maxDeviation)) {
+		deviation, _ := relativeDeviation(highest.Price, lowest.Price).Float64()
+		return fmt.Errorf("the RPL price from %s (%s wei) is %.4f%% above the price from %s (%s wei), beyond the %.4f%% limit",
+			highest.Source, highest.Price.String(), deviation*100, lowest.Source, lowest.Price.String(), maxDeviation)
+	}
+	return nil
+
+}
+
+// Update the safe mode tripwires that depend on the execution client and price sources
+func (s *safeMode) checkTripwires(ec *services.ExecutionClientManager, cfg *config.RocketPoolConfig, prices *priceCache, checkPrices bool) error {
+
+	if err := s.update(chainIdTripwire, checkChainId(ec, cfg)); err != nil {
+		return err
+	}
+	if err := s.update(clockSkewTripwire, checkClockSkew(ec, cfg)); err != nil {
+		return err
+	}
+
+	// Querying every price source is only worth it if the node could submit a price
+	if !checkPrices {
+		return nil
+	}
+	candidates, err := getLatestPriceCandidates(ec, cfg, prices)
+	if err := s.update(priceOraclesTripwire, err); err != nil {
+		return err
+	}
+	if err != nil {
+		return nil
+	}
+	return s.update(priceDeviationTripwire, checkPriceDeviation(candidates, cfg.Smartnode.SafeModeMaxSourceDeviation.Value.(float64)))
+
+}
+
+// Check if the node could submit prices, which requires it to be an Oracle DAO member and price submissions to be
+// enabled
+func canSubmitPrices(rp *rocketpool.RocketPool, w *wallet.Wallet) (bool, error) {
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return false, err
+	}
+	nodeTrusted, err := trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return false, fmt.Errorf("Error checking Oracle DAO membership: %w", err)
+	}
+	if !nodeTrusted {
+		return false, nil
+	}
+	submitPricesEnabled, err := protocol.GetSubmitPricesEnabled(rp, nil)
+	if err != nil {
+		return false, fmt.Errorf("Error checking if price submissions are enabled: %w", err)
+	}
+	return submitPricesEnabled, nil
+}
+
+// Manually reset safe mode, clearing every tripwire
+func resetSafeMode(store rpstate.StateStore) error {
+	if err := store.Delete(safeModeKey); err != nil {
+		return fmt.Errorf("Error resetting safe mode: %w", err)
+	}
+	fmt.Println("Safe mode has been reset. It will engage again if any of its tripwires are still firing.")
+	return nil
+}
@@ -0,0 +1,118 @@
+package watchtower
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpstate "github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Create a safe mode controller backed by an in-memory store
+func newTestSafeMode(manualReset bool) (*safeMode, rpstate.StateStore) {
+	cfg := config.NewRocketPoolConfig("", false)
+	cfg.Smartnode.SafeModeManualReset.Value = manualReset
+	store := rpstate.NewMemoryStateStore()
+	return newSafeMode(cfg, store, log.NewColorLogger(WarningColor)), store
+}
+
+// Fail the test if safe mode's engaged status isn't the expected one
+func requireEngaged(t *testing.T, s *safeMode, want bool) {
+	t.Helper()
+	engaged, err := s.isEngaged()
+	if err != nil {
+		t.Fatalf("isEngaged() returned an error: %s", err)
+	}
+	if engaged != want {
+		t.Fatalf("isEngaged() = %t, want %t", engaged, want)
+	}
+}
+
+func TestSafeModeTripwires(t *testing.T) {
+	tripwires := []string{ecSyncTripwire, chainIdTripwire, clockSkewTripwire, priceOraclesTripwire, priceDeviationTripwire}
+	for _, tripwire := range tripwires {
+		t.Run(tripwire, func(t *testing.T) {
+			s, _ := newTestSafeMode(false)
+			requireEngaged(t, s, false)
+
+			if err := s.update(tripwire, errors.New("firing")); err != nil {
+				t.Fatal(err)
+			}
+			requireEngaged(t, s, true)
+
+			if err := s.update(tripwire, nil); err != nil {
+				t.Fatal(err)
+			}
+			requireEngaged(t, s, false)
+		})
+	}
+}
+
+func TestSafeModeStaysEngagedUntilEveryTripwireClears(t *testing.T) {
+	s, _ := newTestSafeMode(false)
+	if err := s.update(chainIdTripwire, errors.New("wrong chain")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.update(priceDeviationTripwire, errors.New("sources disagree")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.update(chainIdTripwire, nil); err != nil {
+		t.Fatal(err)
+	}
+	requireEngaged(t, s, true)
+
+	if err := s.update(priceDeviationTripwire, nil); err != nil {
+		t.Fatal(err)
+	}
+	requireEngaged(t, s, false)
+}
+
+func TestSafeModeManualReset(t *testing.T) {
+	s, store := newTestSafeMode(true)
+	if err := s.update(ecSyncTripwire, errors.New("not synced")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The tripwire clearing doesn't disengage safe mode on its own
+	if err := s.update(ecSyncTripwire, nil); err != nil {
+		t.Fatal(err)
+	}
+	requireEngaged(t, s, true)
+
+	if err := resetSafeMode(store); err != nil {
+		t.Fatal(err)
+	}
+	requireEngaged(t, s, false)
+}
+
+func TestCheckPriceDeviation(t *testing.T) {
+	candidates := []priceCandidate{
+		{Source: "1inch", Price: big.NewInt(1000000)},
+		{Source: "chainlink", Price: big.NewInt(1030000)},
+		{Source: "uniswap", Price: big.NewInt(1010000)},
+	}
+	tests := []struct {
+		name         string
+		candidates   []priceCandidate
+		maxDeviation float64
+		wantErr      bool
+	}{
+		{"within the limit", candidates, 5, false},
+		{"at the limit", candidates, 3, false},
+		{"beyond the limit", candidates, 2.5, true},
+		{"check disabled", candidates, 0, false},
+		{"single source", candidates[:1], 0.1, false},
+		{"no sources", nil, 0.1, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkPriceDeviation(test.candidates, test.maxDeviation)
+			if (err != nil) != test.wantErr {
+				t.Errorf("checkPriceDeviation() = %v, want error: %t", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package watchtower
+
+import (
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Holds off transactions for a while after startup, so the clients have a chance to settle first
+type startupWarmup struct {
+	end    time.Time
+	log    log.ColorLogger
+	logged bool
+}
+
+// Create a new startup warmup that lasts for the duration after the start time; a duration of 0 disables it
+func newStartupWarmup(start time.Time, duration time.Duration, logger log.ColorLogger) *startupWarmup {
+	return &startupWarmup{
+		end: start.Add(duration),
+		log: logger,
+	}
+}
+
+// Check if the watchtower is still warming up, logging the time left the first time it is
+func (w *startupWarmup) isWarmingUp(now time.Time) bool {
+	remaining := w.end.Sub(now)
+	if remaining <= 0 {
+		return false
+	}
+	if !w.logged {
+		w.log.Printlnf("Warming up after startup; transactions won't be submitted for another %s.", remaining.Round(time.Second))
+		w.logged = true
+	}
+	return true
+}
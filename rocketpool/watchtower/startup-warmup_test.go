@@ -0,0 +1,33 @@
+package watchtower
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestStartupWarmup(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tests := []struct {
+		name     string
+		duration time.Duration
+		after    time.Duration
+		want     bool
+	}{
+		{"disabled", 0, 0, false},
+		{"at startup", 2 * time.Minute, 0, true},
+		{"during the warmup", 2 * time.Minute, time.Minute, true},
+		{"just before the end", 2 * time.Minute, 2*time.Minute - time.Second, true},
+		{"at the end", 2 * time.Minute, 2 * time.Minute, false},
+		{"after the end", 2 * time.Minute, time.Hour, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			warmup := newStartupWarmup(start, test.duration, log.NewColorLogger(WarningColor))
+			if got := warmup.isWarmingUp(start.Add(test.after)); got != test.want {
+				t.Errorf("isWarmingUp() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
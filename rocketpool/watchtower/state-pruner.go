@@ -0,0 +1,67 @@
+package watchtower
+
+import (
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often the local state is pruned
+const statePruneInterval = 24 * time.Hour
+
+// Keeps the audit log and alert history bounded by removing the entries older than the retention window
+type statePruner struct {
+	cfg     *config.RocketPoolConfig
+	audit   *auditLogger
+	alerter *dedupeAlerter
+	log     log.ColorLogger
+	lastRun time.Time
+}
+
+// Create a new state pruner
+func newStatePruner(cfg *config.RocketPoolConfig, audit *auditLogger, alerter *dedupeAlerter, logger log.ColorLogger) *statePruner {
+	return &statePruner{
+		cfg:     cfg,
+		audit:   audit,
+		alerter: alerter,
+		log:     logger,
+	}
+}
+
+// Prune the local state if it hasn't been pruned within the prune interval and a retention window is set
+func (p *statePruner) run() {
+
+	if time.Since(p.lastRun) < statePruneInterval {
+		return
+	}
+	retentionDays := p.cfg.Smartnode.StateRetentionDays.Value.(uint64)
+	if retentionDays == 0 {
+		return
+	}
+	p.lastRun = time.Now()
+
+	if err := p.pruneState(time.Now().Add(-time.Duration(retentionDays) * 24 * time.Hour)); err != nil {
+		p.log.Printlnf("WARNING: could not prune the local state: %s", err.Error())
+	}
+
+}
+
+// Remove the audit records and alert history from before a cutoff
+func (p *statePruner) pruneState(olderThan time.Time) error {
+
+	auditPruned, err := p.audit.prune(olderThan)
+	if err != nil {
+		return err
+	}
+	alertsPruned, err := p.alerter.prune(olderThan)
+	if err != nil {
+		return err
+	}
+
+	if auditPruned > 0 || alertsPruned > 0 {
+		p.log.Printlnf("Pruned %d audit record(s) and %d alert record(s) from before %s.", auditPruned, alertsPruned, olderThan.UTC().Format(time.RFC1123))
+	}
+	return nil
+
+}
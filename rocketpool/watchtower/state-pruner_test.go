@@ -0,0 +1,97 @@
+package watchtower
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuditLoggerPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Nothing to prune before the log exists
+	logger := newAuditLogger(filepath.Join(dir, "audit.jsonl"))
+	cutoff := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	if pruned, err := logger.prune(cutoff); err != nil || pruned != 0 {
+		t.Fatalf("prune() on a missing log = (%d, %v), want (0, nil)", pruned, err)
+	}
+
+	for _, record := range []*auditRecord{
+		{Time: cutoff.Add(-time.Hour), Block: 1},
+		{Time: cutoff, Block: 2},
+		{Time: cutoff.Add(time.Hour), Block: 3},
+	} {
+		if err := logger.record(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file, err := os.OpenFile(logger.path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("damaged record\n")
+	file.Close()
+
+	pruned, err := logger.prune(cutoff)
+	if err != nil {
+		t.Fatalf("prune() returned an error: %s", err)
+	}
+	if pruned != 1 {
+		t.Errorf("prune() removed %d records, want 1", pruned)
+	}
+	contents, err := ioutil.ReadFile(logger.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := 0
+	for _, c := range contents {
+		if c == '\n' {
+			lines++
+		}
+	}
+	if lines != 3 {
+		t.Errorf("audit log has %d lines after pruning, want the 2 newer records and the damaged one", lines)
+	}
+}
+
+func TestDedupeAlerterPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dedupe-alerter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	alerter, err := newTestDedupeAlerter("", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cutoff := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	alerter.records["old"] = &alertRecord{LastSent: cutoff.Add(-time.Hour)}
+	alerter.records["new"] = &alertRecord{LastSent: cutoff.Add(time.Hour)}
+
+	pruned, err := alerter.prune(cutoff)
+	if err != nil {
+		t.Fatalf("prune() returned an error: %s", err)
+	}
+	if pruned != 1 {
+		t.Errorf("prune() removed %d alerts, want 1", pruned)
+	}
+
+	// The pruned state is saved
+	restarted, err := newTestDedupeAlerter("", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := restarted.records["old"]; exists {
+		t.Error("the old alert was restored after a restart")
+	}
+	if _, exists := restarted.records["new"]; !exists {
+		t.Error("the new alert was lost after a restart")
+	}
+}
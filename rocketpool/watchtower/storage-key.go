@@ -0,0 +1,68 @@
+package watchtower
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Print the RocketStorage key that records whether a node has submitted prices for a block, along with its
+// current value, so it can be checked independently in a block explorer
+func printStorageKey(c *cli.Context, blockNumber uint64, addressString string) error {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the node address, defaulting to the node wallet
+	nodeAddress, err := getNodeAddressArg(c, addressString)
+	if err != nil {
+		return err
+	}
+
+	// Get the key and its value
+	key := rputils.SubmittedPricesStorageKey(nodeAddress, blockNumber)
+	submitted, err := rp.RocketStorage.GetBool(nil, key)
+	if err != nil {
+		return fmt.Errorf("Error reading storage key %s: %w", key.Hex(), err)
+	}
+
+	// Print
+	fmt.Printf("Node:        %s\n", nodeAddress.Hex())
+	fmt.Printf("Block:       %d\n", blockNumber)
+	fmt.Printf("Storage key: %s\n", key.Hex())
+	fmt.Printf("Submitted:   %t\n", submitted)
+	return nil
+
+}
+
+// Parse a node address argument, defaulting to the node wallet's address if it's empty
+func getNodeAddressArg(c *cli.Context, addressString string) (common.Address, error) {
+	if addressString != "" {
+		if !common.IsHexAddress(addressString) {
+			return common.Address{}, fmt.Errorf("Invalid node address '%s'.", addressString)
+		}
+		return common.HexToAddress(addressString), nil
+	}
+	if err := services.RequireNodeWallet(c); err != nil {
+		return common.Address{}, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return common.Address{}, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return nodeAccount.Address, nil
+}
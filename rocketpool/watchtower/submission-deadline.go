@@ -0,0 +1,45 @@
+package watchtower
+
+import "fmt"
+
+// The smallest submission frequency, in blocks, the watchtower will accept from the protocol settings
+const MinSubmitFrequency uint64 = 10
+
+// Returned when the protocol's submission frequency setting can't be used
+type InvalidFrequencyError struct {
+	Frequency uint64
+}
+
+func (e *InvalidFrequencyError) Error() string {
+	if e.Frequency == 0 {
+		return "the submission frequency is set to 0 blocks"
+	}
+	return fmt.Sprintf("the submission frequency of %d blocks is below the minimum of %d blocks", e.Frequency, MinSubmitFrequency)
+}
+
+// Make sure a submission frequency is large enough to compute checkpoints with
+func checkSubmitFrequency(frequency uint64) error {
+	if frequency < MinSubmitFrequency {
+		return &InvalidFrequencyError{Frequency: frequency}
+	}
+	return nil
+}
+
+// Get the last block a submission for a reportable block should be made in, given the submission frequency
+// and the number of blocks before the next checkpoint that submissions are abandoned
+func getSubmissionDeadline(reportableBlock uint64, frequency uint64, deadlineBlocks uint64) uint64 {
+	nextCheckpoint := reportableBlock + frequency
+	if deadlineBlocks >= nextCheckpoint {
+		return 0
+	}
+	return nextCheckpoint - deadlineBlocks
+}
+
+// Check if a submission for a reportable block should be abandoned because the deadline has passed.
+// A deadline of 0 blocks disables the check.
+func isPastSubmissionDeadline(currentBlock uint64, reportableBlock uint64, frequency uint64, deadlineBlocks uint64) bool {
+	if deadlineBlocks == 0 {
+		return false
+	}
+	return currentBlock >= getSubmissionDeadline(reportableBlock, frequency, deadlineBlocks)
+}
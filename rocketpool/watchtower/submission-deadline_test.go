@@ -0,0 +1,76 @@
+package watchtower
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetSubmissionDeadline(t *testing.T) {
+	tests := []struct {
+		name            string
+		reportableBlock uint64
+		frequency       uint64
+		deadlineBlocks  uint64
+		want            uint64
+	}{
+		{"before the next checkpoint", 1000, 100, 10, 1090},
+		{"no deadline", 1000, 100, 0, 1100},
+		{"deadline covers the whole interval", 1000, 100, 100, 1000},
+		{"deadline before the chain started", 0, 100, 200, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getSubmissionDeadline(test.reportableBlock, test.frequency, test.deadlineBlocks); got != test.want {
+				t.Errorf("getSubmissionDeadline() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsPastSubmissionDeadline(t *testing.T) {
+	tests := []struct {
+		name           string
+		currentBlock   uint64
+		deadlineBlocks uint64
+		want           bool
+	}{
+		{"well before the deadline", 1010, 10, false},
+		{"just before the deadline", 1089, 10, false},
+		{"at the deadline", 1090, 10, true},
+		{"after the deadline", 1095, 10, true},
+		{"after the next checkpoint", 1200, 10, true},
+		{"check disabled", 1200, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isPastSubmissionDeadline(test.currentBlock, 1000, 100, test.deadlineBlocks); got != test.want {
+				t.Errorf("isPastSubmissionDeadline(%d) = %t, want %t", test.currentBlock, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckSubmitFrequency(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency uint64
+		wantErr   bool
+	}{
+		{"zero", 0, true},
+		{"below the minimum", MinSubmitFrequency - 1, true},
+		{"at the minimum", MinSubmitFrequency, false},
+		{"normal", 5760, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkSubmitFrequency(test.frequency)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("checkSubmitFrequency(%d) = %v, want error: %t", test.frequency, err, test.wantErr)
+			}
+			var freqErr *InvalidFrequencyError
+			if err != nil && (!errors.As(err, &freqErr) || freqErr.Frequency != test.frequency) {
+				t.Errorf("checkSubmitFrequency(%d) returned %#v, want an InvalidFrequencyError for the frequency", test.frequency, err)
+			}
+		})
+	}
+}
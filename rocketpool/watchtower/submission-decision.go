@@ -0,0 +1,280 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The state a price submission decision is made from
+type submissionState struct {
+	Block          uint64
+	PricesBlock    uint64
+	Epoch          uint64
+	FinalizedEpoch uint64
+
+	// The checks below are passed over until Price has been set, so the price only has to be computed for blocks
+	// that pass the checks above
+	Price                *big.Int
+	SourceSpreadErr      error
+	CurrentPrice         *big.Int
+	SkipIdenticalPrice   bool
+	HasSubmittedSpecific bool
+	PastDeadline         bool
+	DeadlineBlocks       uint64
+}
+
+// The outcome of a price submission decision
+type submissionDecision struct {
+	Action auditAction
+	Reason string
+
+	// A message for the human log, or an empty string for outcomes that are too routine to log
+	Message string
+}
+
+// Decide whether to submit prices for a block.
+// The checks are made in the same order as the submission task makes them, and the first one that fails decides the
+// outcome. If the price hasn't been set, a block that passes the checks before it is reported as submittable.
+func decideSubmission(state submissionState) submissionDecision {
+
+	if state.Block <= state.PricesBlock {
+		return submissionDecision{
+			Action: auditSkip,
+			Reason: fmt.Sprintf("prices are already set for block %d", state.PricesBlock),
+		}
+	}
+	if state.Epoch > state.FinalizedEpoch {
+		return submissionDecision{
+			Action:  auditDefer,
+			Reason:  fmt.Sprintf("epoch %d is not finalized yet", state.Epoch),
+			Message: fmt.Sprintf("Prices must be reported for EL block %d, waiting until Epoch %d is finalized (currently %d)", state.Block, state.Epoch, state.FinalizedEpoch),
+		}
+	}
+	if state.Price == nil {
+		return submissionDecision{
+			Action: auditSubmit,
+			Reason: "the block is ready to be evaluated",
+		}
+	}
+
+	if state.SourceSpreadErr != nil {
+		return submissionDecision{
+			Action:  auditDefer,
+			Reason:  fmt.Sprintf("the price sources disagree: %s", state.SourceSpreadErr.Error()),
+			Message: fmt.Sprintf("Holding off on the RPL price submission for block %d until the price sources reconverge: %s", state.Block, state.SourceSpreadErr.Error()),
+		}
+	}
+	if state.SkipIdenticalPrice && state.CurrentPrice != nil && state.Price.Cmp(state.CurrentPrice) == 0 {
+		return submissionDecision{
+			Action:  auditSkip,
+			Reason:  "the price is identical to the current on-chain price",
+			Message: fmt.Sprintf("RPL price for block %d is the same as the current on-chain price, skipping submission.", state.Block),
+		}
+	}
+	if state.HasSubmittedSpecific {
+		return submissionDecision{
+			Action: auditSkip,
+			Reason: "these values have already been submitted",
+		}
+	}
+	if state.PastDeadline {
+		return submissionDecision{
+			Action:  auditSkip,
+			Reason:  "the submission deadline has passed",
+			Message: fmt.Sprintf("Abandoning prices submission for block %d because the next checkpoint is less than %d blocks away.", state.Block, state.DeadlineBlocks),
+		}
+	}
+	return submissionDecision{
+		Action: auditSubmit,
+		Reason: "prices are ready to submit",
+	}
+
+}
+
+// Get the Beacon epoch a block with the given time belongs to
+func getEpochForBlockTime(blockTime time.Time, eth2Config beacon.Eth2Config) uint64 {
+	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
+	timeSinceGenesis := blockTime.Sub(genesisTime)
+	slotNumber := uint64(timeSinceGenesis.Seconds()) / eth2Config.SecondsPerSlot
+	return slotNumber / eth2Config.SlotsPerEpoch
+}
+
+// Check whether a node has submitted specific prices for a block
+func hasSubmittedSpecificPrices(rp *rocketpool.RocketPool, nodeAddress common.Address, blockNumber uint64, rplPrice, effectiveRplStake *big.Int, opts *bind.CallOpts) (bool, error) {
+
+	blockNumberBuf := make([]byte, 32)
+	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
+
+	rplPriceBuf := make([]byte, 32)
+	rplPrice.FillBytes(rplPriceBuf)
+
+	effectiveRplStakeBuf := make([]byte, 32)
+	effectiveRplStake.FillBytes(effectiveRplStakeBuf)
+
+	return rp.RocketStorage.GetBool(opts, crypto.Keccak256Hash([]byte("network.prices.submitted.node"), nodeAddress.Bytes(), blockNumberBuf, rplPriceBuf, effectiveRplStakeBuf))
+
+}
+
+// Reconstruct the decision the watchtower would make about submitting prices for a block with the current oracles and
+// config, and print it as an audit record. The on-chain state is read at atBlock if it's set, which requires an archive
+// node for old blocks; otherwise the latest state is used.
+func replayPriceSubmission(c *cli.Context, blockNumber uint64, atBlock uint64) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if err := services.RequireNodeWallet(c); err != nil {
+		return err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the block to read the on-chain state at
+	if atBlock == 0 {
+		atBlock, err = ec.BlockNumber(context.Background())
+		if err != nil {
+			return fmt.Errorf("Error getting current block: %w", err)
+		}
+	}
+	if atBlock < blockNumber {
+		return fmt.Errorf("The state block (%d) can't be before the block being replayed (%d).", atBlock, blockNumber)
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(atBlock),
+	}
+	if err := requireArchiveAt(rp, atBlock); err != nil {
+		return err
+	}
+	printMessage := func(message string) {
+		fmt.Println(message)
+	}
+
+	// Get the state the decision is made from
+	frequency, err := protocol.GetSubmitPricesFrequency(rp, opts)
+	if err != nil {
+		return fmt.Errorf("Error getting prices submission frequency: %w", err)
+	}
+	pricesBlock, err := network.GetPricesBlock(rp, opts)
+	if err != nil {
+		return fmt.Errorf("Error getting the RPL price block: %w", err)
+	}
+	header, err := ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+	if err != nil {
+		return fmt.Errorf("Error getting header for block %d: %w", blockNumber, err)
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return err
+	}
+	beaconHead, err := bc.GetBeaconHead()
+	if err != nil {
+		return err
+	}
+	deadlineBlocks := cfg.Smartnode.SubmitDeadlineBlocks.Value.(uint64)
+	state := submissionState{
+		Block:              blockNumber,
+		PricesBlock:        pricesBlock,
+		Epoch:              getEpochForBlockTime(time.Unix(int64(header.Time), 0), eth2Config),
+		FinalizedEpoch:     beaconHead.FinalizedEpoch,
+		SkipIdenticalPrice: (cfg.Smartnode.SkipIdenticalPrice.Value == true),
+		PastDeadline:       isPastSubmissionDeadline(atBlock, blockNumber, frequency, deadlineBlocks),
+		DeadlineBlocks:     deadlineBlocks,
+	}
+	record := &auditRecord{
+		Task:  "prices",
+		Block: blockNumber,
+	}
+
+	// Get the price if the block gets that far
+	decision := decideSubmission(state)
+	if decision.Action == auditSubmit {
+		record.Submittable = true
+		candidates, err := getRplPriceCandidatesAtBlock(c, rp, cfg, blockNumber, printMessage)
+		if err != nil {
+			record.Action = auditSkip
+			record.Reason = err.Error()
+			return printReplayRecord(record)
+		}
+		state.Price = getSubmissionPrice(candidates, cfg)
+		state.SourceSpreadErr = checkPriceDeviation(candidates, cfg.Smartnode.MaxSourceSpreadPct.Value.(float64))
+		record.Sources = map[string]string{}
+		for _, candidate := range candidates {
+			record.Sources[candidate.Source] = candidate.Price.String()
+		}
+		record.Value = state.Price.String()
+
+		state.CurrentPrice, err = network.GetRPLPrice(rp, opts)
+		if err != nil {
+			return fmt.Errorf("Error getting current on-chain RPL price: %w", err)
+		}
+		if state.CurrentPrice.Sign() > 0 {
+			comparison := comparePrices(state.CurrentPrice, state.Price, 0)
+			record.Deviation = &comparison.RelativeDiff
+		}
+
+		zero := big.NewInt(0)
+		effectiveRplStake, err := node.CalculateTotalEffectiveRPLStake(rp, zero, zero, state.Price, opts)
+		if err != nil {
+			return fmt.Errorf("Error getting total effective RPL stake: %w", err)
+		}
+		state.HasSubmittedSpecific, err = hasSubmittedSpecificPrices(rp, nodeAccount.Address, blockNumber, state.Price, effectiveRplStake, opts)
+		if err != nil {
+			return err
+		}
+		decision = decideSubmission(state)
+	}
+
+	record.Action = decision.Action
+	record.Reason = decision.Reason
+	return printReplayRecord(record)
+
+}
+
+// Print a replayed decision in the same format as the audit log
+func printReplayRecord(record *auditRecord) error {
+	record.Time = time.Now().UTC()
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("Error serializing audit record: %w", err)
+	}
+	fmt.Println(string(line))
+	return nil
+}
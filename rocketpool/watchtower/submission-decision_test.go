@@ -0,0 +1,135 @@
+package watchtower
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestDecideSubmissionSourceSpread(t *testing.T) {
+	state := submissionState{
+		Block:          200,
+		PricesBlock:    100,
+		Epoch:          10,
+		FinalizedEpoch: 10,
+		Price:          big.NewInt(1000),
+		CurrentPrice:   big.NewInt(900),
+	}
+	if decision := decideSubmission(state); decision.Action != auditSubmit {
+		t.Fatalf("decideSubmission() = %s without a spread error, want %s", decision.Action, auditSubmit)
+	}
+
+	state.SourceSpreadErr = errors.New("spread too wide")
+	decision := decideSubmission(state)
+	if decision.Action != auditDefer {
+		t.Errorf("decideSubmission() = %s with a spread error, want %s", decision.Action, auditDefer)
+	}
+	if !strings.Contains(decision.Reason, "spread too wide") || decision.Message == "" {
+		t.Errorf("decideSubmission() returned %+v, want the spread error reported", decision)
+	}
+}
+
+func TestDecideSubmission(t *testing.T) {
+
+	// A block that passes the checks made before the price is known
+	ready := func() submissionState {
+		return submissionState{
+			Block:          200,
+			PricesBlock:    100,
+			Epoch:          10,
+			FinalizedEpoch: 10,
+		}
+	}
+	// A block with a price that passes every check
+	priced := func() submissionState {
+		state := ready()
+		state.Price = big.NewInt(1000)
+		state.CurrentPrice = big.NewInt(900)
+		state.DeadlineBlocks = 10
+		return state
+	}
+
+	tests := []struct {
+		name        string
+		state       func() submissionState
+		wantAction  auditAction
+		wantMessage bool
+	}{
+		{"ready to evaluate", ready, auditSubmit, false},
+		{"ready to submit", priced, auditSubmit, false},
+		{"already set", func() submissionState {
+			state := priced()
+			state.PricesBlock = 200
+			return state
+		}, auditSkip, false},
+		{"older than the prices block", func() submissionState {
+			state := priced()
+			state.PricesBlock = 300
+			return state
+		}, auditSkip, false},
+		{"epoch not finalized", func() submissionState {
+			state := priced()
+			state.Epoch = 11
+			return state
+		}, auditDefer, true},
+		{"price sources disagree", func() submissionState {
+			state := priced()
+			state.SourceSpreadErr = errors.New("spread too wide")
+			return state
+		}, auditDefer, true},
+		{"identical price skipped", func() submissionState {
+			state := priced()
+			state.CurrentPrice = big.NewInt(1000)
+			state.SkipIdenticalPrice = true
+			return state
+		}, auditSkip, true},
+		{"identical price submitted when not skipping", func() submissionState {
+			state := priced()
+			state.CurrentPrice = big.NewInt(1000)
+			return state
+		}, auditSubmit, false},
+		{"identical price check without an on-chain price", func() submissionState {
+			state := priced()
+			state.CurrentPrice = nil
+			state.SkipIdenticalPrice = true
+			return state
+		}, auditSubmit, false},
+		{"already submitted", func() submissionState {
+			state := priced()
+			state.HasSubmittedSpecific = true
+			return state
+		}, auditSkip, false},
+		{"past the deadline", func() submissionState {
+			state := priced()
+			state.PastDeadline = true
+			return state
+		}, auditSkip, true},
+		{"unpriced block past the deadline", func() submissionState {
+			state := ready()
+			state.PastDeadline = true
+			return state
+		}, auditSubmit, false},
+		{"already set takes priority over finalization", func() submissionState {
+			state := priced()
+			state.PricesBlock = 200
+			state.Epoch = 11
+			return state
+		}, auditSkip, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decision := decideSubmission(test.state())
+			if decision.Action != test.wantAction {
+				t.Errorf("Action = %s (%s), want %s", decision.Action, decision.Reason, test.wantAction)
+			}
+			if decision.Reason == "" {
+				t.Error("Reason should always be set")
+			}
+			if (decision.Message != "") != test.wantMessage {
+				t.Errorf("Message = %q, want a message: %t", decision.Message, test.wantMessage)
+			}
+		})
+	}
+
+}
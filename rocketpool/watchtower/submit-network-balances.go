@@ -41,13 +41,17 @@ const MinipoolBalanceDetailsBatchSize = 8
 
 // Submit network balances task
 type submitNetworkBalances struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	ec  rocketpool.ExecutionClient
-	rp  *rocketpool.RocketPool
-	bc  beacon.Client
+	c       *cli.Context
+	log     log.ColorLogger
+	cfg     *config.RocketPoolConfig
+	w       *wallet.Wallet
+	ec      rocketpool.ExecutionClient
+	rp      *rocketpool.RocketPool
+	bc      beacon.Client
+	balance *accountBalanceCheck
+
+	// The last checkpoint balances were computed for in read-only mode
+	lastMonitoredBlock uint64
 }
 
 // Network balance info
@@ -69,7 +73,7 @@ type minipoolBalanceDetails struct {
 }
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, balance *accountBalanceCheck) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -95,13 +99,14 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNe
 
 	// Return task
 	return &submitNetworkBalances{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		ec:  ec,
-		rp:  rp,
-		bc:  bc,
+		c:       c,
+		log:     logger,
+		cfg:     cfg,
+		w:       w,
+		ec:      ec,
+		rp:      rp,
+		bc:      bc,
+		balance: balance,
 	}, nil
 
 }
@@ -146,26 +151,54 @@ func (t *submitNetworkBalances) run() error {
 	}
 
 	// Check node trusted status & settings
-	if !(nodeTrusted && submitBalancesEnabled) {
+	readOnly := isReadOnly(t.cfg, nodeTrusted)
+	if !((nodeTrusted || readOnly) && submitBalancesEnabled) {
 		return nil
 	}
 
 	// Log
 	t.log.Println("Checking for network balance checkpoint...")
 
+	// Make sure the submission frequency is usable
+	frequency, err := protocol.GetSubmitBalancesFrequency(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting balances submission frequency: %w", err)
+	}
+	if err := checkSubmitFrequency(frequency); err != nil {
+		t.log.Printlnf("WARNING: skipping network balance submission because %s.", err.Error())
+		return nil
+	}
+
 	// Get block to submit balances for
-	blockNumber, err := t.getLatestReportableBlock()
+	blockNumber, err := t.getLatestReportableBlock(frequency)
 	if err != nil {
 		return err
 	}
 
+	// Make sure the execution client isn't so far behind that the block is stale
+	age, err := getBlockAge(t.ec, blockNumber, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := checkReportableBlockAge(blockNumber, age, getMaxReportableBlockAge(t.cfg)); err != nil {
+		t.log.Printlnf("WARNING: skipping network balance submission because %s.", err.Error())
+		return nil
+	}
+
 	// Check if a submission needs to be made
 	balancesBlock, err := network.GetBalancesBlock(t.rp, nil)
 	if err != nil {
 		return err
 	}
-	if blockNumber <= balancesBlock {
-		return nil
+	if readOnly {
+		// Balances are expensive to compute, so only monitor each checkpoint once whether or not it has consensus
+		if blockNumber == t.lastMonitoredBlock {
+			return nil
+		}
+	} else {
+		if blockNumber <= balancesBlock {
+			return nil
+		}
 	}
 
 	// Get the time of the block
@@ -214,6 +247,18 @@ func (t *submitNetworkBalances) run() error {
 	t.log.Printlnf("rETH contract balance: %s wei", balances.RETHContract.String())
 	t.log.Printlnf("rETH token supply: %s wei", balances.RETHSupply.String())
 
+	// Stop here if the node is only monitoring, comparing the balances to the on-chain ones if the checkpoint has
+	// already reached consensus
+	if readOnly {
+		t.lastMonitoredBlock = blockNumber
+		if blockNumber == balancesBlock {
+			if err := t.compareOnChainBalances(balances); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// Check if we have reported these specific values before
 	hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(nodeAccount.Address, blockNumber, balances)
 	if err != nil {
@@ -232,6 +277,25 @@ func (t *submitNetworkBalances) run() error {
 		t.log.Printlnf("Have previously submitted out-of-date balances for block $d, trying again...", blockNumber)
 	}
 
+	// Make sure the next checkpoint isn't about to supersede this one
+	pastDeadline, err := t.isPastDeadline(blockNumber, frequency)
+	if err != nil {
+		return err
+	}
+	if pastDeadline {
+		t.log.Printlnf("Abandoning balances submission for block %d because the next checkpoint is less than %d blocks away.", blockNumber, t.cfg.Smartnode.SubmitDeadlineBlocks.Value.(uint64))
+		return nil
+	}
+
+	// Make sure the node account can pay for the submission
+	sufficient, err := t.balance.hasSufficientBalance(nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	if !sufficient {
+		return nil
+	}
+
 	// Log
 	t.log.Println("Submitting balances...")
 
@@ -246,40 +310,32 @@ func (t *submitNetworkBalances) run() error {
 }
 
 // Get the latest block number to report balances for
-func (t *submitNetworkBalances) getLatestReportableBlock() (uint64, error) {
+func (t *submitNetworkBalances) getLatestReportableBlock(frequency uint64) (uint64, error) {
 
 	// Require eth client synced
 	if err := services.RequireEthClientSynced(t.c); err != nil {
 		return 0, err
 	}
 
-	latestBlock, err := network.GetLatestReportableBalancesBlock(t.rp, nil)
+	// Use the latest checkpoint at or before the safe head so the block can't be reorged away
+	headBlock, err := getSafeHeadBlock(t.ec, t.cfg.Smartnode.ConfirmationDepth.Value.(uint64))
 	if err != nil {
 		return 0, fmt.Errorf("Error getting latest reportable block: %w", err)
 	}
-	return latestBlock.Uint64(), nil
+	return getReportableBlock(headBlock, frequency), nil
 
 }
 
 // Check whether balances for a block has already been submitted by the node
 func (t *submitNetworkBalances) hasSubmittedBlockBalances(nodeAddress common.Address, blockNumber uint64) (bool, error) {
-
-	blockNumberBuf := make([]byte, 32)
-	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
-	return t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte("network.balances.submitted.node"), nodeAddress.Bytes(), blockNumberBuf))
-
+	return t.rp.RocketStorage.GetBool(nil, rp.SubmittedBalancesStorageKey(nodeAddress, blockNumber))
 }
 
 // Check whether specific balances for a block has already been submitted by the node
 func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress common.Address, blockNumber uint64, balances networkBalances) (bool, error) {
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := getTotalEthBalance(balances)
 
 	blockNumberBuf := make([]byte, 32)
 	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
@@ -297,6 +353,24 @@ func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress co
 
 }
 
+// Check if the deadline for submitting balances for a block has passed
+func (t *submitNetworkBalances) isPastDeadline(blockNumber uint64, frequency uint64) (bool, error) {
+
+	deadlineBlocks := t.cfg.Smartnode.SubmitDeadlineBlocks.Value.(uint64)
+	if deadlineBlocks == 0 {
+		return false, nil
+	}
+
+	// Get the current block
+	currentBlock, err := t.ec.BlockNumber(context.Background())
+	if err != nil {
+		return false, fmt.Errorf("Error getting current block: %w", err)
+	}
+
+	return isPastSubmissionDeadline(currentBlock, blockNumber, frequency, deadlineBlocks), nil
+
+}
+
 // Prints a message to the log
 func (t *submitNetworkBalances) printMessage(message string) {
 	t.log.Println(message)
@@ -770,6 +844,48 @@ func (t *submitNetworkBalances) getFeeDistributorBalances(client *rocketpool.Roc
 
 }
 
+// Get the total ETH balance that's submitted for a set of network balances
+func getTotalEthBalance(balances networkBalances) *big.Int {
+	totalEth := big.NewInt(0)
+	totalEth.Add(totalEth, balances.DepositPool)
+	totalEth.Add(totalEth, balances.MinipoolsTotal)
+	totalEth.Add(totalEth, balances.RETHContract)
+	totalEth.Add(totalEth, balances.DistributorShareTotal)
+	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	return totalEth
+}
+
+// Log how far locally computed balances are from the ones the Oracle DAO reached consensus on
+func (t *submitNetworkBalances) compareOnChainBalances(balances networkBalances) error {
+
+	// Get the on-chain balances
+	totalEth, err := network.GetTotalETHBalance(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the on-chain total ETH balance: %w", err)
+	}
+	stakingEth, err := network.GetStakingETHBalance(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the on-chain staking ETH balance: %w", err)
+	}
+	rethSupply, err := network.GetTotalRETHSupply(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the on-chain rETH supply: %w", err)
+	}
+
+	// Log the differences
+	t.logBalanceComparison("Total ETH balance", totalEth, getTotalEthBalance(balances))
+	t.logBalanceComparison("Staking ETH balance", stakingEth, balances.MinipoolsStaking)
+	t.logBalanceComparison("rETH supply", rethSupply, balances.RETHSupply)
+	return nil
+
+}
+
+// Log a locally computed balance against the on-chain one
+func (t *submitNetworkBalances) logBalanceComparison(name string, onChain *big.Int, local *big.Int) {
+	difference, _ := relativeDeviation(local, onChain).Float64()
+	t.log.Printlnf("%s: on-chain %s wei, local %s wei (%.4f%% difference)", name, onChain.String(), local.String(), difference*100)
+}
+
 // Submit network balances
 func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 
@@ -777,12 +893,7 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	t.log.Printlnf("Submitting network balances for block %d...", balances.Block)
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := getTotalEthBalance(balances)
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -13,7 +14,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/network"
 	"github.com/rocket-pool/rocketpool-go/node"
@@ -23,15 +23,15 @@ import (
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
-	"github.com/rocket-pool/smartnode/shared/services/contracts"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
-	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	mathutils "github.com/rocket-pool/smartnode/shared/utils/math"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 const MessengerAbi = `[
@@ -62,18 +62,40 @@ const BlocksPerTurn = 75 // Approx. 15 minutes
 
 // Submit RPL price task
 type submitRplPrice struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	ec  rocketpool.ExecutionClient
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
-	oio *contracts.OneInchOracle
-	bc  beacon.Client
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	ec        rocketpool.ExecutionClient
+	w         *wallet.Wallet
+	rp        *rocketpool.RocketPool
+	bc        beacon.Client
+	coll      *collectors.PriceCollector
+	gasSource GasPriceSource
+	audit     *auditLogger
+	balance   *accountBalanceCheck
+	prices    *priceCache
+	versions  *clientVersionTracker
+
+	// Whether the missed checkpoints have been checked for backfilling since startup
+	backfillChecked bool
+
+	// Whether prices are only being computed for monitoring because the node isn't an Oracle DAO member
+	readOnly bool
+
+	// The blocks with submissions that haven't finished yet
+	inFlight     map[uint64]bool
+	inFlightLock sync.Mutex
+}
+
+// The RPL price computed for a block and the on-chain price it's compared against
+type priceEvaluation struct {
+	Candidates   []priceCandidate
+	Price        *big.Int
+	CurrentPrice *big.Int
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, coll *collectors.PriceCollector, balance *accountBalanceCheck, prices *priceCache, versions *clientVersionTracker, audit *auditLogger) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -92,25 +114,36 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger) (*submitRplPrice,
 	if err != nil {
 		return nil, err
 	}
-	oio, err := services.GetOneInchOracle(c)
+	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
-	bc, err := services.GetBeaconClient(c)
+
+	// Check the configured price sources
+	if _, err := getPriceOracles(cfg); err != nil {
+		return nil, err
+	}
+	gasSource, err := getGasPriceSource(cfg, ec)
 	if err != nil {
 		return nil, err
 	}
 
 	// Return task
 	return &submitRplPrice{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		ec:  ec,
-		w:   w,
-		rp:  rp,
-		oio: oio,
-		bc:  bc,
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		ec:        ec,
+		w:         w,
+		rp:        rp,
+		bc:        bc,
+		coll:      coll,
+		gasSource: gasSource,
+		audit:     audit,
+		balance:   balance,
+		prices:    prices,
+		versions:  versions,
+		inFlight:  map[uint64]bool{},
 	}, nil
 
 }
@@ -152,71 +185,177 @@ func (t *submitRplPrice) run() error {
 	}
 
 	// Check node trusted status & settings
-	if !(nodeTrusted && submitPricesEnabled) {
+	t.readOnly = isReadOnly(t.cfg, nodeTrusted)
+	if !((nodeTrusted || t.readOnly) && submitPricesEnabled) {
 		return nil
 	}
 
 	// Check if Optimism rate is stale and submit
-	err = t.submitOptimismPrice()
-	if err != nil {
-		// Error is not fatal for this task so print and continue
-		t.log.Printf("Error submitting Optimism price: %q\n", err)
+	if !t.readOnly {
+		err = t.submitOptimismPrice()
+		if err != nil {
+			// Error is not fatal for this task so print and continue
+			t.log.Printf("Error submitting Optimism price: %q\n", err)
+		}
 	}
 
 	// Log
 	t.log.Println("Checking for RPL price checkpoint...")
 
+	// Make sure the submission frequency is usable
+	frequency, err := protocol.GetSubmitPricesFrequency(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting prices submission frequency: %w", err)
+	}
+	if err := checkSubmitFrequency(frequency); err != nil {
+		t.log.Printlnf("WARNING: skipping RPL price submission because %s.", err.Error())
+		return nil
+	}
+
 	// Get block to submit price for
-	blockNumber, err := t.getLatestReportableBlock()
+	blockNumber, err := t.getLatestReportableBlock(frequency)
 	if err != nil {
 		return err
 	}
 
-	// Check if a submission needs to be made
-	pricesBlock, err := network.GetPricesBlock(t.rp, nil)
+	// Make sure the execution client isn't so far behind that the block is stale
+	age, err := getBlockAge(t.ec, blockNumber, time.Now())
 	if err != nil {
 		return err
 	}
-	if blockNumber <= pricesBlock {
+	if err := checkReportableBlockAge(blockNumber, age, getMaxReportableBlockAge(t.cfg)); err != nil {
+		t.log.Printlnf("WARNING: skipping RPL price submission because %s.", err.Error())
 		return nil
 	}
 
-	// Get the time of the block
-	header, err := t.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
-	if err != nil {
-		return err
+	// Backfill the open checkpoints that were missed while the node was offline, once on startup
+	if !t.backfillChecked && !t.readOnly {
+		t.backfillChecked = true
+		if err := t.backfill(nodeAccount.Address, blockNumber, frequency); err != nil {
+			t.log.Printlnf("WARNING: could not backfill missed RPL price checkpoints: %s", err.Error())
+		}
 	}
-	blockTime := time.Unix(int64(header.Time), 0)
 
-	// Get the Beacon block corresponding to this time
-	eth2Config, err := t.bc.GetEth2Config()
-	if err != nil {
-		return err
-	}
-	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
-	timeSinceGenesis := blockTime.Sub(genesisTime)
-	slotNumber := uint64(timeSinceGenesis.Seconds()) / eth2Config.SecondsPerSlot
+	return t.submitForBlock(nodeAccount.Address, blockNumber, frequency, false)
 
-	// Check if the epoch is finalized yet
-	epoch := slotNumber / eth2Config.SlotsPerEpoch
-	beaconHead, err := t.bc.GetBeaconHead()
+}
+
+// Backfill the open checkpoints before the latest reportable block that are within the backfill window
+func (t *submitRplPrice) backfill(nodeAddress common.Address, latestBlock uint64, frequency uint64) error {
+
+	maxBackfillBlocks := t.cfg.Smartnode.MaxBackfillBlocks.Value.(uint64)
+	if maxBackfillBlocks == 0 {
+		return nil
+	}
+	pricesBlock, err := network.GetPricesBlock(t.rp, nil)
 	if err != nil {
 		return err
 	}
-	finalizedEpoch := beaconHead.FinalizedEpoch
-	if epoch > finalizedEpoch {
-		t.log.Printlnf("Prices must be reported for EL block %d, waiting until Epoch %d is finalized (currently %d)", blockNumber, epoch, finalizedEpoch)
+	checkpoints := getBackfillCheckpoints(pricesBlock, latestBlock, frequency, maxBackfillBlocks)
+	if len(checkpoints) == 0 {
 		return nil
 	}
 
-	// Log
-	t.log.Printlnf("Getting RPL price for block %d...", blockNumber)
+	t.log.Printlnf("%d RPL price checkpoint(s) before block %d are still open, backfilling them...", len(checkpoints), latestBlock)
+	for _, checkpoint := range checkpoints {
+		if err := t.submitForBlock(nodeAddress, checkpoint, frequency, true); err != nil {
+			return fmt.Errorf("Error backfilling block %d: %w", checkpoint, err)
+		}
+	}
+	return nil
 
-	// Get RPL price at block
-	rplPrice, err := t.getRplPrice(blockNumber)
+}
+
+// Get the open checkpoints between the latest block with consensus and the latest reportable block, oldest first,
+// limited to the ones within maxBackfillBlocks of the latest reportable block
+func getBackfillCheckpoints(pricesBlock uint64, latestBlock uint64, frequency uint64, maxBackfillBlocks uint64) []uint64 {
+	checkpoints := []uint64{}
+	if frequency == 0 || latestBlock <= pricesBlock+frequency {
+		return checkpoints
+	}
+	earliest := pricesBlock + frequency
+	if latestBlock > maxBackfillBlocks && latestBlock-maxBackfillBlocks > earliest {
+		earliest = latestBlock - maxBackfillBlocks
+	}
+	for checkpoint := getReportableBlock(earliest+frequency-1, frequency); checkpoint < latestBlock; checkpoint += frequency {
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	return checkpoints
+}
+
+// Get the RPL price for a checkpoint and submit it if the node needs to
+func (t *submitRplPrice) submitForBlock(nodeAddress common.Address, blockNumber uint64, frequency uint64, backfill bool) error {
+
+	// Check if a submission needs to be made
+	pricesBlock, err := network.GetPricesBlock(t.rp, nil)
 	if err != nil {
 		return err
 	}
+	record := &auditRecord{
+		Task:  "prices",
+		Block: blockNumber,
+	}
+	state := submissionState{
+		Block:              blockNumber,
+		PricesBlock:        pricesBlock,
+		SkipIdenticalPrice: (t.cfg.Smartnode.SkipIdenticalPrice.Value == true),
+		DeadlineBlocks:     t.cfg.Smartnode.SubmitDeadlineBlocks.Value.(uint64),
+	}
+	if blockNumber > pricesBlock {
+
+		// Get the time of the block
+		header, err := t.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+		if err != nil {
+			return err
+		}
+
+		// Get the Beacon epoch corresponding to this time and the latest finalized one
+		eth2Config, err := t.bc.GetEth2Config()
+		if err != nil {
+			return err
+		}
+		beaconHead, err := t.bc.GetBeaconHead()
+		if err != nil {
+			return err
+		}
+		state.Epoch = getEpochForBlockTime(time.Unix(int64(header.Time), 0), eth2Config)
+		state.FinalizedEpoch = beaconHead.FinalizedEpoch
+
+	}
+	// Compute the price and record how far it is from the on-chain price whenever the block hasn't been reported yet,
+	// whether or not it ends up being submitted. Blocks that have already been reported are skipped before any oracle
+	// or archive calls are made.
+	var evaluation priceEvaluation
+	var evaluationErr error
+	if needsPriceEvaluation(blockNumber, pricesBlock) {
+		evaluation, evaluationErr = t.evaluatePrice(blockNumber, record)
+	}
+
+	// Stop here if the node is only monitoring
+	if t.readOnly {
+		if evaluationErr != nil {
+			return evaluationErr
+		}
+		t.recordDecision(record, auditSkip, "the watchtower is in read-only mode")
+		return nil
+	}
+
+	if decision := decideSubmission(state); decision.Action != auditSubmit {
+		if evaluationErr != nil {
+			t.log.Printlnf("WARNING: could not compare the RPL price for block %d to the on-chain price: %s", blockNumber, evaluationErr.Error())
+		}
+		t.applyDecision(record, decision)
+		return nil
+	}
+	record.Submittable = true
+	if evaluationErr != nil {
+		t.recordDecision(record, auditSkip, evaluationErr.Error())
+		return evaluationErr
+	}
+	rplPrice := evaluation.Price
+	state.Price = rplPrice
+	state.CurrentPrice = evaluation.CurrentPrice
+	state.SourceSpreadErr = checkPriceDeviation(evaluation.Candidates, t.cfg.Smartnode.MaxSourceSpreadPct.Value.(float64))
 
 	// Calculate the total effective RPL stake on the network
 	zero := new(big.Int).SetUint64(0)
@@ -229,27 +368,67 @@ func (t *submitRplPrice) run() error {
 	t.log.Printlnf("RPL price: %.6f ETH", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
 
 	// Check if we have reported these specific values before
-	hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockPrices(nodeAccount.Address, blockNumber, rplPrice, effectiveRplStake)
+	state.HasSubmittedSpecific, err = hasSubmittedSpecificPrices(t.rp, nodeAddress, blockNumber, rplPrice, effectiveRplStake, nil)
 	if err != nil {
 		return err
 	}
-	if hasSubmittedSpecific {
+
+	// We haven't submitted these values, check if we've submitted any for this block so we can log it
+	if !state.HasSubmittedSpecific {
+		hasSubmitted, err := t.hasSubmittedBlockPrices(nodeAddress, blockNumber)
+		if err != nil {
+			return err
+		}
+		if hasSubmitted {
+			t.log.Printlnf("Have previously submitted out-of-date prices for block %d, trying again...", blockNumber)
+		}
+	}
+
+	// Make sure the next checkpoint isn't about to supersede this one; checkpoints being backfilled have already
+	// been superseded, so they're only limited by the backfill window
+	if !backfill {
+		state.PastDeadline, err = t.isPastDeadline(blockNumber, frequency)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Decide whether to submit
+	if decision := decideSubmission(state); decision.Action != auditSubmit {
+		t.applyDecision(record, decision)
 		return nil
 	}
 
-	// We haven't submitted these values, check if we've submitted any for this block so we can log it
-	hasSubmitted, err := t.hasSubmittedBlockPrices(nodeAccount.Address, blockNumber)
+	// Make sure another run isn't already submitting for this block
+	if !t.startSubmission(blockNumber) {
+		t.log.Printlnf("A submission for block %d is already in progress, skipping.", blockNumber)
+		t.recordDecision(record, auditSkip, "a submission is already in progress")
+		return nil
+	}
+	defer t.finishSubmission(blockNumber)
+
+	// Simulate the submission so a transaction that would revert isn't sent
+	if err := t.simulateSubmit(blockNumber, rplPrice, effectiveRplStake, nodeAddress); err != nil {
+		t.log.Printlnf("Skipping RPL price submission for block %d because it would fail: %s", blockNumber, err.Error())
+		t.recordDecision(record, auditSkip, fmt.Sprintf("simulation failed: %s", err.Error()))
+		return nil
+	}
+
+	// Make sure the node account can pay for the submission
+	sufficient, err := t.balance.hasSufficientBalance(nodeAddress)
 	if err != nil {
 		return err
 	}
-	if hasSubmitted {
-		t.log.Printlnf("Have previously submitted out-of-date prices for block %d, trying again...", blockNumber)
+	if !sufficient {
+		t.recordDecision(record, auditSkip, "the node account balance is below the minimum")
+		return nil
 	}
 
 	// Log
 	t.log.Println("Submitting RPL price...")
 
 	// Submit RPL price
+	t.recordDecision(record, auditSubmit, "prices are ready to submit")
 	if err := t.submitRplPrice(blockNumber, rplPrice, effectiveRplStake); err != nil {
 		return fmt.Errorf("Could not submit RPL price: %w", err)
 	}
@@ -260,83 +439,136 @@ func (t *submitRplPrice) run() error {
 }
 
 // Get the latest block number to report RPL price for
-func (t *submitRplPrice) getLatestReportableBlock() (uint64, error) {
+func (t *submitRplPrice) getLatestReportableBlock(frequency uint64) (uint64, error) {
 
 	// Require eth client synced
 	if err := services.RequireEthClientSynced(t.c); err != nil {
 		return 0, err
 	}
 
-	latestBlock, err := network.GetLatestReportablePricesBlock(t.rp, nil)
+	// Use the latest checkpoint at or before the safe head so the block can't be reorged away
+	headBlock, err := getSafeHeadBlock(t.ec, t.cfg.Smartnode.ConfirmationDepth.Value.(uint64))
 	if err != nil {
 		return 0, fmt.Errorf("Error getting latest reportable block: %w", err)
 	}
-	return latestBlock.Uint64(), nil
+	return getReportableBlock(headBlock, frequency), nil
 
 }
 
 // Check whether prices for a block has already been submitted by the node
 func (t *submitRplPrice) hasSubmittedBlockPrices(nodeAddress common.Address, blockNumber uint64) (bool, error) {
-
-	blockNumberBuf := make([]byte, 32)
-	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
-	return t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte("network.prices.submitted.node"), nodeAddress.Bytes(), blockNumberBuf))
-
+	return t.rp.RocketStorage.GetBool(nil, rputils.SubmittedPricesStorageKey(nodeAddress, blockNumber))
 }
 
-// Check whether specific prices for a block has already been submitted by the node
-func (t *submitRplPrice) hasSubmittedSpecificBlockPrices(nodeAddress common.Address, blockNumber uint64, rplPrice, effectiveRplStake *big.Int) (bool, error) {
+// Get the RPL price from each oracle at block, reusing the prices already fetched for it in this iteration
+func (t *submitRplPrice) getRplPriceCandidates(blockNumber uint64) ([]priceCandidate, error) {
+	return t.prices.get(blockNumber, func() ([]priceCandidate, error) {
+		return getRplPriceCandidatesAtBlock(t.c, t.rp, t.cfg, blockNumber, t.printMessage)
+	})
+}
 
-	blockNumberBuf := make([]byte, 32)
-	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
+// Get the price candidates for a block, the price that would be submitted and the current on-chain price, adding
+// them to the audit record and observing how far the price is from the on-chain one
+func (t *submitRplPrice) evaluatePrice(blockNumber uint64, record *auditRecord) (priceEvaluation, error) {
 
-	rplPriceBuf := make([]byte, 32)
-	rplPrice.FillBytes(rplPriceBuf)
+	// Get RPL price at block
+	candidates, err := t.getRplPriceCandidates(blockNumber)
+	if err != nil {
+		return priceEvaluation{}, err
+	}
+	rplPrice := getSubmissionPrice(candidates, t.cfg)
+	record.Sources = map[string]string{}
+	for _, candidate := range candidates {
+		record.Sources[candidate.Source] = candidate.Price.String()
+	}
+	record.Value = rplPrice.String()
 
-	effectiveRplStakeBuf := make([]byte, 32)
-	effectiveRplStake.FillBytes(effectiveRplStakeBuf)
+	// Record how far the price has moved from the one currently on-chain
+	currentPrice, err := network.GetRPLPrice(t.rp, nil)
+	if err != nil {
+		return priceEvaluation{}, fmt.Errorf("Error getting current on-chain RPL price: %w", err)
+	}
+	if deviation, ok := getPriceDeviation(currentPrice, rplPrice); ok {
+		t.coll.ObserveDeviation(deviation)
+		deviationPct := deviation * 100
+		record.Deviation = &deviationPct
+	}
 
-	return t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte("network.prices.submitted.node"), nodeAddress.Bytes(), blockNumberBuf, rplPriceBuf, effectiveRplStakeBuf))
+	return priceEvaluation{
+		Candidates:   candidates,
+		Price:        rplPrice,
+		CurrentPrice: currentPrice,
+	}, nil
 
 }
 
-// Get RPL price at block
-func (t *submitRplPrice) getRplPrice(blockNumber uint64) (*big.Int, error) {
+// Check if the price for a block needs to be computed, which is only the case if it's later than the last block the
+// prices were reported for
+func needsPriceEvaluation(blockNumber uint64, pricesBlock uint64) bool {
+	return blockNumber > pricesBlock
+}
 
-	// Require 1inch oracle contract
-	if err := services.RequireOneInchOracle(t.c); err != nil {
-		return nil, err
+// Get the relative deviation of a price from the on-chain price, as a fraction of the on-chain price.
+// There is no deviation if the on-chain price hasn't been set yet.
+func getPriceDeviation(onChainPrice *big.Int, price *big.Int) (float64, bool) {
+	if onChainPrice.Sign() <= 0 {
+		return 0, false
 	}
+	return comparePrices(onChainPrice, price, 0).RelativeDiff / 100, true
+}
 
-	// Get RPL token address
-	rplAddress := common.HexToAddress(t.cfg.Smartnode.GetRplTokenAddress())
-
-	// Initialize call options
-	opts := &bind.CallOpts{
-		BlockNumber: big.NewInt(int64(blockNumber)),
+// Log a submission decision that isn't a submission and record it in the audit log
+func (t *submitRplPrice) applyDecision(record *auditRecord, decision submissionDecision) {
+	if decision.Message != "" {
+		t.log.Println(decision.Message)
 	}
+	t.recordDecision(record, decision.Action, decision.Reason)
+}
 
-	// Get a client with the block number available
-	client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, opts.BlockNumber)
-	if err != nil {
-		return nil, err
+// Record a submission decision in the audit log
+func (t *submitRplPrice) recordDecision(record *auditRecord, action auditAction, reason string) {
+	record.Action = action
+	record.Reason = reason
+	record.Clients = t.versions.get()
+	if err := t.audit.record(record); err != nil {
+		t.log.Printlnf("WARNING: could not write to the audit log: %s", err.Error())
 	}
+}
 
-	// Generate an OIO wrapper using the client
-	oio, err := contracts.NewOneInchOracle(common.HexToAddress(t.cfg.Smartnode.GetOneInchOracleAddress()), client.Client)
-	if err != nil {
-		return nil, err
+// Check if the deadline for submitting prices for a block has passed
+func (t *submitRplPrice) isPastDeadline(blockNumber uint64, frequency uint64) (bool, error) {
+
+	deadlineBlocks := t.cfg.Smartnode.SubmitDeadlineBlocks.Value.(uint64)
+	if deadlineBlocks == 0 {
+		return false, nil
 	}
 
-	// Get RPL price
-	rplPrice, err := oio.GetRateToEth(opts, rplAddress, true)
+	// Get the current block
+	currentBlock, err := t.ec.BlockNumber(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("Could not get RPL price at block %d: %w", blockNumber, err)
+		return false, fmt.Errorf("Error getting current block: %w", err)
 	}
 
-	// Return
-	return rplPrice, nil
+	return isPastSubmissionDeadline(currentBlock, blockNumber, frequency, deadlineBlocks), nil
+
+}
+
+// Mark a block's submission as in flight, returning false if it already was
+func (t *submitRplPrice) startSubmission(blockNumber uint64) bool {
+	t.inFlightLock.Lock()
+	defer t.inFlightLock.Unlock()
+	if t.inFlight[blockNumber] {
+		return false
+	}
+	t.inFlight[blockNumber] = true
+	return true
+}
 
+// Clear a block's in-flight submission
+func (t *submitRplPrice) finishSubmission(blockNumber uint64) {
+	t.inFlightLock.Lock()
+	defer t.inFlightLock.Unlock()
+	delete(t.inFlight, blockNumber)
 }
 
 func (t *submitRplPrice) printMessage(message string) {
@@ -358,11 +590,11 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 	// Get the gas limit
 	gasInfo, err := network.EstimateSubmitPricesGas(t.rp, blockNumber, rplPrice, effectiveRplStake, opts)
 	if err != nil {
-		return fmt.Errorf("Could not estimate the gas required to submit RPL price: %w", err)
+		return fmt.Errorf("Could not estimate the gas required to submit RPL price: %w", decodeRevert(t.rp.Client, opts.From, common.Hash{}, err))
 	}
 
 	// Print the gas info
-	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	maxFee := getMaxFeeWithFallback(t.gasSource, t.log)
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -370,18 +602,27 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 	// Set the gas settings
 	opts.GasFeeCap = maxFee
 	opts.GasTipCap = eth.GweiToWei(WatchtowerMaxPriorityFee)
-	opts.GasLimit = gasInfo.SafeGasLimit
+	opts.GasLimit = getSubmitGasLimit(t.cfg.Smartnode.SubmitGasLimit.Value.(uint64), gasInfo)
 
 	// Submit RPL price
-	hash, err := network.SubmitPrices(t.rp, blockNumber, rplPrice, effectiveRplStake, opts)
+	hash, err := t.submitPrices(blockNumber, rplPrice, effectiveRplStake, opts)
+	if err != nil && isNonceTooLow(err) && t.cfg.Smartnode.RetryNonceTooLow.Value == true {
+		t.log.Printlnf("WARNING: the nonce for the RPL price submission was already used (%s), retrying with the latest pending nonce...", err.Error())
+		nonce, nonceErr := t.ec.PendingNonceAt(context.Background(), opts.From)
+		if nonceErr != nil {
+			return fmt.Errorf("Error refreshing nonce after a nonce-too-low error (%s): %w", err.Error(), nonceErr)
+		}
+		opts.Nonce = big.NewInt(0).SetUint64(nonce)
+		hash, err = t.submitPrices(blockNumber, rplPrice, effectiveRplStake, opts)
+	}
 	if err != nil {
-		return err
+		return decodeRevert(t.rp.Client, opts.From, common.Hash{}, err)
 	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
 	if err != nil {
-		return err
+		return decodeRevert(t.rp.Client, opts.From, hash, err)
 	}
 
 	// Log
@@ -392,6 +633,54 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 
 }
 
+// Get the gas limit for a submission, using the configured limit if there is one.
+// The estimated gas usage is a floor so a configured limit that's too low can't make the submission run out of gas.
+func getSubmitGasLimit(configuredLimit uint64, gasInfo rocketpool.GasInfo) uint64 {
+	if configuredLimit == 0 {
+		return gasInfo.SafeGasLimit
+	}
+	if configuredLimit < gasInfo.EstGasLimit {
+		return gasInfo.EstGasLimit
+	}
+	return configuredLimit
+}
+
+// Check if a transaction was rejected because its nonce had already been used
+func isNonceTooLow(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
+
+// Simulate submitting prices with eth_call at the latest block, returning the decoded revert reason if it would fail
+func (t *submitRplPrice) simulateSubmit(blockNumber uint64, rplPrice, effectiveRplStake *big.Int, from common.Address) error {
+
+	rocketNetworkPrices, err := t.rp.GetContract("rocketNetworkPrices", nil)
+	if err != nil {
+		return err
+	}
+	return simulateCall(t.ec, from, rocketNetworkPrices, "submitPrices", big.NewInt(0).SetUint64(blockNumber), rplPrice, effectiveRplStake)
+
+}
+
+// Send the prices transaction, attaching an access list if enabled and falling back to a normal transaction if one can't be built
+func (t *submitRplPrice) submitPrices(blockNumber uint64, rplPrice, effectiveRplStake *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+
+	if t.cfg.Smartnode.UseAccessList.Value == true {
+		rocketNetworkPrices, err := t.rp.GetContract("rocketNetworkPrices", nil)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		chainID := big.NewInt(0).SetUint64(uint64(t.cfg.Smartnode.GetChainID()))
+		hash, err := transactWithAccessList(t.ec, chainID, rocketNetworkPrices, opts, "submitPrices", big.NewInt(0).SetUint64(blockNumber), rplPrice, effectiveRplStake)
+		if err == nil {
+			return hash, nil
+		}
+		t.log.Printlnf("WARNING: could not submit prices with an access list, sending a normal transaction instead: %s", err.Error())
+	}
+
+	return network.SubmitPrices(t.rp, blockNumber, rplPrice, effectiveRplStake, opts)
+
+}
+
 // Checks if Optimism rate is stale and if it's our turn to submit, calls submitRate on the messenger
 func (t *submitRplPrice) submitOptimismPrice() error {
 	priceMessengerAddress := t.cfg.Smartnode.GetOptimismMessengerAddress()
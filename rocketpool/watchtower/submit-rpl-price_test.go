@@ -0,0 +1,168 @@
+package watchtower
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+func TestSubmitRplPriceInFlightGuard(t *testing.T) {
+	task := &submitRplPrice{inFlight: map[uint64]bool{}}
+
+	// Only one of many concurrent runs gets to submit for a block
+	var started int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if task.startSubmission(100) {
+				atomic.AddInt32(&started, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if started != 1 {
+		t.Fatalf("%d runs started a submission for the same block, want 1", started)
+	}
+
+	// Other blocks aren't affected
+	if !task.startSubmission(200) {
+		t.Error("startSubmission() refused a block with no submission in flight")
+	}
+
+	// The block can be submitted again once the submission finishes
+	task.finishSubmission(100)
+	if !task.startSubmission(100) {
+		t.Error("startSubmission() refused a block whose submission finished")
+	}
+}
+
+func TestGetSubmitGasLimit(t *testing.T) {
+	gasInfo := rocketpool.GasInfo{
+		EstGasLimit:  100000,
+		SafeGasLimit: 150000,
+	}
+	tests := []struct {
+		name            string
+		configuredLimit uint64
+		want            uint64
+	}{
+		{"no configured limit", 0, 150000},
+		{"configured limit above the estimate", 200000, 200000},
+		{"configured limit between the estimate and the safe limit", 120000, 120000},
+		{"configured limit at the estimate", 100000, 100000},
+		{"configured limit below the estimate", 50000, 100000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getSubmitGasLimit(test.configuredLimit, gasInfo); got != test.want {
+				t.Errorf("getSubmitGasLimit(%d) = %d, want %d", test.configuredLimit, got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsNonceTooLow(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"geth error", core.ErrNonceTooLow, true},
+		{"wrapped error", fmt.Errorf("Error submitting prices: %w", core.ErrNonceTooLow), true},
+		{"other client casing", errors.New("Nonce too low: address 0x1234, tx: 5 state: 6"), true},
+		{"nonce too high", core.ErrNonceTooHigh, false},
+		{"unrelated error", errors.New("insufficient funds for gas * price + value"), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isNonceTooLow(test.err); got != test.want {
+				t.Errorf("isNonceTooLow(%q) = %t, want %t", test.err, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetBackfillCheckpoints(t *testing.T) {
+	tests := []struct {
+		name              string
+		pricesBlock       uint64
+		latestBlock       uint64
+		frequency         uint64
+		maxBackfillBlocks uint64
+		want              []uint64
+	}{
+		{"no frequency", 100, 500, 0, 1000, []uint64{}},
+		{"up to date", 400, 500, 100, 1000, []uint64{}},
+		{"missed checkpoints", 100, 500, 100, 1000, []uint64{200, 300, 400}},
+		{"limited by the window", 100, 500, 100, 150, []uint64{400}},
+		{"window before the next checkpoint", 100, 500, 100, 450, []uint64{200, 300, 400}},
+		{"no window", 100, 500, 100, 0, []uint64{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := getBackfillCheckpoints(test.pricesBlock, test.latestBlock, test.frequency, test.maxBackfillBlocks)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("getBackfillCheckpoints() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetPriceDeviation(t *testing.T) {
+	tests := []struct {
+		name         string
+		onChainPrice *big.Int
+		price        *big.Int
+		want         float64
+		wantOk       bool
+	}{
+		{"unchanged", eth.EthToWei(0.01), eth.EthToWei(0.01), 0, true},
+		{"higher", eth.EthToWei(0.01), eth.EthToWei(0.0105), 0.05, true},
+		{"lower", eth.EthToWei(0.01), eth.EthToWei(0.009), 0.1, true},
+		{"doubled", big.NewInt(1000), big.NewInt(2000), 1, true},
+		{"no on-chain price", big.NewInt(0), eth.EthToWei(0.01), 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := getPriceDeviation(test.onChainPrice, test.price)
+			if ok != test.wantOk {
+				t.Fatalf("getPriceDeviation(%s, %s) ok = %t, want %t", test.onChainPrice, test.price, ok, test.wantOk)
+			}
+			if math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("getPriceDeviation(%s, %s) = %f, want %f", test.onChainPrice, test.price, got, test.want)
+			}
+		})
+	}
+}
+
+func TestNeedsPriceEvaluation(t *testing.T) {
+	tests := []struct {
+		name        string
+		blockNumber uint64
+		pricesBlock uint64
+		want        bool
+	}{
+		{"new checkpoint", 200, 100, true},
+		{"checkpoint already reported", 100, 100, false},
+		{"consensus ahead of the checkpoint", 100, 200, false},
+		{"prices never reported", 100, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := needsPriceEvaluation(test.blockNumber, test.pricesBlock); got != test.want {
+				t.Errorf("needsPriceEvaluation(%d, %d) = %t, want %t", test.blockNumber, test.pricesBlock, got, test.want)
+			}
+		})
+	}
+}
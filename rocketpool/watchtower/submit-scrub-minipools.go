@@ -27,6 +27,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
@@ -63,6 +64,8 @@ type iterationData struct {
 	badOnDepositContract  int
 	unknownMinipools      int
 	safetyScrubs          int
+	wrongPrefixCount      int
+	wrongAddressCount     int
 
 	// Minipool info
 	minipools map[*minipool.Minipool]*minipoolDetails
@@ -79,6 +82,42 @@ type minipoolDetails struct {
 	expectedWithdrawalCredentials common.Hash
 }
 
+// The result of comparing a validator's withdrawal credentials against the ones its minipool expects
+type credentialsCheck int
+
+const (
+	credentialsMatch credentialsCheck = iota
+	credentialsWrongPrefix
+	credentialsWrongAddress
+)
+
+// Compare withdrawal credentials against the expected ones.
+// Credentials with the wrong prefix (e.g. 0x00 BLS credentials instead of 0x01 execution ones) are reported
+// separately from ones with the right prefix but the wrong address, since they can never receive rewards correctly.
+func checkWithdrawalCredentials(expected common.Hash, actual common.Hash) credentialsCheck {
+	if actual == expected {
+		return credentialsMatch
+	}
+	if actual[0] != expected[0] {
+		return credentialsWrongPrefix
+	}
+	return credentialsWrongAddress
+}
+
+// Get a description of a credentials check failure for the scrub logs
+func (c credentialsCheck) String() string {
+	switch c {
+	case credentialsMatch:
+		return "match"
+	case credentialsWrongPrefix:
+		return "wrong prefix"
+	case credentialsWrongAddress:
+		return "wrong address"
+	default:
+		return "unknown"
+	}
+}
+
 // Create submit scrub minipools task
 func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.ScrubCollector) (*submitScrubMinipools, error) {
 
@@ -272,36 +311,58 @@ func (t *submitScrubMinipools) handleError(err error) {
 // Get the correct withdrawal credentials and pubkeys for each minipool
 func (t *submitScrubMinipools) initializeMinipoolDetails(minipoolAddresses []common.Address) []types.ValidatorPubkey {
 
-	pubkeys := []types.ValidatorPubkey{}
+	// Get the contracts
+	rocketMinipoolManager, err := t.rp.GetContract("rocketMinipoolManager", nil)
+	if err != nil {
+		t.log.Printf("Error getting the minipool manager contract: %s", err.Error())
+		return []types.ValidatorPubkey{}
+	}
+	mc, err := newMultiCaller(t.cfg, t.rp)
+	if err != nil {
+		t.log.Printf("Error creating multicaller: %s", err.Error())
+		return []types.ValidatorPubkey{}
+	}
 
-	for _, minipoolAddress := range minipoolAddresses {
-		// Create a minipool contract wrapper for the given address
-		mp, err := minipool.NewMinipool(t.rp, minipoolAddress, nil)
-		if err != nil {
-			t.log.Printf("Error creating minipool wrapper for %s: %s", minipoolAddress.Hex(), err.Error())
-			continue
+	// Load the correct withdrawal credentials and validator pubkeys in batches
+	details := make([]*minipoolDetails, len(minipoolAddresses))
+	for mi := range details {
+		details[mi] = &minipoolDetails{}
+	}
+	loadErrs, err := multicallInBatches(mc, len(minipoolAddresses), getMinipoolBatchSize(t.cfg), func(mi int) []multicall.Call {
+		args := []interface{}{minipoolAddresses[mi]}
+		return []multicall.Call{
+			{Contract: rocketMinipoolManager, Method: "getMinipoolWithdrawalCredentials", Args: args, Output: &details[mi].expectedWithdrawalCredentials},
+			{Contract: rocketMinipoolManager, Method: "getMinipoolPubkey", Args: args, Output: &details[mi].pubkey},
 		}
+	})
+	if err != nil {
+		t.log.Printf("Error getting minipool details: %s", err.Error())
+		return []types.ValidatorPubkey{}
+	}
 
-		// Get the correct withdrawal credentials
-		expectedCreds, err := minipool.GetMinipoolWithdrawalCredentials(t.rp, minipoolAddress, nil)
-		if err != nil {
-			t.log.Printf("Error getting expected withdrawal creds for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+	// Create a minipool contract wrapper for each minipool that loaded; ones that failed are logged and left out
+	minipools := make([]*minipool.Minipool, len(minipoolAddresses))
+	for mi, minipoolAddress := range minipoolAddresses {
+		if loadErrs[mi] != nil {
+			t.log.Printf("Error getting details for minipool %s: %s", minipoolAddress.Hex(), loadErrs[mi].Error())
 			continue
 		}
-
-		// Get the validator pubkey
-		pubkey, err := minipool.GetMinipoolPubkey(t.rp, minipoolAddress, nil)
+		mp, err := minipool.NewMinipool(t.rp, minipoolAddress, nil)
 		if err != nil {
-			t.log.Printf("Error getting validator pubkey for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+			t.log.Printf("Error creating minipool wrapper for %s: %s", minipoolAddress.Hex(), err.Error())
 			continue
 		}
-		pubkeys = append(pubkeys, pubkey)
+		minipools[mi] = mp
+	}
 
-		// Create a new details entry for this minipool
-		t.it.minipools[mp] = &minipoolDetails{
-			expectedWithdrawalCredentials: expectedCreds,
-			pubkey:                        pubkey,
+	// Create a new details entry for each minipool that loaded
+	pubkeys := []types.ValidatorPubkey{}
+	for mi, mp := range minipools {
+		if mp == nil {
+			continue
 		}
+		pubkeys = append(pubkeys, details[mi].pubkey)
+		t.it.minipools[mp] = details[mi]
 	}
 
 	return pubkeys
@@ -314,7 +375,7 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(pubkeys []types
 	minipoolsToScrub := []*minipool.Minipool{}
 
 	// Get the status of the validators on the Beacon chain
-	statuses, err := t.bc.GetValidatorStatuses(pubkeys, nil)
+	statuses, err := t.bc.GetValidatorStatuses(pubkeys, getValidatorStatusOptions(t.cfg))
 	if err != nil {
 		return err
 	}
@@ -328,14 +389,16 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(pubkeys []types
 			// This minipool's deposit has been seen on the Beacon Chain
 			expectedCreds := details.expectedWithdrawalCredentials
 			beaconCreds := status.WithdrawalCredentials
-			if beaconCreds != expectedCreds {
+			if check := checkWithdrawalCredentials(expectedCreds, beaconCreds); check != credentialsMatch {
 				t.log.Println("=== SCRUB DETECTED ON BEACON CHAIN ===")
 				t.log.Printlnf("\tMinipool: %s", minipool.Address.Hex())
+				t.log.Printlnf("\tReason: %s", check.String())
 				t.log.Printlnf("\tExpected creds: %s", expectedCreds.Hex())
 				t.log.Printlnf("\tActual creds: %s", beaconCreds.Hex())
 				t.log.Println("======================================")
 				minipoolsToScrub = append(minipoolsToScrub, minipool)
 				t.it.badOnBeaconCount++
+				t.countCredentialsFailure(check)
 			} else {
 				// This minipool's credentials match, it's clean.
 				t.it.goodOnBeaconCount++
@@ -510,16 +573,18 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 				// This is a valid deposit
 				expectedCreds := details.expectedWithdrawalCredentials
 				actualCreds := deposit.WithdrawalCredentials
-				if actualCreds != expectedCreds {
+				if check := checkWithdrawalCredentials(expectedCreds, actualCreds); check != credentialsMatch {
 					t.log.Println("=== SCRUB DETECTED ON DEPOSIT CONTRACT ===")
 					t.log.Printlnf("\tTX Hash: %s", deposit.TxHash.Hex())
 					t.log.Printlnf("\tBlock: %d, TX Index: %d, Deposit Index: %d", deposit.BlockNumber, deposit.TxIndex, depositIndex)
 					t.log.Printlnf("\tMinipool: %s", minipool.Address.Hex())
+					t.log.Printlnf("\tReason: %s", check.String())
 					t.log.Printlnf("\tExpected creds: %s", expectedCreds.Hex())
 					t.log.Printlnf("\tActual creds: %s", actualCreds.Hex())
 					t.log.Println("==========================================")
 					minipoolsToScrub = append(minipoolsToScrub, minipool)
 					t.it.badOnDepositContract++
+					t.countCredentialsFailure(check)
 				} else {
 					t.it.goodOnDepositContract++
 				}
@@ -659,6 +724,16 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp *minipool.Minipool) er
 
 }
 
+// Count a withdrawal credentials failure by its reason
+func (t *submitScrubMinipools) countCredentialsFailure(check credentialsCheck) {
+	switch check {
+	case credentialsWrongPrefix:
+		t.it.wrongPrefixCount++
+	case credentialsWrongAddress:
+		t.it.wrongAddressCount++
+	}
+}
+
 // Prints the final tally of minipool counts
 func (t *submitScrubMinipools) printFinalTally(prefix string) {
 
@@ -667,6 +742,8 @@ func (t *submitScrubMinipools) printFinalTally(prefix string) {
 	t.log.Printlnf("\tBeacon Chain scrubs: %d/%d", t.it.badOnBeaconCount, (t.it.badOnBeaconCount + t.it.goodOnBeaconCount))
 	t.log.Printlnf("\tPrestake scrubs: %d/%d", t.it.badPrestakeCount, (t.it.badPrestakeCount + t.it.goodPrestakeCount))
 	t.log.Printlnf("\tDeposit Contract scrubs: %d/%d", t.it.badOnDepositContract, (t.it.badOnDepositContract + t.it.goodOnDepositContract))
+	t.log.Printlnf("\tWrong credential prefix: %d", t.it.wrongPrefixCount)
+	t.log.Printlnf("\tWrong credential address: %d", t.it.wrongAddressCount)
 	t.log.Printlnf("\tPools without deposits: %d", t.it.unknownMinipools)
 	t.log.Printlnf("\tRemaining uncovered minipools: %d", len(t.it.minipools))
 
@@ -684,6 +761,8 @@ func (t *submitScrubMinipools) printFinalTally(prefix string) {
 		t.coll.BadOnDepositContract = float64(t.it.badOnDepositContract)
 		t.coll.DepositlessMinipools = float64(t.it.unknownMinipools)
 		t.coll.UncoveredMinipools = float64(len(t.it.minipools))
+		t.coll.WrongPrefixScrubs = float64(t.it.wrongPrefixCount)
+		t.coll.WrongAddressScrubs = float64(t.it.wrongAddressCount)
 		t.coll.LatestBlockTime = float64(t.it.latestBlockTime.Unix())
 	}
 }
@@ -0,0 +1,28 @@
+package watchtower
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestCheckWithdrawalCredentials(t *testing.T) {
+	expected := common.HexToHash("0x0100000000000000000000001111111111111111111111111111111111111111")
+	tests := []struct {
+		name   string
+		actual common.Hash
+		want   credentialsCheck
+	}{
+		{"match", expected, credentialsMatch},
+		{"BLS credentials", common.HexToHash("0x0000000000000000000000001111111111111111111111111111111111111111"), credentialsWrongPrefix},
+		{"other address", common.HexToHash("0x0100000000000000000000002222222222222222222222222222222222222222"), credentialsWrongAddress},
+		{"wrong prefix and address", common.HexToHash("0x0000000000000000000000002222222222222222222222222222222222222222"), credentialsWrongPrefix},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := checkWithdrawalCredentials(expected, test.actual); got != test.want {
+				t.Errorf("checkWithdrawalCredentials() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
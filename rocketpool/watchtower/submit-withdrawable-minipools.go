@@ -23,12 +23,10 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
-// Settings
-const MinipoolWithdrawableDetailsBatchSize = 20
-
 // Submit withdrawable minipools task
 type submitWithdrawableMinipools struct {
 	c   *cli.Context
@@ -47,6 +45,14 @@ type minipoolWithdrawableDetails struct {
 	Withdrawable bool
 }
 
+// Minipool contract state needed to check whether a minipool is withdrawable
+type minipoolWithdrawableState struct {
+	status                  uint8
+	nodeDepositBalance      *big.Int
+	userDepositBalance      *big.Int
+	userDepositAssignedTime *big.Int
+}
+
 // Create submit withdrawable minipools task
 func newSubmitWithdrawableMinipools(c *cli.Context, logger log.ColorLogger) (*submitWithdrawableMinipools, error) {
 
@@ -192,38 +198,52 @@ func (t *submitWithdrawableMinipools) getNetworkMinipoolWithdrawableDetails(node
 		return []minipoolWithdrawableDetails{}, err
 	}
 
-	// Load details in batches
-	minipools := make([]minipoolWithdrawableDetails, len(addresses))
-	for bsi := 0; bsi < len(addresses); bsi += MinipoolWithdrawableDetailsBatchSize {
-
-		// Get batch start & end index
-		msi := bsi
-		mei := bsi + MinipoolWithdrawableDetailsBatchSize
-		if mei > len(addresses) {
-			mei = len(addresses)
+	// Create minipool contracts
+	mps := make([]*minipool.Minipool, len(addresses))
+	for mi, address := range addresses {
+		mp, err := minipool.NewMinipool(t.rp, address, nil)
+		if err != nil {
+			return []minipoolWithdrawableDetails{}, err
 		}
+		mps[mi] = mp
+	}
 
-		// Log
-		//t.log.Printlnf("Checking minipools %d - %d of %d for withdrawable status...", msi + 1, mei, len(addresses))
-
-		// Load details
-		var wg errgroup.Group
-		for mi := msi; mi < mei; mi++ {
-			mi := mi
-			wg.Go(func() error {
-				address := addresses[mi]
-				validator := validators[address]
-				mpDetails, err := t.getMinipoolWithdrawableDetails(nodeAddress, address, validator, eth2Config, beaconHead)
-				if err == nil {
-					minipools[mi] = mpDetails
-				}
-				return err
-			})
+	// Load minipool states in batches
+	mc, err := newMultiCaller(t.cfg, t.rp)
+	if err != nil {
+		return []minipoolWithdrawableDetails{}, err
+	}
+	states := make([]minipoolWithdrawableState, len(mps))
+	loadErrs, err := multicallInBatches(mc, len(mps), getMinipoolBatchSize(t.cfg), func(mi int) []multicall.Call {
+		contract := mps[mi].Contract
+		return []multicall.Call{
+			{Contract: contract, Method: "getStatus", Output: &states[mi].status},
+			{Contract: contract, Method: "getNodeDepositBalance", Output: &states[mi].nodeDepositBalance},
+			{Contract: contract, Method: "getUserDepositBalance", Output: &states[mi].userDepositBalance},
+			{Contract: contract, Method: "getUserDepositAssignedTime", Output: &states[mi].userDepositAssignedTime},
 		}
-		if err := wg.Wait(); err != nil {
-			return []minipoolWithdrawableDetails{}, err
+	})
+	if err != nil {
+		return []minipoolWithdrawableDetails{}, err
+	}
+	for mi, loadErr := range loadErrs {
+		if loadErr != nil {
+			return []minipoolWithdrawableDetails{}, fmt.Errorf("Could not get minipool %s details: %w", addresses[mi].Hex(), loadErr)
 		}
+	}
 
+	// Check the staking minipools in batches
+	minipools := make([]minipoolWithdrawableDetails, len(addresses))
+	err = loadInBatches(len(addresses), getMinipoolBatchSize(t.cfg), func(mi int) error {
+		validator := validators[addresses[mi]]
+		mpDetails, err := t.getMinipoolWithdrawableDetails(nodeAddress, mps[mi], states[mi], validator, eth2Config, beaconHead)
+		if err == nil {
+			minipools[mi] = mpDetails
+		}
+		return err
+	})
+	if err != nil {
+		return []minipoolWithdrawableDetails{}, err
 	}
 
 	// Filter by withdrawable status
@@ -240,52 +260,10 @@ func (t *submitWithdrawableMinipools) getNetworkMinipoolWithdrawableDetails(node
 }
 
 // Get minipool withdrawable details
-func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress common.Address, minipoolAddress common.Address, validator beacon.ValidatorStatus, eth2Config beacon.Eth2Config, beaconHead beacon.BeaconHead) (minipoolWithdrawableDetails, error) {
-
-	// Create minipool
-	mp, err := minipool.NewMinipool(t.rp, minipoolAddress, nil)
-	if err != nil {
-		return minipoolWithdrawableDetails{}, err
-	}
-
-	// Data
-	var wg errgroup.Group
-	var status types.MinipoolStatus
-	var nodeDepositBalance *big.Int
-	var userDepositBalance *big.Int
-	var userDepositTime uint64
-
-	// Load data
-	wg.Go(func() error {
-		var err error
-		status, err = mp.GetStatus(nil)
-		return err
-	})
-	wg.Go(func() error {
-		var err error
-		nodeDepositBalance, err = mp.GetNodeDepositBalance(nil)
-		return err
-	})
-	wg.Go(func() error {
-		var err error
-		userDepositBalance, err = mp.GetUserDepositBalance(nil)
-		return err
-	})
-	wg.Go(func() error {
-		userDepositAssignedTime, err := mp.GetUserDepositAssignedTime(nil)
-		if err == nil {
-			userDepositTime = uint64(userDepositAssignedTime.Unix())
-		}
-		return err
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
-		return minipoolWithdrawableDetails{}, err
-	}
+func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress common.Address, mp *minipool.Minipool, state minipoolWithdrawableState, validator beacon.ValidatorStatus, eth2Config beacon.Eth2Config, beaconHead beacon.BeaconHead) (minipoolWithdrawableDetails, error) {
 
 	// Check minipool status
-	if status != types.Staking {
+	if types.MinipoolStatus(state.status) != types.Staking {
 		return minipoolWithdrawableDetails{}, nil
 	}
 
@@ -295,7 +273,7 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
 	}
 
 	// Get start epoch for node balance calculation
-	startEpoch := eth2.EpochAt(eth2Config, userDepositTime)
+	startEpoch := eth2.EpochAt(eth2Config, state.userDepositAssignedTime.Uint64())
 	if startEpoch < validator.ActivationEpoch {
 		startEpoch = validator.ActivationEpoch
 	} else if startEpoch > beaconHead.FinalizedEpoch {
@@ -304,7 +282,7 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
 
 	// Get validator activation balance
 	activationBalanceWei := new(big.Int)
-	activationBalanceWei.Add(nodeDepositBalance, userDepositBalance)
+	activationBalanceWei.Add(state.nodeDepositBalance, state.userDepositBalance)
 	activationBalance := eth.WeiToGwei(activationBalanceWei)
 
 	// Calculate approximate validator balance at start epoch & validator balance at current epoch
@@ -312,7 +290,7 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
 	endBalance := eth.GweiToWei(float64(validator.Balance))
 
 	// Check for existing node submission
-	nodeSubmittedMinipool, err := t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte("minipool.withdrawable.submitted.node"), nodeAddress.Bytes(), minipoolAddress.Bytes()))
+	nodeSubmittedMinipool, err := t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte("minipool.withdrawable.submitted.node"), nodeAddress.Bytes(), mp.Address.Bytes()))
 	if err != nil {
 		return minipoolWithdrawableDetails{}, err
 	}
@@ -321,7 +299,7 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
 	}
 
 	// Get the current ETH balance
-	ethBalance, err := t.rp.Client.BalanceAt(context.Background(), minipoolAddress, nil)
+	ethBalance, err := t.rp.Client.BalanceAt(context.Background(), mp.Address, nil)
 	if err != nil {
 		return minipoolWithdrawableDetails{}, err
 	}
@@ -341,7 +319,7 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
 
 	// Return
 	return minipoolWithdrawableDetails{
-		Address:      minipoolAddress,
+		Address:      mp.Address,
 		StartBalance: startBalance,
 		EndBalance:   endBalance,
 		Withdrawable: true,
@@ -0,0 +1,106 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The result of comparing the on-chain RPL price against a locally computed one
+type priceComparison struct {
+	OnChainPrice *big.Int
+	LocalPrice   *big.Int
+	AbsoluteDiff *big.Int
+	RelativeDiff float64 // As a percentage of the on-chain price
+	Tolerance    float64 // As a percentage of the on-chain price
+	Pass         bool
+}
+
+// Compare an on-chain price to a locally computed one, passing if the relative difference is within the tolerance (in percent)
+func comparePrices(onChainPrice *big.Int, localPrice *big.Int, tolerance float64) priceComparison {
+
+	absoluteDiff := new(big.Int).Sub(localPrice, onChainPrice)
+	absoluteDiff.Abs(absoluteDiff)
+
+	relativeDiff, _ := relativeDeviation(localPrice, onChainPrice).Float64()
+	relativeDiff *= 100
+
+	return priceComparison{
+		OnChainPrice: onChainPrice,
+		LocalPrice:   localPrice,
+		AbsoluteDiff: absoluteDiff,
+		RelativeDiff: relativeDiff,
+		Tolerance:    tolerance,
+		Pass:         !deviationExceeds(localPrice, onChainPrice, percentToPpm(tolerance)),
+	}
+
+}
+
+// Print a price comparison
+func (p priceComparison) print() {
+	fmt.Printf("On-chain RPL price:  %.6f ETH\n", math.RoundDown(eth.WeiToEth(p.OnChainPrice), 6))
+	fmt.Printf("Local RPL price:     %.6f ETH\n", math.RoundDown(eth.WeiToEth(p.LocalPrice), 6))
+	fmt.Printf("Absolute difference: %.6f ETH (%s wei)\n", math.RoundDown(eth.WeiToEth(p.AbsoluteDiff), 6), p.AbsoluteDiff.String())
+	fmt.Printf("Relative difference: %.4f%% (tolerance %.4f%%)\n", p.RelativeDiff, p.Tolerance)
+	if p.Pass {
+		fmt.Println("PASS: the locally computed price matches the on-chain price.")
+	} else {
+		fmt.Println("FAIL: the locally computed price does not match the on-chain price.")
+	}
+}
+
+// Verify that the on-chain RPL price matches the price computed from the configured oracles at the same block
+func verifyPrice(c *cli.Context, tolerance float64) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the block the current price was reported for
+	pricesBlock, err := network.GetPricesBlock(rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the RPL price block: %w", err)
+	}
+	if pricesBlock == 0 {
+		return fmt.Errorf("No RPL price has been reported yet.")
+	}
+
+	// Get the on-chain price as of that report
+	onChainPrice, err := network.GetRPLPrice(rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the on-chain RPL price: %w", err)
+	}
+
+	// Compute the price locally
+	localPrice, err := getRplPriceAtBlock(c, rp, cfg, pricesBlock, func(message string) {
+		fmt.Println(message)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Print the comparison
+	fmt.Printf("Comparing the RPL price reported for block %d.\n\n", pricesBlock)
+	result := comparePrices(onChainPrice, localPrice, tolerance)
+	result.print()
+	if !result.Pass {
+		return fmt.Errorf("price verification failed")
+	}
+	return nil
+
+}
@@ -0,0 +1,42 @@
+package watchtower
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+func TestComparePrices(t *testing.T) {
+	tests := []struct {
+		name         string
+		onChainPrice *big.Int
+		localPrice   *big.Int
+		tolerance    float64
+		wantDiff     *big.Int
+		wantRelative float64
+		wantPass     bool
+	}{
+		{"identical", eth.EthToWei(0.01), eth.EthToWei(0.01), 0, big.NewInt(0), 0, true},
+		{"local price higher", eth.EthToWei(0.01), eth.EthToWei(0.0102), 5, eth.EthToWei(0.0002), 2, true},
+		{"local price lower", eth.EthToWei(0.01), eth.EthToWei(0.0098), 5, eth.EthToWei(0.0002), 2, true},
+		{"outside the tolerance", eth.EthToWei(0.01), eth.EthToWei(0.0102), 1, eth.EthToWei(0.0002), 2, false},
+		{"no on-chain price", big.NewInt(0), eth.EthToWei(0.01), 5, eth.EthToWei(0.01), 100, false},
+		{"no prices", big.NewInt(0), big.NewInt(0), 0, big.NewInt(0), 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			comparison := comparePrices(test.onChainPrice, test.localPrice, test.tolerance)
+			if comparison.AbsoluteDiff.Cmp(test.wantDiff) != 0 {
+				t.Errorf("AbsoluteDiff = %s, want %s", comparison.AbsoluteDiff, test.wantDiff)
+			}
+			if math.Abs(comparison.RelativeDiff-test.wantRelative) > 1e-9 {
+				t.Errorf("RelativeDiff = %f, want %f", comparison.RelativeDiff, test.wantRelative)
+			}
+			if comparison.Pass != test.wantPass {
+				t.Errorf("Pass = %t, want %t", comparison.Pass, test.wantPass)
+			}
+		})
+	}
+}
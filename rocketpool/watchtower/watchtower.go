@@ -2,9 +2,14 @@ package watchtower
 
 import (
 	"fmt"
-	"math/rand"
+	stdlog "log"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/fatih/color"
@@ -12,6 +17,9 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -36,6 +44,9 @@ const (
 	SubmitRewardsTreeColor           = color.FgHiCyan
 	WarningColor                     = color.FgYellow
 	ProcessPenaltiesColor            = color.FgHiMagenta
+	AssignDepositsColor              = color.FgHiBlue
+	CheckTrustedMembershipColor      = color.FgHiWhite
+	ReportOdaoParticipationColor     = color.FgWhite
 )
 
 // Register watchtower command
@@ -47,6 +58,307 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		Action: func(c *cli.Context) error {
 			return run(c)
 		},
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "benchmark-oracle",
+				Usage:     "Measure the latency of each configured RPL price oracle and the underlying RPC",
+				UsageText: "rocketpool watchtower benchmark-oracle [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "iterations, i",
+						Usage: "The number of calls to make to each oracle",
+						Value: 10,
+					},
+					cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the results as JSON",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return benchmarkOracle(c, c.Uint64("iterations"), c.Bool("json"))
+
+				},
+			},
+
+			{
+				Name:      "verify-price",
+				Usage:     "Check that the on-chain RPL price matches the price computed from the configured oracles at the same block",
+				UsageText: "rocketpool watchtower verify-price [options]",
+				Flags: []cli.Flag{
+					cli.Float64Flag{
+						Name:  "tolerance, t",
+						Usage: "The maximum allowed relative difference, in percent",
+						Value: 0.5,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyPrice(c, c.Float64("tolerance"))
+
+				},
+			},
+
+			{
+				Name:      "backtest-prices",
+				Usage:     "Compare the RPL prices the configured oracles would have submitted against the prices that reached consensus",
+				UsageText: "rocketpool watchtower backtest-prices --from block [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "from",
+						Usage: "The first block to backtest",
+					},
+					cli.Uint64Flag{
+						Name:  "to",
+						Usage: "The last block to backtest (defaults to the latest block)",
+					},
+					cli.Float64Flag{
+						Name:  "tolerance, t",
+						Usage: "The maximum allowed relative difference, in percent",
+						Value: 0.5,
+					},
+					cli.BoolFlag{
+						Name:  "resume",
+						Usage: "Resume an interrupted backtest from its last completed checkpoint; the saved range and tolerance are used",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return backtestPrices(c, c.Uint64("from"), c.Uint64("to"), c.Float64("tolerance"), c.Bool("resume"))
+
+				},
+			},
+
+			{
+				Name:      "challenges",
+				Usage:     "Show any active challenge against the node, its response deadline and whether the node can respond to it",
+				UsageText: "rocketpool watchtower challenges [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the challenge details as JSON",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return printChallenges(c, c.Bool("json"))
+
+				},
+			},
+
+			{
+				Name:      "reset-safe-mode",
+				Usage:     "Clear safe mode so the watchtower can submit transactions again",
+				UsageText: "rocketpool watchtower reset-safe-mode",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					store, err := services.GetStateStore(c)
+					if err != nil {
+						return err
+					}
+					return resetSafeMode(store)
+
+				},
+			},
+
+			{
+				Name:      "dump-config",
+				Usage:     "Print the effective configuration with secrets, credentials and paths redacted so it can be shared in bug reports",
+				UsageText: "rocketpool watchtower dump-config",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					cfg, err := services.GetConfig(c)
+					if err != nil {
+						return err
+					}
+					fmt.Print(cfg.RedactedString())
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "replay",
+				Usage:     "Reconstruct the decision the watchtower would make about submitting prices for a block and print it as an audit record",
+				UsageText: "rocketpool watchtower replay --block block [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "block, b",
+						Usage: "The block to replay the price submission decision for",
+					},
+					cli.Uint64Flag{
+						Name:  "at",
+						Usage: "The block to read the on-chain state at, which requires an archive node for old blocks (defaults to the latest block)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if !c.IsSet("block") {
+						return fmt.Errorf("The --block flag is required.")
+					}
+
+					// Run
+					return replayPriceSubmission(c, c.Uint64("block"), c.Uint64("at"))
+
+				},
+			},
+
+			{
+				Name:      "test-alert",
+				Usage:     "Send a sample notification through the configured alert webhook",
+				UsageText: "rocketpool watchtower test-alert",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return sendTestAlert(c)
+
+				},
+			},
+
+			{
+				Name:      "diff-rewards",
+				Usage:     "Generate the rewards tree for an interval locally and list the nodes whose allocations differ from the consensus tree",
+				UsageText: "rocketpool watchtower diff-rewards --interval interval [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "interval, i",
+						Usage: "The rewards interval to compare",
+					},
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The path to the consensus rewards tree file (downloaded from IPFS if not set)",
+					},
+					cli.StringFlag{
+						Name:  "cid",
+						Usage: "The IPFS CID of the consensus rewards tree (defaults to the CID recorded on-chain for the interval)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if !c.IsSet("interval") {
+						return fmt.Errorf("The --interval flag is required.")
+					}
+
+					// Run
+					return diffRewards(c, c.Uint64("interval"), c.String("file"), c.String("cid"))
+
+				},
+			},
+
+			{
+				Name:      "storage-key",
+				Usage:     "Print the RocketStorage key that records whether a node has submitted prices for a block",
+				UsageText: "rocketpool watchtower storage-key --block block [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "block, b",
+						Usage: "The block the prices were submitted for",
+					},
+					cli.StringFlag{
+						Name:  "address, a",
+						Usage: "The node address (defaults to the node wallet)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if !c.IsSet("block") {
+						return fmt.Errorf("The --block flag is required.")
+					}
+
+					// Run
+					return printStorageKey(c, c.Uint64("block"), c.String("address"))
+
+				},
+			},
+
+			{
+				Name:      "missed-blocks",
+				Usage:     "List the reportable blocks the node didn't submit prices or balances for while it was an Oracle DAO member",
+				UsageText: "rocketpool watchtower missed-blocks --from block [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "from",
+						Usage: "The first block to check",
+					},
+					cli.Uint64Flag{
+						Name:  "to",
+						Usage: "The last block to check (defaults to the latest block that reached consensus)",
+					},
+					cli.StringFlag{
+						Name:  "address, a",
+						Usage: "The node address (defaults to the node wallet)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if !c.IsSet("from") {
+						return fmt.Errorf("The --from flag is required.")
+					}
+
+					// Run
+					return printMissedBlocks(c, c.Uint64("from"), c.Uint64("to"), c.String("address"))
+
+				},
+			},
+		},
 	})
 }
 
@@ -56,27 +368,99 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
+	// Set up logging
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if err := configureLogging(cfg); err != nil {
+		return err
+	}
+
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
 		return err
 	}
 
+	// Warn if the contracts have moved past the version the bindings support
+	startupLog := log.NewColorLogger(WarningColor)
+	if err := checkProtocolVersion(c); err != nil {
+		startupLog.Printlnf("WARNING: %s", err.Error())
+	}
+
 	// Initialize the scrub metrics reporter
 	scrubCollector := collectors.NewScrubCollector()
+	priceCollector := collectors.NewPriceCollector()
+	participationCollector := collectors.NewParticipationCollector()
+	accountCollector := collectors.NewAccountCollector()
+	taskCollector := collectors.NewTaskCollector()
+	clientCollector := collectors.NewClientCollector()
 
 	// Initialize error logger
 	errorLog := log.NewColorLogger(ErrorColor)
 
+	// Initialize the alerter for client outages
+	store, err := services.GetStateStore(c)
+	if err != nil {
+		return err
+	}
+	alerter, err := newDedupeAlerter(cfg, store)
+	if err != nil {
+		return err
+	}
+
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+
+	// Warn about a skewed system clock up front; safe mode holds off submissions while it stays skewed
+	if err := checkClockSkew(ec, cfg); err != nil {
+		startupLog.Printlnf("WARNING: %s", err.Error())
+	}
+
+	// Initialize the node account balance check
+	balanceCheck := newAccountBalanceCheck(cfg, ec, accountCollector, alerter, log.NewColorLogger(WarningColor))
+
+	// Initialize the price cache shared by the tasks in each iteration
+	prices := newPriceCache()
+	versions := newClientVersionTracker(c, errorLog, clientCollector)
+
+	// Initialize the audit log and prune the old state before the tasks start adding to it
+	audit := newAuditLogger(filepath.Join(cfg.Smartnode.GetWatchtowerFolder(true), config.WatchtowerAuditLogFile))
+	pruner := newStatePruner(cfg, audit, alerter, log.NewColorLogger(WarningColor))
+	pruner.run()
+
 	// Initialize tasks
+	checkTrustedMembership, err := newCheckTrustedMembership(c, log.NewColorLogger(CheckTrustedMembershipColor), errorLog)
+	if err != nil {
+		return fmt.Errorf("error during trusted membership check: %w", err)
+	}
+	checkWithdrawalAddress, err := newCheckWithdrawalAddress(c, log.NewColorLogger(WarningColor))
+	if err != nil {
+		return fmt.Errorf("error during withdrawal address check: %w", err)
+	}
+	reportOdaoParticipation, err := newReportOdaoParticipation(c, log.NewColorLogger(ReportOdaoParticipationColor), participationCollector)
+	if err != nil {
+		return fmt.Errorf("error during oracle DAO participation check: %w", err)
+	}
 	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor))
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor))
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), priceCollector, balanceCheck, prices, versions, audit)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor))
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), balanceCheck)
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
@@ -100,6 +484,10 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during rewards tree check: %w", err)
 	}
+	assignDeposits, err := newAssignDeposits(c, log.NewColorLogger(AssignDepositsColor))
+	if err != nil {
+		return fmt.Errorf("error during deposit assignment check: %w", err)
+	}
 	/*processPenalties, err := newProcessPenalties(c, log.NewColorLogger(ProcessPenaltiesColor), errorLog)
 	if err != nil {
 		return fmt.Errorf("error during penalties check: %w", err)
@@ -109,8 +497,68 @@ func run(c *cli.Context) error {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
 
-	intervalDelta := maxTasksInterval - minTasksInterval
-	secondsDelta := intervalDelta.Seconds()
+	// Initialize the source of task loop triggers
+	var heads headSource = newPollingHeadSource()
+	if wsUrl := cfg.Smartnode.WatchtowerWsUrl.Value.(string); wsUrl != "" {
+		heads = newSubscriptionHeadSource(cfg, wsUrl, log.NewColorLogger(WarningColor))
+	}
+	throttle := newLoopThrottle(minTasksInterval)
+
+	// Initialize the checks that decide whether this watchtower can submit transactions
+	var elector *leaderElector
+	if leaseTime := cfg.Smartnode.LeaderLeaseTime.Value.(uint64); leaseTime > 0 {
+		elector, err = newLeaderElector(store, time.Duration(leaseTime)*time.Second, log.NewColorLogger(WarningColor))
+		if err != nil {
+			return err
+		}
+		elector.start()
+
+		// The lease can lapse while a task is running, so confirm it again before anything is sent
+		w.SetTransactorGuard(elector.confirm)
+	}
+	isLeader := func() bool {
+		if elector == nil {
+			return true
+		}
+		return elector.isLeader()
+	}
+	warmup := newStartupWarmup(time.Now(), time.Duration(cfg.Smartnode.StartupWarmupSeconds.Value.(uint64))*time.Second, log.NewColorLogger(WarningColor))
+	safeMode := newSafeMode(cfg, store, log.NewColorLogger(WarningColor))
+	canSubmit := func() bool {
+		engaged, err := safeMode.isEngaged()
+		if err != nil {
+			errorLog.Println(err)
+			return false
+		}
+		if engaged {
+			return false
+		}
+		if warmup.isWarmingUp(time.Now()) {
+			return false
+		}
+		return isLeader()
+	}
+
+	// Hold off on the price and balance submissions for a moment after the Oracle DAO reaches consensus
+	cooldown := newConsensusCooldown(time.Duration(cfg.Smartnode.ConsensusCooldownSeconds.Value.(uint64)) * time.Second)
+	cooldownLog := log.NewColorLogger(WarningColor)
+	isCoolingDown := func() bool {
+		remaining, err := cooldown.update(rp)
+		if err != nil {
+			errorLog.Println(err)
+			return false
+		}
+		if remaining > 0 {
+			cooldownLog.Printlnf("Consensus was recently reached, waiting %s before evaluating the next prices and balances.", remaining.Round(time.Second))
+			return true
+		}
+		return false
+	}
+
+	// Reload the hot-swappable settings on SIGHUP
+	reloads := make(chan os.Signal, 1)
+	signal.Notify(reloads, syscall.SIGHUP)
+	reloadLog := log.NewColorLogger(WarningColor)
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
@@ -119,71 +567,141 @@ func run(c *cli.Context) error {
 	// Run task loop
 	go func() {
 		for {
-			// Randomize the next interval
-			randomSeconds := rand.Intn(int(secondsDelta))
-			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
+			// Apply a pending config reload between iterations so no task sees a half-updated config
+			select {
+			case <-reloads:
+				if err := reloadConfig(c, cfg, reloadLog); err != nil {
+					errorLog.Println(err)
+				}
+			default:
+			}
+			prices.clear()
+			pruner.run()
+
+			// New heads only run the checkpoint tasks until the full task loop is due again
+			fullRun := throttle.isFullRun(time.Now())
 
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
-			if err != nil {
-				errorLog.Println(err)
-			} else {
+			reportClientStatus(alerter, errorLog, "ec-sync", "Execution client unavailable", err)
+			if safeModeErr := safeMode.update(ecSyncTripwire, err); safeModeErr != nil {
+				errorLog.Println(safeModeErr)
+			}
+			if err == nil {
 				// Check the BC status
 				err := services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
-				if err != nil {
-					errorLog.Println(err)
-				} else {
-					// Run the manual rewards tree generation
-					if err := generateRewardsTree.run(); err != nil {
+				reportClientStatus(alerter, errorLog, "bc-sync", "Beacon client unavailable", err)
+				if err == nil {
+					// Record which client software the submissions are based on
+					versions.refresh()
+
+					// Check the safe mode tripwires; the price source ones are only checked if the node could submit prices
+					checkPrices, err := canSubmitPrices(rp, w)
+					if err != nil {
 						errorLog.Println(err)
 					}
-					time.Sleep(taskCooldown)
-
-					// Run the challenge check
-					if err := respondChallenges.run(); err != nil {
+					if err := safeMode.checkTripwires(ec, cfg, prices, checkPrices); err != nil {
 						errorLog.Println(err)
 					}
-					time.Sleep(taskCooldown)
 
-					// Run the rewards tree submission check
-					if err := submitRewardsTree.run(); err != nil {
-						errorLog.Println(err)
+					// Run the tasks that don't depend on a checkpoint
+					if fullRun {
+						// Check for changes in the node's trusted membership
+						if err := timeTask(taskCollector, "check-trusted-membership", checkTrustedMembership.run); err != nil {
+							errorLog.Println(err)
+						}
+
+						// Check the node's withdrawal address
+						if err := timeTask(taskCollector, "check-withdrawal-address", checkWithdrawalAddress.run); err != nil {
+							errorLog.Println(err)
+						}
+
+						// Update the Oracle DAO participation rates
+						if err := timeTask(taskCollector, "report-odao-participation", reportOdaoParticipation.run); err != nil {
+							errorLog.Println(err)
+						}
+
+						// Run the manual rewards tree generation
+						if err := timeTask(taskCollector, "generate-rewards-tree", generateRewardsTree.run); err != nil {
+							errorLog.Println(err)
+						}
+						time.Sleep(taskCooldown)
+
+						// Run the challenge check
+						if canSubmit() {
+							if err := timeTask(taskCollector, "respond-challenges", respondChallenges.run); err != nil {
+								errorLog.Println(err)
+							}
+						}
+						time.Sleep(taskCooldown)
+
+						// Run the rewards tree submission check
+						if canSubmit() {
+							if err := timeTask(taskCollector, "submit-rewards-tree", submitRewardsTree.run); err != nil {
+								errorLog.Println(err)
+							}
+						}
+						time.Sleep(taskCooldown)
 					}
-					time.Sleep(taskCooldown)
+
+					// Check the consensus cooldown once for both checkpoint tasks
+					coolingDown := isCoolingDown()
 
 					// Run the price submission check
-					if err := submitRplPrice.run(); err != nil {
-						errorLog.Println(err)
+					if canSubmit() && !coolingDown {
+						if err := timeTask(taskCollector, "submit-rpl-price", submitRplPrice.run); err != nil {
+							errorLog.Println(err)
+						}
 					}
 					time.Sleep(taskCooldown)
 
 					// Run the network balance submission check
-					if err := submitNetworkBalances.run(); err != nil {
-						errorLog.Println(err)
+					if canSubmit() && !coolingDown {
+						if err := timeTask(taskCollector, "submit-network-balances", submitNetworkBalances.run); err != nil {
+							errorLog.Println(err)
+						}
 					}
-					time.Sleep(taskCooldown)
+					if fullRun {
+						time.Sleep(taskCooldown)
 
-					// Run the withdrawable status submission check
-					if err := submitWithdrawableMinipools.run(); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
+						// Run the withdrawable status submission check
+						if canSubmit() {
+							if err := timeTask(taskCollector, "submit-withdrawable-minipools", submitWithdrawableMinipools.run); err != nil {
+								errorLog.Println(err)
+							}
+						}
+						time.Sleep(taskCooldown)
 
-					// Run the minipool dissolve check
-					if err := dissolveTimedOutMinipools.run(); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
+						// Run the minipool dissolve check
+						if canSubmit() {
+							if err := timeTask(taskCollector, "dissolve-timed-out-minipools", dissolveTimedOutMinipools.run); err != nil {
+								errorLog.Println(err)
+							}
+						}
+						time.Sleep(taskCooldown)
 
-					// Run the withdrawal processing check
-					if err := processWithdrawals.run(); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
+						// Run the withdrawal processing check
+						if canSubmit() {
+							if err := timeTask(taskCollector, "process-withdrawals", processWithdrawals.run); err != nil {
+								errorLog.Println(err)
+							}
+						}
+						time.Sleep(taskCooldown)
 
-					// Run the minipool scrub check
-					if err := submitScrubMinipools.run(); err != nil {
-						errorLog.Println(err)
+						// Run the minipool scrub check
+						if canSubmit() {
+							if err := timeTask(taskCollector, "submit-scrub-minipools", submitScrubMinipools.run); err != nil {
+								errorLog.Println(err)
+							}
+						}
+						time.Sleep(taskCooldown)
+
+						// Run the deposit assignment check
+						if canSubmit() {
+							if err := timeTask(taskCollector, "assign-deposits", assignDeposits.run); err != nil {
+								errorLog.Println(err)
+							}
+						}
 					}
 					/*time.Sleep(taskCooldown)
 
@@ -194,14 +712,21 @@ func run(c *cli.Context) error {
 					// DISABLED until MEV-Boost can support it
 				}
 			}
-			time.Sleep(interval)
+			heads.next()
 		}
 		wg.Done()
 	}()
 
+	// Run the debug server; it isn't waited on since it's off unless explicitly enabled
+	go func() {
+		if err := runPprofServer(cfg, log.NewColorLogger(MetricsColor)); err != nil {
+			errorLog.Println(err)
+		}
+	}()
+
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, priceCollector, participationCollector, accountCollector, taskCollector, clientCollector)
 		if err != nil {
 			errorLog.Println(err)
 		}
@@ -222,3 +747,70 @@ func configureHTTP() {
 	http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost = MaxConcurrentEth1Requests
 
 }
+
+// Run a task, recording how long it took
+func timeTask(collector *collectors.TaskCollector, name string, run func() error) error {
+	start := time.Now()
+	err := run()
+	collector.ObserveDuration(name, time.Since(start))
+	return err
+}
+
+// Route the daemon's logs to the console, the configured log file, or both
+func configureLogging(cfg *config.RocketPoolConfig) error {
+
+	output, err := log.NewOutput(os.Stderr, os.ExpandEnv(cfg.Smartnode.LogFile.Value.(string)), cfg.Smartnode.LogMaxSizeMb.Value.(uint64), cfg.Smartnode.LogToConsole.Value == true)
+	if err != nil {
+		return err
+	}
+	stdlog.SetOutput(output)
+	return nil
+
+}
+
+// Reload the config and apply the settings that can be changed without a restart
+func reloadConfig(c *cli.Context, cfg *config.RocketPoolConfig, logger log.ColorLogger) error {
+
+	newCfg, err := services.ReloadConfig(c)
+	if err != nil {
+		return fmt.Errorf("Error reloading config, keeping the current settings: %w", err)
+	}
+	if errs := newCfg.Validate(); len(errs) > 0 {
+		return fmt.Errorf("Reloaded config is invalid, keeping the current settings: %s", strings.Join(errs, "; "))
+	}
+
+	applied, deferred := cfg.ApplyHotSwappable(newCfg)
+	if len(applied) == 0 && len(deferred) == 0 {
+		logger.Println("Reloaded config, no settings changed.")
+		return nil
+	}
+	for _, change := range applied {
+		logger.Printlnf("Reloaded %s.%s: %s -> %s", change.Section, change.ID, change.OldValue, change.NewValue)
+	}
+	for _, change := range deferred {
+		logger.Printlnf("WARNING: %s.%s changed (%s -> %s) but requires a restart to take effect.", change.Section, change.ID, change.OldValue, change.NewValue)
+	}
+	return nil
+
+}
+
+// Log and alert on a client status error, or clear the alert if the client is healthy again
+func reportClientStatus(alerter *dedupeAlerter, errorLog log.ColorLogger, key string, title string, err error) {
+	if err == nil {
+		if err := alerter.Resolve(key); err != nil {
+			errorLog.Println(err)
+		}
+		return
+	}
+	errorLog.Println(err)
+	if err := alerter.Alert(key, title, err.Error()); err != nil {
+		errorLog.Printlnf("Error sending alert: %s", err.Error())
+	}
+}
+
+// Get the options for reading validator details from the Beacon chain during checks
+func getValidatorStatusOptions(cfg *config.RocketPoolConfig) *beacon.ValidatorStatusOptions {
+	return &beacon.ValidatorStatusOptions{
+		StateId: cfg.Smartnode.BeaconStateId.Value.(string),
+	}
+}
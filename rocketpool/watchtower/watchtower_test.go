@@ -0,0 +1,24 @@
+package watchtower
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+)
+
+func TestTimeTask(t *testing.T) {
+	collector := collectors.NewTaskCollector()
+	taskErr := errors.New("task failed")
+	ran := false
+	err := timeTask(collector, "test", func() error {
+		ran = true
+		return taskErr
+	})
+	if !ran {
+		t.Error("timeTask() didn't run the task")
+	}
+	if err != taskErr {
+		t.Errorf("timeTask() returned %v, want the task's error", err)
+	}
+}
@@ -0,0 +1,78 @@
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const webhookTimeout = 10 * time.Second
+
+// The body of an alert sent to the webhook
+type alertPayload struct {
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+	Text    string    `json:"text"`
+}
+
+// Sends alerts to the configured webhook
+type Alerter struct {
+	url    string
+	client *http.Client
+}
+
+// Create a new alerter from the Smartnode config
+func NewAlerter(cfg *config.RocketPoolConfig) *Alerter {
+	return &Alerter{
+		url: cfg.Smartnode.AlertWebhookUrl.Value.(string),
+		client: &http.Client{
+			Timeout: webhookTimeout,
+		},
+	}
+}
+
+// Check if a webhook has been configured
+func (a *Alerter) IsEnabled() bool {
+	return a.url != ""
+}
+
+// Send an alert to the webhook; this is a no-op if no webhook is configured
+func (a *Alerter) Alert(title string, message string) error {
+
+	if !a.IsEnabled() {
+		return nil
+	}
+
+	// Serialize the payload
+	body, err := json.Marshal(alertPayload{
+		Title:   title,
+		Message: message,
+		Time:    time.Now().UTC(),
+		Text:    fmt.Sprintf("%s: %s", title, message),
+	})
+	if err != nil {
+		return fmt.Errorf("Error serializing alert: %w", err)
+	}
+
+	// Send it
+	response, err := a.client.Post(a.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("Error sending alert: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Check the response code
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("Alert webhook request failed with code %d", response.StatusCode)
+	}
+	return nil
+
+}
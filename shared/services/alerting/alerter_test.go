@@ -0,0 +1,62 @@
+package alerting
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Create an alerter that sends to the given webhook URL
+func newTestAlerter(url string) *Alerter {
+	cfg := config.NewRocketPoolConfig("", false)
+	cfg.Smartnode.AlertWebhookUrl.Value = url
+	return NewAlerter(cfg)
+}
+
+func TestAlerterSendsPayload(t *testing.T) {
+	payloads := make(chan alertPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload alertPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Error decoding alert: %s", err)
+		}
+		payloads <- payload
+	}))
+	defer server.Close()
+
+	alerter := newTestAlerter(server.URL)
+	if !alerter.IsEnabled() {
+		t.Fatal("IsEnabled() = false with a webhook configured")
+	}
+	if err := alerter.Alert("Title", "Message"); err != nil {
+		t.Fatalf("Alert() returned an error: %s", err)
+	}
+	payload := <-payloads
+	if payload.Title != "Title" || payload.Message != "Message" || payload.Text != "Title: Message" {
+		t.Errorf("payload = %+v, want title \"Title\", message \"Message\" and text \"Title: Message\"", payload)
+	}
+}
+
+func TestAlerterFailsOnErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := newTestAlerter(server.URL).Alert("Title", "Message"); err == nil {
+		t.Error("Alert() should fail when the webhook returns an error code")
+	}
+}
+
+func TestAlerterDisabled(t *testing.T) {
+	alerter := newTestAlerter("")
+	if alerter.IsEnabled() {
+		t.Fatal("IsEnabled() = true without a webhook")
+	}
+	if err := alerter.Alert("Title", "Message"); err != nil {
+		t.Errorf("Alert() returned an error while disabled: %s", err)
+	}
+}
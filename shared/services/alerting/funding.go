@@ -0,0 +1,72 @@
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A request to top up an account's balance; amounts are in wei
+type FundingRequest struct {
+	Event   string    `json:"event"`
+	Address string    `json:"address"`
+	Balance string    `json:"balance"`
+	Target  string    `json:"target"`
+	TopUp   string    `json:"topUp"`
+	Time    time.Time `json:"time"`
+}
+
+// Sends funding requests to the configured webhook
+type FundingNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Create a new funding notifier from the Smartnode config
+func NewFundingNotifier(cfg *config.RocketPoolConfig) *FundingNotifier {
+	return &FundingNotifier{
+		url: cfg.Smartnode.FundingWebhookUrl.Value.(string),
+		client: &http.Client{
+			Timeout: webhookTimeout,
+		},
+	}
+}
+
+// Check if a webhook has been configured
+func (n *FundingNotifier) IsEnabled() bool {
+	return n.url != ""
+}
+
+// Send a funding request to the webhook; this is a no-op if no webhook is configured
+func (n *FundingNotifier) Notify(request FundingRequest) error {
+
+	if !n.IsEnabled() {
+		return nil
+	}
+
+	// Serialize the payload
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("Error serializing funding request: %w", err)
+	}
+
+	// Send it
+	response, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("Error sending funding request: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Check the response code
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("Funding webhook request failed with code %d", response.StatusCode)
+	}
+	return nil
+
+}
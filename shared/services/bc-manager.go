@@ -3,6 +3,7 @@ package services
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -71,18 +72,35 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 		}
 	}
 
+	// Extra headers
+	headers, err := cfg.Smartnode.GetBcHeaders()
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing BC headers: %w", err)
+	}
+
+	// Retry policy
+	retryStatusCodes, err := cfg.Smartnode.GetBcRetryStatusCodes()
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing BC retry status codes: %w", err)
+	}
+	retryPolicy := client.RetryPolicy{
+		Attempts:             int(cfg.Smartnode.BcRetryAttempts.Value.(uint64)),
+		Backoff:              time.Duration(cfg.Smartnode.BcRetryBackoffMs.Value.(uint64)) * time.Millisecond,
+		RetryableStatusCodes: retryStatusCodes,
+	}
+
 	var primaryBc beacon.Client
 	var fallbackBc beacon.Client
 	switch selectedCC {
 	case cfgtypes.ConsensusClient_Nimbus:
-		primaryBc = client.NewNimbusClient(primaryProvider)
+		primaryBc = client.NewNimbusClient(primaryProvider, headers, retryPolicy)
 		if fallbackProvider != "" {
-			fallbackBc = client.NewNimbusClient(fallbackProvider)
+			fallbackBc = client.NewNimbusClient(fallbackProvider, headers, retryPolicy)
 		}
 	default:
-		primaryBc = client.NewStandardHttpClient(primaryProvider)
+		primaryBc = client.NewStandardHttpClient(primaryProvider, headers, retryPolicy)
 		if fallbackProvider != "" {
-			fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+			fallbackBc = client.NewStandardHttpClient(fallbackProvider, headers, retryPolicy)
 		}
 	}
 
@@ -122,6 +140,17 @@ func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
 	return result.(beacon.SyncStatus), nil
 }
 
+// Get the name and version string the client reports for itself
+func (m *BeaconClientManager) GetClientVersion() (string, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetClientVersion()
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.(string), nil
+}
+
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2Config() (beacon.Eth2Config, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -8,8 +8,9 @@ import (
 
 // API request options
 type ValidatorStatusOptions struct {
-	Epoch *uint64
-	Slot  *uint64
+	Epoch   *uint64
+	Slot    *uint64
+	StateId string // A named state such as "head" or "finalized"; takes precedence over Epoch and Slot if set
 }
 
 // API response types
@@ -113,6 +114,7 @@ const (
 type Client interface {
 	GetClientType() (BeaconClientType, error)
 	GetSyncStatus() (SyncStatus, error)
+	GetClientVersion() (string, error)
 	GetEth2Config() (Eth2Config, error)
 	GetEth2DepositContract() (Eth2DepositContract, error)
 	GetAttestations(blockId string) ([]AttestationInfo, bool, error)
@@ -7,9 +7,9 @@ type NimbusClient struct {
 }
 
 // Create a new client instance
-func NewNimbusClient(providerAddress string) *NimbusClient {
+func NewNimbusClient(providerAddress string, headers map[string]string, retryPolicy RetryPolicy) *NimbusClient {
 	return &NimbusClient{
-		StandardHttpClient: *NewStandardHttpClient(providerAddress),
+		StandardHttpClient: *NewStandardHttpClient(providerAddress, headers, retryPolicy),
 	}
 }
 
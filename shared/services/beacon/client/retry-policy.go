@@ -0,0 +1,36 @@
+package client
+
+import (
+	"time"
+)
+
+// How the client retries requests that get a transient error response from the beacon node
+type RetryPolicy struct {
+	// The total number of times a request is attempted; values below 1 mean it's only attempted once
+	Attempts int
+
+	// How long to wait before the first retry; the wait doubles with every retry after that
+	Backoff time.Duration
+
+	// The HTTP status codes that are retried
+	RetryableStatusCodes []int
+}
+
+// Check if a response with the given status code should be retried
+func (p RetryPolicy) isRetryable(statusCode int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Get how long to wait before the next attempt, after the given number of attempts have been made
+func (p RetryPolicy) getBackoff(attempt int) time.Duration {
+	backoff := p.Backoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+	}
+	return backoff
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyGetBackoff(t *testing.T) {
+	policy := RetryPolicy{Backoff: 100 * time.Millisecond}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+	}
+	for _, test := range tests {
+		if got := policy.getBackoff(test.attempt); got != test.want {
+			t.Errorf("getBackoff(%d) = %s, want %s", test.attempt, got, test.want)
+		}
+	}
+}
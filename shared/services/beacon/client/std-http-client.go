@@ -28,6 +28,7 @@ const (
 	RequestContentType = "application/json"
 
 	RequestSyncStatusPath            = "/eth/v1/node/syncing"
+	RequestNodeVersionPath           = "/eth/v1/node/version"
 	RequestEth2ConfigPath            = "/eth/v1/config/spec"
 	RequestEth2DepositContractMethod = "/eth/v1/config/deposit_contract"
 	RequestGenesisPath               = "/eth/v1/beacon/genesis"
@@ -47,12 +48,17 @@ const (
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
 	providerAddress string
+	headers         map[string]string
+	retryPolicy     RetryPolicy
 }
 
-// Create a new client instance
-func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
+// Create a new client instance; the headers are attached to every request, and requests that get a retryable
+// response are retried according to the retry policy
+func NewStandardHttpClient(providerAddress string, headers map[string]string, retryPolicy RetryPolicy) *StandardHttpClient {
 	return &StandardHttpClient{
 		providerAddress: providerAddress,
+		headers:         headers,
+		retryPolicy:     retryPolicy,
 	}
 }
 
@@ -86,6 +92,15 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 
 }
 
+// Get the name and version string the node reports for itself
+func (c *StandardHttpClient) GetClientVersion() (string, error) {
+	nodeVersion, err := c.getNodeVersion()
+	if err != nil {
+		return "", err
+	}
+	return nodeVersion.Data.Version, nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -567,6 +582,22 @@ func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	return syncStatus, nil
 }
 
+// Get the node version
+func (c *StandardHttpClient) getNodeVersion() (NodeVersionResponse, error) {
+	responseBody, status, err := c.getRequest(RequestNodeVersionPath)
+	if err != nil {
+		return NodeVersionResponse{}, fmt.Errorf("Could not get node version: %w", err)
+	}
+	if status != http.StatusOK {
+		return NodeVersionResponse{}, fmt.Errorf("Could not get node version: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var nodeVersion NodeVersionResponse
+	if err := json.Unmarshal(responseBody, &nodeVersion); err != nil {
+		return NodeVersionResponse{}, fmt.Errorf("Could not decode node version: %w", err)
+	}
+	return nodeVersion, nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) getEth2Config() (Eth2ConfigResponse, error) {
 	responseBody, status, err := c.getRequest(RequestEth2ConfigPath)
@@ -674,6 +705,8 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 	var stateId string
 	if opts == nil {
 		stateId = "head"
+	} else if opts.StateId != "" {
+		stateId = opts.StateId
 	} else if opts.Slot != nil {
 		stateId = strconv.FormatInt(int64(*opts.Slot), 10)
 	} else if opts.Epoch != nil {
@@ -795,7 +828,11 @@ func (c *StandardHttpClient) getCommittees(stateId string, epoch *uint64) (Commi
 func (c *StandardHttpClient) getRequest(requestPath string) ([]byte, int, error) {
 
 	// Send request
-	response, err := http.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	response, err := c.sendRequest(request)
 	if err != nil {
 		return []byte{}, 0, err
 	}
@@ -825,7 +862,12 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
 	// Send request
-	response, err := http.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), requestBodyReader)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	request.Header.Set("Content-Type", RequestContentType)
+	response, err := c.sendRequest(request)
 	if err != nil {
 		return []byte{}, 0, err
 	}
@@ -843,3 +885,28 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	return body, response.StatusCode, nil
 
 }
+
+// Send a request to the beacon node with the configured headers, retrying it if the response has a retryable status.
+// Requests that fail outright aren't retried here so the client manager can switch to the fallback client instead.
+func (c *StandardHttpClient) sendRequest(request *http.Request) (*http.Response, error) {
+	for key, value := range c.headers {
+		request.Header.Set(key, value)
+	}
+	for attempt := 1; ; attempt++ {
+		response, err := http.DefaultClient.Do(request)
+		if err != nil || attempt >= c.retryPolicy.Attempts || !c.retryPolicy.isRetryable(response.StatusCode) {
+			return response, err
+		}
+		_ = response.Body.Close()
+		time.Sleep(c.retryPolicy.getBackoff(attempt))
+
+		// Rewind the body for the next attempt
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+	}
+}
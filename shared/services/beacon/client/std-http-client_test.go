@@ -0,0 +1,106 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Create a client for a test server
+func newTestStandardHttpClient(url string) *StandardHttpClient {
+	return NewStandardHttpClient(url, nil, RetryPolicy{})
+}
+
+func TestGetValidatorsByOptsStateId(t *testing.T) {
+	paths := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+	client := newTestStandardHttpClient(server.URL)
+
+	slot := uint64(1234)
+	tests := []struct {
+		name     string
+		opts     *beacon.ValidatorStatusOptions
+		wantPath string
+	}{
+		{"no options", nil, "/eth/v1/beacon/states/head/validators"},
+		{"named state", &beacon.ValidatorStatusOptions{StateId: "finalized"}, "/eth/v1/beacon/states/finalized/validators"},
+		{"slot", &beacon.ValidatorStatusOptions{Slot: &slot}, "/eth/v1/beacon/states/1234/validators"},
+		{"named state takes precedence over the slot", &beacon.ValidatorStatusOptions{StateId: "justified", Slot: &slot}, "/eth/v1/beacon/states/justified/validators"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := client.getValidatorsByOpts([]string{"1"}, test.opts); err != nil {
+				t.Fatalf("getValidatorsByOpts() returned an error: %s", err)
+			}
+			if path := <-paths; path != test.wantPath {
+				t.Errorf("requested %s, want %s", path, test.wantPath)
+			}
+		})
+	}
+}
+
+func TestStandardHttpClientSendsHeaders(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewStandardHttpClient(server.URL, map[string]string{"Authorization": "Bearer abc"}, RetryPolicy{})
+	if _, err := client.getValidatorsByOpts([]string{"1"}, nil); err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	if got := (<-headers).Get("Authorization"); got != "Bearer abc" {
+		t.Errorf("Authorization header = %q, want %q", got, "Bearer abc")
+	}
+}
+
+func TestStandardHttpClientRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		status       int
+		wantErr      bool
+		wantRequests int32
+	}{
+		{"retryable status", 2, http.StatusServiceUnavailable, false, 3},
+		{"too many failures", 5, http.StatusServiceUnavailable, true, 3},
+		{"status that isn't retried", 1, http.StatusNotFound, true, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= test.failures {
+					w.WriteHeader(test.status)
+					return
+				}
+				w.Write([]byte(`{"data":[]}`))
+			}))
+			defer server.Close()
+
+			policy := RetryPolicy{
+				Attempts:             3,
+				Backoff:              time.Millisecond,
+				RetryableStatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable},
+			}
+			client := NewStandardHttpClient(server.URL, nil, policy)
+			_, err := client.getValidatorsByOpts([]string{"1"}, nil)
+			if (err != nil) != test.wantErr {
+				t.Errorf("request returned error %v, want an error: %t", err, test.wantErr)
+			}
+			if got := atomic.LoadInt32(&requests); got != test.wantRequests {
+				t.Errorf("server got %d requests, want %d", got, test.wantRequests)
+			}
+		})
+	}
+}
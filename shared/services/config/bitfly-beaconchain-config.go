@@ -15,7 +15,7 @@ const (
 type BitflyNodeMetricsConfig struct {
 	Title string `yaml:"-"`
 
-	Secret config.Parameter `yaml:"secret,omitempty"`
+	Secret config.Parameter `yaml:"secret,omitempty" sensitive:"secret"`
 
 	Endpoint config.Parameter `yaml:"endpoint,omitempty"`
 
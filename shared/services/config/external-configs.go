@@ -9,10 +9,10 @@ type ExternalExecutionConfig struct {
 	Title string `yaml:"-"`
 
 	// The URL of the HTTP endpoint
-	HttpUrl config.Parameter `yaml:"httpUrl,omitempty"`
+	HttpUrl config.Parameter `yaml:"httpUrl,omitempty" sensitive:"url"`
 
 	// The URL of the websocket endpoint
-	WsUrl config.Parameter `yaml:"wsUrl,omitempty"`
+	WsUrl config.Parameter `yaml:"wsUrl,omitempty" sensitive:"url"`
 }
 
 // Configuration for external Consensus clients
@@ -20,7 +20,7 @@ type ExternalLighthouseConfig struct {
 	Title string `yaml:"-"`
 
 	// The URL of the HTTP endpoint
-	HttpUrl config.Parameter `yaml:"httpUrl,omitempty"`
+	HttpUrl config.Parameter `yaml:"httpUrl,omitempty" sensitive:"url"`
 
 	// Custom proposal graffiti
 	Graffiti config.Parameter `yaml:"graffiti,omitempty"`
@@ -40,7 +40,7 @@ type ExternalPrysmConfig struct {
 	Title string `yaml:"-"`
 
 	// The URL of the gRPC (REST) endpoint for the Beacon API
-	HttpUrl config.Parameter `yaml:"httpUrl,omitempty"`
+	HttpUrl config.Parameter `yaml:"httpUrl,omitempty" sensitive:"url"`
 
 	// Custom proposal graffiti
 	Graffiti config.Parameter `yaml:"graffiti,omitempty"`
@@ -49,7 +49,7 @@ type ExternalPrysmConfig struct {
 	DoppelgangerDetection config.Parameter `yaml:"doppelgangerDetection,omitempty"`
 
 	// The URL of the JSON-RPC endpoint for the Validator client
-	JsonRpcUrl config.Parameter `yaml:"jsonRpcUrl,omitempty"`
+	JsonRpcUrl config.Parameter `yaml:"jsonRpcUrl,omitempty" sensitive:"url"`
 
 	// The Docker Hub tag for Prysm's VC
 	ContainerTag config.Parameter `yaml:"containerTag,omitempty"`
@@ -63,7 +63,7 @@ type ExternalTekuConfig struct {
 	Title string `yaml:"-"`
 
 	// The URL of the HTTP endpoint
-	HttpUrl config.Parameter `yaml:"httpUrl,omitempty"`
+	HttpUrl config.Parameter `yaml:"httpUrl,omitempty" sensitive:"url"`
 
 	// Custom proposal graffiti
 	Graffiti config.Parameter `yaml:"graffiti,omitempty"`
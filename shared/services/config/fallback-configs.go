@@ -9,10 +9,10 @@ type FallbackNormalConfig struct {
 	Title string `yaml:"-"`
 
 	// The URL of the Execution Client HTTP endpoint
-	EcHttpUrl config.Parameter `yaml:"ecHttpUrl,omitempty"`
+	EcHttpUrl config.Parameter `yaml:"ecHttpUrl,omitempty" sensitive:"url"`
 
 	// The URL of the Beacon Node HTTP endpoint
-	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
+	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty" sensitive:"url"`
 }
 
 // Configuration for fallback Prysm
@@ -20,13 +20,13 @@ type FallbackPrysmConfig struct {
 	Title string `yaml:"-"`
 
 	// The URL of the Execution Client HTTP endpoint
-	EcHttpUrl config.Parameter `yaml:"ecHttpUrl,omitempty"`
+	EcHttpUrl config.Parameter `yaml:"ecHttpUrl,omitempty" sensitive:"url"`
 
 	// The URL of the Beacon Node HTTP endpoint
-	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
+	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty" sensitive:"url"`
 
 	// The URL of the JSON-RPC endpoint for the Validator client
-	JsonRpcUrl config.Parameter `yaml:"jsonRpcUrl,omitempty"`
+	JsonRpcUrl config.Parameter `yaml:"jsonRpcUrl,omitempty" sensitive:"url"`
 }
 
 // Generates a new FallbackNormalConfig configuration
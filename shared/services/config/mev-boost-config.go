@@ -77,7 +77,7 @@ type MevBoostConfig struct {
 	AdditionalFlags config.Parameter `yaml:"additionalFlags,omitempty"`
 
 	// The URL of an external MEV-Boost client
-	ExternalUrl config.Parameter `yaml:"externalUrl"`
+	ExternalUrl config.Parameter `yaml:"externalUrl" sensitive:"url"`
 
 	///////////////////////////
 	// Non-editable settings //
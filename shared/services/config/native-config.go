@@ -11,19 +11,19 @@ type NativeConfig struct {
 	Title string `yaml:"-"`
 
 	// The URL of the EC HTTP endpoint
-	EcHttpUrl config.Parameter `yaml:"ecHttpUrl,omitempty"`
+	EcHttpUrl config.Parameter `yaml:"ecHttpUrl,omitempty" sensitive:"url"`
 
 	// The selected CC
 	ConsensusClient config.Parameter `yaml:"consensusClient,omitempty"`
 
 	// The URL of the CC HTTP endpoint
-	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
+	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty" sensitive:"url"`
 
 	// The command for restarting the validator container in native mode
-	ValidatorRestartCommand config.Parameter `yaml:"validatorRestartCommand,omitempty"`
+	ValidatorRestartCommand config.Parameter `yaml:"validatorRestartCommand,omitempty" sensitive:"path"`
 
 	// The command for stopping the validator container in native mode
-	ValidatorStopCommand config.Parameter `yaml:"validatorStopCommand,omitempty"`
+	ValidatorStopCommand config.Parameter `yaml:"validatorStopCommand,omitempty" sensitive:"path"`
 }
 
 // Generates a new Smartnode configuration
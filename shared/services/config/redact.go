@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+	"gopkg.in/yaml.v2"
+)
+
+// The placeholder for redacted values
+const redactedValue string = "<redacted>"
+
+// The kinds of sensitive parameters, as used in the `sensitive` struct tag
+const (
+	sensitiveSecret string = "secret"
+	sensitiveUrl    string = "url"
+	sensitivePath   string = "path"
+)
+
+// Get the IDs of the sensitive parameters in a config struct, mapped to the kind of sensitivity from their tags
+func getSensitiveParameters(cfg interface{}) map[string]string {
+
+	sensitive := map[string]string{}
+	value := reflect.ValueOf(cfg)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return sensitive
+	}
+
+	parameterType := reflect.TypeOf(config.Parameter{})
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type != parameterType {
+			continue
+		}
+		kind := field.Tag.Get("sensitive")
+		if kind == "" {
+			continue
+		}
+		param := value.Field(i).Interface().(config.Parameter)
+		sensitive[param.ID] = kind
+	}
+	return sensitive
+
+}
+
+// Redact a sensitive value. URLs keep their scheme and host so the endpoint type is still visible, but lose any
+// credentials, path and query since API keys are commonly embedded there.
+func redactValue(value string, kind string) string {
+
+	if value == "" {
+		return value
+	}
+	if kind != sensitiveUrl {
+		return redactedValue
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return redactedValue
+	}
+	redacted := fmt.Sprintf("%s://", parsed.Scheme)
+	if parsed.User != nil {
+		redacted += redactedValue + "@"
+	}
+	redacted += parsed.Host
+	if parsed.Path != "" && parsed.Path != "/" {
+		redacted += "/" + redactedValue
+	}
+	if parsed.RawQuery != "" {
+		redacted += "?" + redactedValue
+	}
+	return redacted
+
+}
+
+// Serialize the config the same way as the settings file, with the values of sensitive parameters redacted
+// so it can be shared safely
+func (cfg *RocketPoolConfig) RedactedString() string {
+
+	masterMap := cfg.Serialize()
+
+	// Redact the root parameters
+	rootParams := masterMap[rootConfigName]
+	for id, kind := range getSensitiveParameters(cfg) {
+		rootParams[id] = redactValue(rootParams[id], kind)
+	}
+	rootParams["rpDir"] = redactValue(rootParams["rpDir"], sensitivePath)
+
+	// Redact the subconfig parameters
+	for name, subconfig := range cfg.GetSubconfigs() {
+		subconfigParams := masterMap[name]
+		for id, kind := range getSensitiveParameters(subconfig) {
+			if _, exists := subconfigParams[id]; exists {
+				subconfigParams[id] = redactValue(subconfigParams[id], kind)
+			}
+		}
+	}
+
+	bytes, err := yaml.Marshal(masterMap)
+	if err != nil {
+		return fmt.Sprintf("error serializing config: %s", err.Error())
+	}
+	return string(bytes)
+
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A parameter whose value differs between the running config and a reloaded one
+type ParameterChange struct {
+	Section  string
+	ID       string
+	OldValue string
+	NewValue string
+}
+
+// Get the IDs of the parameters in a config struct that are tagged as safe to change while the daemons are running
+func getHotSwappableParameters(cfg interface{}) map[string]bool {
+
+	hotSwappable := map[string]bool{}
+	value := reflect.ValueOf(cfg)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return hotSwappable
+	}
+
+	parameterType := reflect.TypeOf(config.Parameter{})
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type != parameterType || field.Tag.Get("hotswap") != "true" {
+			continue
+		}
+		param := value.Field(i).Interface().(config.Parameter)
+		hotSwappable[param.ID] = true
+	}
+	return hotSwappable
+
+}
+
+// Apply the hot-swappable settings from a reloaded config to this one.
+// Returns the changes that were applied, and the changes that were ignored because they only take effect after a
+// restart. Sensitive values are redacted in both lists so they can be logged.
+func (cfg *RocketPoolConfig) ApplyHotSwappable(newCfg *RocketPoolConfig) ([]ParameterChange, []ParameterChange) {
+
+	applied := []ParameterChange{}
+	deferred := []ParameterChange{}
+
+	oldSettings := cfg.Serialize()
+	newSettings := newCfg.Serialize()
+	newSubconfigs := newCfg.GetSubconfigs()
+
+	// Get the hot-swappable and sensitive parameters by section
+	hotSwappable := map[string]map[string]bool{}
+	sensitive := map[string]map[string]string{
+		rootConfigName: getSensitiveParameters(cfg),
+	}
+	for name, subconfig := range cfg.GetSubconfigs() {
+		hotSwappable[name] = getHotSwappableParameters(subconfig)
+		sensitive[name] = getSensitiveParameters(subconfig)
+	}
+
+	for _, section := range getSortedSections(oldSettings, newSettings) {
+		oldParams := oldSettings[section]
+		newParams := newSettings[section]
+		for _, id := range getSortedIds(oldParams, newParams) {
+			oldValue := oldParams[id]
+			newValue := newParams[id]
+			if oldValue == newValue {
+				continue
+			}
+			change := ParameterChange{
+				Section:  section,
+				ID:       id,
+				OldValue: oldValue,
+				NewValue: newValue,
+			}
+			if kind, exists := sensitive[section][id]; exists {
+				change.OldValue = redactValue(oldValue, kind)
+				change.NewValue = redactValue(newValue, kind)
+			}
+
+			if !hotSwappable[section][id] {
+				deferred = append(deferred, change)
+				continue
+			}
+			if setParameterValue(cfg.GetSubconfigs()[section].GetParameters(), newSubconfigs[section].GetParameters(), id) {
+				applied = append(applied, change)
+			} else {
+				deferred = append(deferred, change)
+			}
+		}
+	}
+
+	return applied, deferred
+
+}
+
+// Copy the value of the parameter with the given ID from one parameter list to another
+func setParameterValue(params []*config.Parameter, newParams []*config.Parameter, id string) bool {
+	for _, newParam := range newParams {
+		if newParam.ID != id {
+			continue
+		}
+		for _, param := range params {
+			if param.ID == id {
+				param.Value = newParam.Value
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Get the section names from a set of settings maps in a stable order
+func getSortedSections(settings ...map[string]map[string]string) []string {
+	names := map[string]bool{}
+	for _, settingsMap := range settings {
+		for name := range settingsMap {
+			names[name] = true
+		}
+	}
+	return getSortedKeys(names)
+}
+
+// Get the parameter IDs from a set of parameter maps in a stable order
+func getSortedIds(params ...map[string]string) []string {
+	ids := map[string]bool{}
+	for _, paramMap := range params {
+		for id := range paramMap {
+			ids[id] = true
+		}
+	}
+	return getSortedKeys(ids)
+}
+
+// Get the keys of a set in sorted order
+func getSortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
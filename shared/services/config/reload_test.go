@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+)
+
+// Find a change to a parameter by its section and ID
+func findParameterChange(changes []ParameterChange, section string, id string) *ParameterChange {
+	for i := range changes {
+		if changes[i].Section == section && changes[i].ID == id {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+func TestApplyHotSwappable(t *testing.T) {
+	cfg := NewRocketPoolConfig("", true)
+	newCfg := NewRocketPoolConfig("", true)
+	newCfg.Smartnode.PriceSources.Value = "chainlink"
+	newCfg.Smartnode.PprofPort.Value = uint64(6060)
+	newCfg.Smartnode.Web3StorageApiToken.Value = "secret-token"
+
+	applied, deferred := cfg.ApplyHotSwappable(newCfg)
+
+	// Hot-swappable settings are applied to the running config
+	if change := findParameterChange(applied, "smartnode", cfg.Smartnode.PriceSources.ID); change == nil {
+		t.Error("the price sources change wasn't applied")
+	} else if change.NewValue != "chainlink" {
+		t.Errorf("the price sources change has new value %q, want %q", change.NewValue, "chainlink")
+	}
+	if cfg.Smartnode.PriceSources.Value != "chainlink" {
+		t.Errorf("the price sources are %v, want chainlink", cfg.Smartnode.PriceSources.Value)
+	}
+
+	// Everything else is left until a restart
+	if findParameterChange(deferred, "smartnode", cfg.Smartnode.PprofPort.ID) == nil {
+		t.Error("the pprof port change wasn't deferred")
+	}
+	if cfg.Smartnode.PprofPort.Value != uint64(0) {
+		t.Errorf("the pprof port was changed to %v", cfg.Smartnode.PprofPort.Value)
+	}
+
+	// Sensitive values are redacted
+	if change := findParameterChange(deferred, "smartnode", cfg.Smartnode.Web3StorageApiToken.ID); change == nil {
+		t.Error("the web3.storage token change wasn't deferred")
+	} else if change.NewValue != redactedValue {
+		t.Errorf("the web3.storage token change has new value %q, want it redacted", change.NewValue)
+	}
+	if len(applied)+len(deferred) != 3 {
+		t.Errorf("got %d changes, want 3", len(applied)+len(deferred))
+	}
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alessio/shellescape"
+	"gopkg.in/yaml.v2"
+)
+
+// Settings for fetching a remote config
+const (
+	remoteConfigTimeout time.Duration = 10 * time.Second
+	remoteConfigMaxSize int64         = 1024 * 1024
+)
+
+// Load the config from a remote HTTPS URL, with its settings applied over the ones in the local settings file at path.
+// The merged config is validated before it's returned so a bad remote config can't replace a working local one.
+func LoadFromUrl(configUrl string, path string) (*RocketPoolConfig, error) {
+
+	// Get the remote settings
+	remoteSettings, err := fetchRemoteSettings(configUrl, remoteConfigTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the local settings, if there are any
+	localSettings := map[string]map[string]string{}
+	configBytes, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("could not read Rocket Pool settings file at %s: %w", shellescape.Quote(path), err)
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(configBytes, &localSettings); err != nil {
+			return nil, fmt.Errorf("could not parse settings file: %w", err)
+		}
+	}
+
+	// Deserialize the merged settings into a config object
+	cfg := NewRocketPoolConfig(filepath.Dir(path), false)
+	if err := cfg.Deserialize(mergeSettings(localSettings, remoteSettings)); err != nil {
+		return nil, fmt.Errorf("could not deserialize remote settings: %w", err)
+	}
+	if errs := cfg.Validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("remote settings are invalid: %s", strings.Join(errs, "; "))
+	}
+
+	return cfg, nil
+
+}
+
+// Download and parse a settings map from a remote HTTPS URL
+func fetchRemoteSettings(configUrl string, timeout time.Duration) (map[string]map[string]string, error) {
+
+	parsedUrl, err := url.Parse(configUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config URL [%s]: %w", configUrl, err)
+	}
+	if parsedUrl.Scheme != "https" {
+		return nil, fmt.Errorf("config URL [%s] must use https", configUrl)
+	}
+
+	client := http.Client{
+		Timeout: timeout,
+	}
+	response, err := client.Get(configUrl)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch remote settings: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch remote settings: server returned %s", response.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, remoteConfigMaxSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not read remote settings: %w", err)
+	}
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(body, &settings); err != nil {
+		return nil, fmt.Errorf("could not parse remote settings: %w", err)
+	}
+	if len(settings) == 0 {
+		return nil, fmt.Errorf("remote settings are empty")
+	}
+	return settings, nil
+
+}
+
+// Merge two settings maps, with the values in overrides taking precedence
+func mergeSettings(base map[string]map[string]string, overrides map[string]map[string]string) map[string]map[string]string {
+	merged := map[string]map[string]string{}
+	for _, settings := range []map[string]map[string]string{base, overrides} {
+		for section, params := range settings {
+			if merged[section] == nil {
+				merged[section] = map[string]string{}
+			}
+			for name, value := range params {
+				merged[section][name] = value
+			}
+		}
+	}
+	return merged
+}
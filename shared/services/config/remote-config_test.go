@@ -0,0 +1,77 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMergeSettings(t *testing.T) {
+	base := map[string]map[string]string{
+		"root":      {"network": "prater", "isNative": "false"},
+		"smartnode": {"priceSources": "1inch"},
+	}
+	overrides := map[string]map[string]string{
+		"root":       {"network": "mainnet"},
+		"watchtower": {"leaderLeaseTime": "30"},
+	}
+	want := map[string]map[string]string{
+		"root":       {"network": "mainnet", "isNative": "false"},
+		"smartnode":  {"priceSources": "1inch"},
+		"watchtower": {"leaderLeaseTime": "30"},
+	}
+	if got := mergeSettings(base, overrides); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeSettings() = %v, want %v", got, want)
+	}
+
+	// The inputs aren't modified
+	if base["root"]["network"] != "prater" {
+		t.Error("mergeSettings() modified the base settings")
+	}
+}
+
+func TestFetchRemoteSettings(t *testing.T) {
+	responses := map[string]struct {
+		status int
+		body   string
+	}{
+		"/valid":   {http.StatusOK, "root:\n  network: mainnet\n"},
+		"/missing": {http.StatusNotFound, ""},
+		"/invalid": {http.StatusOK, "not: [valid"},
+		"/empty":   {http.StatusOK, ""},
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := responses[r.URL.Path]
+		w.WriteHeader(response.status)
+		w.Write([]byte(response.body))
+	}))
+	defer server.Close()
+
+	// Trust the test server's certificate
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	defer func() {
+		http.DefaultTransport = defaultTransport
+	}()
+
+	settings, err := fetchRemoteSettings(server.URL+"/valid", time.Second)
+	if err != nil {
+		t.Fatalf("fetchRemoteSettings() returned an error: %s", err)
+	}
+	if settings["root"]["network"] != "mainnet" {
+		t.Errorf("fetchRemoteSettings() = %v, want the network set to mainnet", settings)
+	}
+
+	for _, path := range []string{"/missing", "/invalid", "/empty"} {
+		if _, err := fetchRemoteSettings(server.URL+path, time.Second); err == nil {
+			t.Errorf("fetchRemoteSettings() didn't return an error for %s", path)
+		}
+	}
+
+	// Only HTTPS URLs are allowed
+	if _, err := fetchRemoteSettings("http://example.com/config.yml", time.Second); err == nil {
+		t.Error("fetchRemoteSettings() didn't return an error for an http URL")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/alessio/shellescape"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pbnjay/memory"
 	"github.com/rocket-pool/smartnode/addons"
 	"github.com/rocket-pool/smartnode/shared"
@@ -1074,6 +1075,22 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		errors = append(errors, "You are using an externally-managed Execution client and a locally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.")
 	}
 
+	// Ensure the RocketStorage override is an address
+	storageOverride := cfg.Smartnode.RocketStorageAddressOverride.Value.(string)
+	if storageOverride != "" && !common.IsHexAddress(storageOverride) {
+		errors = append(errors, fmt.Sprintf("The RocketStorage address override [%s] is not a valid address.", storageOverride))
+	}
+	priceQuoteToken := cfg.Smartnode.PriceQuoteToken.Value.(string)
+	if priceQuoteToken != "" && !common.IsHexAddress(priceQuoteToken) {
+		errors = append(errors, fmt.Sprintf("The price quote token [%s] is not a valid address.", priceQuoteToken))
+	}
+
+	// Ensure the challenge response fraction is within the window
+	earliestFraction := cfg.Smartnode.ChallengeResponseEarliestFraction.Value.(float64)
+	if earliestFraction < 0 || earliestFraction > 1 {
+		errors = append(errors, fmt.Sprintf("The challenge response earliest fraction [%f] must be between 0 and 1.", earliestFraction))
+	}
+
 	// Ensure there's a MEV-boost URL
 	if !cfg.IsNativeMode && cfg.EnableMevBoost.Value == true {
 		switch cfg.MevBoost.Mode.Value.(config.Mode) {
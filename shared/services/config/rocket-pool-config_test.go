@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRocketStorageAddressOverride(t *testing.T) {
+	cfg := NewRocketPoolConfig("", false)
+	defaultAddress := cfg.Smartnode.GetStorageAddress()
+	override := "0x1111111111111111111111111111111111111111"
+
+	cfg.Smartnode.RocketStorageAddressOverride.Value = override
+	if got := cfg.Smartnode.GetStorageAddress(); got != override {
+		t.Errorf("GetStorageAddress() = %s, want the override %s", got, override)
+	}
+	cfg.Smartnode.RocketStorageAddressOverride.Value = ""
+	if got := cfg.Smartnode.GetStorageAddress(); got != defaultAddress {
+		t.Errorf("GetStorageAddress() = %s, want the network's deployment %s", got, defaultAddress)
+	}
+}
+
+func TestValidateRocketStorageAddressOverride(t *testing.T) {
+	tests := []struct {
+		name     string
+		override string
+		wantErr  bool
+	}{
+		{"no override", "", false},
+		{"valid address", "0x1111111111111111111111111111111111111111", false},
+		{"invalid address", "0x1234", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := NewRocketPoolConfig("", false)
+			cfg.Smartnode.RocketStorageAddressOverride.Value = test.override
+			gotErr := false
+			for _, err := range cfg.Validate() {
+				if strings.Contains(err, "RocketStorage address override") {
+					gotErr = true
+				}
+			}
+			if gotErr != test.wantErr {
+				t.Errorf("Validate() reported an override error: %t, want %t", gotErr, test.wantErr)
+			}
+		})
+	}
+}
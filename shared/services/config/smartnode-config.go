@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -25,6 +27,9 @@ const (
 	DaemonDataPath                     string = "/.rocketpool/data"
 	WatchtowerFolder                   string = "watchtower"
 	WatchtowerStateFile                string = "state.yml"
+	WatchtowerAlertStateFile           string = "alerts.yml"
+	WatchtowerBacktestStateFile        string = "backtest.yml"
+	WatchtowerAuditLogFile             string = "audit.jsonl"
 	RegenerateRewardsTreeRequestSuffix string = ".request"
 	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
 	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
@@ -51,10 +56,10 @@ type SmartnodeConfig struct {
 	ProjectName config.Parameter `yaml:"projectName,omitempty"`
 
 	// The path of the data folder where everything is stored
-	DataPath config.Parameter `yaml:"dataPath,omitempty"`
+	DataPath config.Parameter `yaml:"dataPath,omitempty" sensitive:"path"`
 
 	// The path of the watchtower's persistent state storage
-	WatchtowerStatePath config.Parameter `yaml:"watchtowerStatePath"`
+	WatchtowerStatePath config.Parameter `yaml:"watchtowerStatePath" sensitive:"path"`
 
 	// Which network we're on
 	Network config.Parameter `yaml:"network,omitempty"`
@@ -72,10 +77,214 @@ type SmartnodeConfig struct {
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
 	// URL for an EC with archive mode, for manual rewards tree generation
-	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
+	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty" sensitive:"url"`
 
 	// Token for Oracle DAO members to use when uploading Merkle trees to Web3.Storage
-	Web3StorageApiToken config.Parameter `yaml:"web3StorageApiToken,omitempty"`
+	Web3StorageApiToken config.Parameter `yaml:"web3StorageApiToken,omitempty" sensitive:"secret"`
+
+	// URL for an EC Websocket endpoint the watchtower can subscribe to for new blocks
+	WatchtowerWsUrl config.Parameter `yaml:"watchtowerWsUrl,omitempty" sensitive:"url"`
+
+	// The max number of deposit assignment transactions the watchtower can submit per loop
+	MaxDepositAssignmentTxs config.Parameter `yaml:"maxDepositAssignmentTxs,omitempty"`
+
+	// Webhook the watchtower sends alerts to
+	AlertWebhookUrl config.Parameter `yaml:"alertWebhookUrl,omitempty" sensitive:"secret"`
+
+	// The Beacon state the watchtower reads validator details from
+	BeaconStateId config.Parameter `yaml:"beaconStateId,omitempty" hotswap:"true"`
+
+	// The number of minutes to wait before repeating an alert
+	AlertCooldown config.Parameter `yaml:"alertCooldown,omitempty"`
+
+	// The number of minutes between reminders for an ongoing alert
+	AlertReminderInterval config.Parameter `yaml:"alertReminderInterval,omitempty"`
+
+	// The number of blocks before the next checkpoint after which price and balance submissions are abandoned
+	SubmitDeadlineBlocks config.Parameter `yaml:"submitDeadlineBlocks,omitempty" hotswap:"true"`
+
+	// The lease time, in seconds, for leader election between redundant watchtowers
+	LeaderLeaseTime config.Parameter `yaml:"leaderLeaseTime,omitempty"`
+
+	// The price sources the watchtower uses to determine the RPL price
+	PriceSources config.Parameter `yaml:"priceSources,omitempty" hotswap:"true"`
+
+	// The number of seconds the watchtower waits after starting before it submits any transactions
+	StartupWarmupSeconds config.Parameter `yaml:"startupWarmupSeconds,omitempty"`
+
+	// Extra HTTP headers to send with every request to the Execution client
+	EcHeaders config.Parameter `yaml:"ecHeaders,omitempty" sensitive:"secret"`
+
+	// Extra HTTP headers to send with every request to the Beacon client
+	BcHeaders config.Parameter `yaml:"bcHeaders,omitempty" sensitive:"secret"`
+
+	// Whether to fetch the latest scores of each active Snapshot proposal when checking quorum
+	RefreshSnapshotScores config.Parameter `yaml:"refreshSnapshotScores,omitempty"`
+
+	// The minimum value of unclaimed rewards to automatically claim
+	AutoClaimThreshold config.Parameter `yaml:"autoClaimThreshold,omitempty"`
+
+	// Whether to restake the RPL from automatic claims
+	AutoClaimRestake config.Parameter `yaml:"autoClaimRestake,omitempty"`
+
+	// The max fee threshold for automatic claims
+	AutoClaimGasThreshold config.Parameter `yaml:"autoClaimGasThreshold,omitempty"`
+
+	// How long to wait after consensus is reached before evaluating the next checkpoint
+	ConsensusCooldownSeconds config.Parameter `yaml:"consensusCooldownSeconds,omitempty"`
+
+	// Where the watchtower gets the max fee for its RPL price submissions
+	PriceGasSource config.Parameter `yaml:"priceGasSource,omitempty"`
+
+	// Whether to gzip rewards tree files when saving them
+	CompressRewardTrees config.Parameter `yaml:"compressRewardTrees,omitempty"`
+
+	// Whether to attach a precomputed access list to price submissions
+	UseAccessList config.Parameter `yaml:"useAccessList,omitempty" hotswap:"true"`
+
+	// The maximum age of a timestamped oracle price before it is excluded
+	MaxStalenessSeconds config.Parameter `yaml:"maxStalenessSeconds,omitempty" hotswap:"true"`
+
+	// Whether safe mode stays engaged until it is manually reset
+	SafeModeManualReset config.Parameter `yaml:"safeModeManualReset,omitempty"`
+
+	// The largest spread between price sources before safe mode engages, in percent
+	SafeModeMaxSourceDeviation config.Parameter `yaml:"safeModeMaxSourceDeviation,omitempty" hotswap:"true"`
+
+	// The collateral ratio the node daemon tops the RPL stake up to, in percent
+	AutoStakeTargetCollateral config.Parameter `yaml:"autoStakeTargetCollateral,omitempty"`
+
+	// The smallest amount of RPL the node daemon will automatically stake
+	AutoStakeMinAmount config.Parameter `yaml:"autoStakeMinAmount,omitempty"`
+
+	// Threshold for automatic RPL staking
+	AutoStakeGasThreshold config.Parameter `yaml:"autoStakeGasThreshold,omitempty"`
+
+	// The number of blocks behind the head the watchtower reports from when the finalized block is unavailable
+	ConfirmationDepth config.Parameter `yaml:"confirmationDepth,omitempty" hotswap:"true"`
+
+	// The smallest ETH balance the node account needs before the watchtower submits prices or balances
+	MinAccountBalanceEth config.Parameter `yaml:"minAccountBalanceEth,omitempty"`
+
+	// The gas limit to use for price submissions instead of the estimated one
+	SubmitGasLimit config.Parameter `yaml:"submitGasLimit,omitempty" hotswap:"true"`
+
+	// The Uniswap V3 RPL / WETH pool used by the uniswap price source
+	UniswapPoolAddress config.Parameter `yaml:"uniswapPoolAddress,omitempty" hotswap:"true"`
+
+	// The minimum in-range liquidity a DEX pool needs for its price to be used
+	MinLiquidity config.Parameter `yaml:"minLiquidity,omitempty" hotswap:"true"`
+
+	// The window, in seconds, the uniswap price source averages the pool price and liquidity over
+	UniswapTwapSeconds config.Parameter `yaml:"uniswapTwapSeconds,omitempty" hotswap:"true"`
+
+	// Whether to retry a price submission once with a fresh nonce if it fails because the nonce was too low
+	RetryNonceTooLow config.Parameter `yaml:"retryNonceTooLow,omitempty" hotswap:"true"`
+
+	// Whether to skip submitting a price that's exactly the same as the one on-chain
+	SkipIdenticalPrice config.Parameter `yaml:"skipIdenticalPrice,omitempty" hotswap:"true"`
+
+	// The port to serve the Go pprof debug endpoints on; 0 disables them
+	PprofPort config.Parameter `yaml:"pprofPort,omitempty"`
+
+	// The address to bind the pprof debug endpoints to
+	PprofAddress config.Parameter `yaml:"pprofAddress,omitempty"`
+
+	// A prioritized list of 1inch oracle contracts to query for the RPL price
+	OneInchOracleAddresses config.Parameter `yaml:"oneInchOracleAddresses,omitempty" hotswap:"true"`
+
+	// The file the daemons write their logs to, in addition to or instead of the console
+	LogFile config.Parameter `yaml:"logFile,omitempty" sensitive:"path"`
+
+	// The size a log file is rotated at
+	LogMaxSizeMb config.Parameter `yaml:"logMaxSizeMb,omitempty"`
+
+	// Whether to keep logging to the console when a log file is set
+	LogToConsole config.Parameter `yaml:"logToConsole,omitempty"`
+
+	// The largest difference between the local clock and the latest block time before safe mode engages
+	MaxClockSkewSeconds config.Parameter `yaml:"maxClockSkewSeconds,omitempty" hotswap:"true"`
+
+	// The lowest RPL price an oracle can return before the watchtower ignores it
+	MinOracleRate config.Parameter `yaml:"minOracleRate,omitempty" hotswap:"true"`
+
+	// The highest RPL price an oracle can return before the watchtower ignores it
+	MaxOracleRate config.Parameter `yaml:"maxOracleRate,omitempty" hotswap:"true"`
+
+	// The address of a price feed contract for the contract price source
+	PriceFeedAddress config.Parameter `yaml:"priceFeedAddress,omitempty" hotswap:"true"`
+
+	// The method used to read the price feed contract
+	PriceFeedMethod config.Parameter `yaml:"priceFeedMethod,omitempty" hotswap:"true"`
+
+	// The number of decimal places in the price feed's answer
+	PriceFeedDecimals config.Parameter `yaml:"priceFeedDecimals,omitempty" hotswap:"true"`
+
+	// How far back the watchtower backfills missed price checkpoints on startup
+	MaxBackfillBlocks config.Parameter `yaml:"maxBackfillBlocks,omitempty"`
+
+	// An address to use for RocketStorage instead of the network's deployment
+	RocketStorageAddressOverride config.Parameter `yaml:"rocketStorageAddressOverride,omitempty"`
+
+	// The node account balance below which the watchtower asks for it to be topped up
+	FundingThresholdEth config.Parameter `yaml:"fundingThresholdEth,omitempty"`
+
+	// The balance a funding request tops the node account up to
+	FundingTargetEth config.Parameter `yaml:"fundingTargetEth,omitempty"`
+
+	// Webhook the watchtower sends funding requests to
+	FundingWebhookUrl config.Parameter `yaml:"fundingWebhookUrl,omitempty" sensitive:"secret"`
+
+	// How the RPL price is chosen when there's an even number of price sources
+	MedianTieBreak config.Parameter `yaml:"medianTieBreak,omitempty" hotswap:"true"`
+
+	// Whether the watchtower computes and reports prices and balances when the node isn't an Oracle DAO member
+	ReadOnlyMode config.Parameter `yaml:"readOnlyMode,omitempty" hotswap:"true"`
+
+	// The number of minipools the watchtower loads details for in a single multicall
+	MinipoolBatchSize config.Parameter `yaml:"minipoolBatchSize,omitempty" hotswap:"true"`
+
+	// The largest spread between price sources a price is submitted with, in percent
+	MaxSourceSpreadPct config.Parameter `yaml:"maxSourceSpreadPct,omitempty" hotswap:"true"`
+
+	// How long each price source has to return a price before it's excluded, in seconds
+	OracleSourceTimeout config.Parameter `yaml:"oracleSourceTimeout,omitempty" hotswap:"true"`
+
+	// The most withdrawn minipools the node daemon finalises in one run
+	AutoFinaliseMaxPerRun config.Parameter `yaml:"autoFinaliseMaxPerRun,omitempty"`
+
+	// Threshold for automatic minipool finalising
+	AutoFinaliseGasThreshold config.Parameter `yaml:"autoFinaliseGasThreshold,omitempty"`
+
+	// The number of times a Beacon client request is attempted when it gets a retryable response
+	BcRetryAttempts config.Parameter `yaml:"bcRetryAttempts,omitempty"`
+
+	// How long to wait before the first retry of a Beacon client request, in milliseconds
+	BcRetryBackoffMs config.Parameter `yaml:"bcRetryBackoffMs,omitempty"`
+
+	// The HTTP status codes from the Beacon client that are retried
+	BcRetryStatusCodes config.Parameter `yaml:"bcRetryStatusCodes,omitempty"`
+
+	// The oldest the reportable block can be before submissions are refused, in seconds
+	MaxReportableBlockAge config.Parameter `yaml:"maxReportableBlockAge,omitempty" hotswap:"true"`
+
+	// The token the 1inch oracle quotes the RPL price in, or blank for ETH
+	PriceQuoteToken config.Parameter `yaml:"priceQuoteToken,omitempty" hotswap:"true"`
+
+	// The fewest price sources that have to return a usable price for the RPL price to be submitted
+	MinSuccessfulSources config.Parameter `yaml:"minSuccessfulSources,omitempty" hotswap:"true"`
+
+	// How long audit records and alert history are kept, in days
+	StateRetentionDays config.Parameter `yaml:"stateRetentionDays,omitempty" hotswap:"true"`
+
+	// How far into a challenge's window the watchtower waits before responding, as a fraction
+	ChallengeResponseEarliestFraction config.Parameter `yaml:"challengeResponseEarliestFraction,omitempty" hotswap:"true"`
+
+	// How long before a challenge's deadline the watchtower responds regardless of the earliest fraction, in seconds
+	ChallengeResponseLatestMargin config.Parameter `yaml:"challengeResponseLatestMargin,omitempty" hotswap:"true"`
+
+	// The number of significant figures the submitted RPL price is truncated to, or 0 to submit it in full
+	PriceSigFigs config.Parameter `yaml:"priceSigFigs,omitempty" hotswap:"true"`
 
 	///////////////////////////
 	// Non-editable settings //
@@ -96,6 +305,12 @@ type SmartnodeConfig struct {
 	// The contract address of the 1inch oracle
 	oneInchOracleAddress map[config.Network]string `yaml:"-"`
 
+	// The contract address of WETH
+	wethAddress map[config.Network]string `yaml:"-"`
+
+	// The contract address of Multicall3
+	multicallAddress map[config.Network]string `yaml:"-"`
+
 	// The contract address of the RPL token
 	rplTokenAddress map[config.Network]string `yaml:"-"`
 
@@ -114,155 +329,961 @@ type SmartnodeConfig struct {
 	// The contract address of rocketRewardsPool from v1.0.0
 	legacyRewardsPoolAddress map[config.Network]string `yaml:"-"`
 
-	// The contract address of rocketClaimNode from v1.0.0
-	legacyClaimNodeAddress map[config.Network]string `yaml:"-"`
+	// The contract address of rocketClaimNode from v1.0.0
+	legacyClaimNodeAddress map[config.Network]string `yaml:"-"`
+
+	// The contract address of rocketClaimTrustedNode from v1.0.0
+	legacyClaimTrustedNodeAddress map[config.Network]string `yaml:"-"`
+
+	// The contract address of rocketMinipoolManager from v1.0.0
+	legacyMinipoolManagerAddress map[config.Network]string `yaml:"-"`
+
+	// Addresses for RocketRewardsPool that have been upgraded during development
+	previousRewardsPoolAddresses map[config.Network]map[string][]common.Address `yaml:"-"`
+
+	// The RocketOvmPriceMessenger address for each network
+	optimismPriceMessengerAddress map[config.Network]string `yaml:"-"`
+
+	// The contract address of the Chainlink RPL / USD feed
+	chainlinkRplUsdFeedAddress map[config.Network]string `yaml:"-"`
+
+	// The contract address of the Chainlink ETH / USD feed
+	chainlinkEthUsdFeedAddress map[config.Network]string `yaml:"-"`
+
+	// Rewards submission block maps
+	rewardsSubmissionBlockMaps map[config.Network][]uint64 `yaml:"-"`
+}
+
+// Generates a new Smartnode configuration
+func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
+
+	return &SmartnodeConfig{
+		Title:  "Smartnode Settings",
+		parent: cfg,
+
+		ProjectName: config.Parameter{
+			ID:                   ProjectNameID,
+			Name:                 "Project Name",
+			Description:          "This is the prefix that will be attached to all of the Docker containers managed by the Smartnode.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultProjectName},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Eth1, config.ContainerID_Eth2, config.ContainerID_Validator, config.ContainerID_Grafana, config.ContainerID_Prometheus, config.ContainerID_Exporter},
+			EnvironmentVariables: []string{"COMPOSE_PROJECT_NAME"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DataPath: config.Parameter{
+			ID:                   "dataPath",
+			Name:                 "Data Path",
+			Description:          "The absolute path of the `data` folder that contains your node wallet's encrypted file, the password for your node wallet, and all of the validator keys for your minipools. You may use environment variables in this string.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: getDefaultDataDir(cfg)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"ROCKETPOOL_DATA_FOLDER"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerStatePath: config.Parameter{
+			ID:                   "watchtowerPath",
+			Name:                 "Watchtower Path",
+			Description:          "The absolute path of the watchtower state folder that contains persistent state that is used by the watchtower process on trusted nodes. **Only relevant for trusted nodes.**",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "$HOME/.rocketpool/watchtower"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{"ROCKETPOOL_WATCHTOWER_FOLDER"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Network: config.Parameter{
+			ID:                   NetworkID,
+			Name:                 "Network",
+			Description:          "The Ethereum network you want to use - select Prater Testnet to practice with fake ETH, or Mainnet to stake on the real network using real ETH.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.Network_Mainnet},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Eth1, config.ContainerID_Eth2, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"NETWORK"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options:              getNetworkOptions(),
+		},
+
+		ManualMaxFee: config.Parameter{
+			ID:                   "manualMaxFee",
+			Name:                 "Manual Max Fee",
+			Description:          "Set this if you want all of the Smartnode's transactions to use this specific max fee value (in gwei), which is the most you'd be willing to pay (*including the priority fee*).\n\nA value of 0 will show you the current suggested max fee based on the current network conditions and let you specify it each time you do a transaction.\n\nAny other value will ignore the recommended max fee and explicitly use this value instead.\n\nThis applies to automated transactions (such as claiming RPL and staking minipools) as well.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PriorityFee: config.Parameter{
+			ID:                   "priorityFee",
+			Name:                 "Priority Fee",
+			Description:          "The default value for the priority fee (in gwei) for all of your transactions. This describes how much you're willing to pay *above the network's current base fee* - the higher this is, the more ETH you give to the validators for including your transaction, which generally means it will be included in a block faster (as long as your max fee is sufficiently high to cover the current network conditions).\n\nMust be larger than 0.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(2)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MinipoolStakeGasThreshold: config.Parameter{
+			ID:   "minipoolStakeGasThreshold",
+			Name: "Minipool Stake Gas Threshold",
+			Description: "Once a newly created minipool passes the scrub check and is ready to perform its second 16 ETH deposit (the `stake` transaction), your node will try to do so automatically using the `Rapid` suggestion from the gas estimator as its max fee. This threshold is a limit (in gwei) you can put on that suggestion; your node will not `stake` the new minipool until the suggestion is below this limit.\n\n" +
+				"Note that to ensure your minipool does not get dissolved, the node will ignore this limit and automatically execute the `stake` transaction at whatever the suggested fee happens to be once too much time has passed since its first deposit (currently 7 days).",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(150)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeMode: config.Parameter{
+			ID:                   "rewardsTreeMode",
+			Name:                 "Rewards Tree Mode",
+			Description:          "Select how you want to acquire the Merkle Tree files for each rewards interval.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsMode_Download},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Download",
+				Description: "Automatically download the Merkle Tree rewards files that were published by the Oracle DAO after a rewards checkpoint.",
+				Value:       config.RewardsMode_Download,
+			}, {
+				Name:        "Generate",
+				Description: "Use your node to automatically generate the Merkle Tree rewards file once a checkpoint has passed. This option lets you build and verify the file that the Oracle DAO created if you prefer not to trust it and want to generate the tree yourself.\n\n[orange]WARNING: Generating the tree can take a *very long time* if many node operators are opted into the Smoothing Pool, which could impact your attestation performance!",
+				Value:       config.RewardsMode_Generate,
+			}},
+		},
+
+		ArchiveECUrl: config.Parameter{
+			ID:                   "archiveECUrl",
+			Name:                 "Archive-Mode EC URL",
+			Description:          "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Web3StorageApiToken: config.Parameter{
+			ID:                   "web3StorageApiToken",
+			Name:                 "Web3.Storage API Token",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The API token for your https://web3.storage/ account. This is required in order for you to upload Merkle rewards trees to Web3.Storage at each rewards interval.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerWsUrl: config.Parameter{
+			ID:                   "watchtowerWsUrl",
+			Name:                 "Watchtower Websocket URL",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The URL of a Websocket RPC endpoint for your Execution client. If set, the watchtower will subscribe to new blocks and check for price and balance checkpoints on each one instead of polling on a fixed timer; the rest of its tasks still run every few minutes. It falls back to polling if the subscription drops.\n\nLeave this blank to always poll.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MaxDepositAssignmentTxs: config.Parameter{
+			ID:                   "maxDepositAssignmentTxs",
+			Name:                 "Max Deposit Assignment TXs",
+			Description:          "The maximum number of `assignDeposits` transactions the watchtower will submit each time it checks the deposit pool. When there are minipools waiting in the queue and enough ETH in the deposit pool to service them, your node will assign the deposits itself, paying the gas cost.\n\nThis does not require your node to be an Oracle DAO member.\n\nSet this to 0 to disable automatic deposit assignment.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AlertWebhookUrl: config.Parameter{
+			ID:                   "alertWebhookUrl",
+			Name:                 "Alert Webhook URL",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The URL of a webhook the watchtower should send alerts to when something significant happens that needs your attention, such as your node losing its Oracle DAO membership. Alerts are sent as an HTTP POST with a JSON body; the `text` field is compatible with Slack-style incoming webhooks.\n\nLeave this blank to only write alerts to the watchtower log.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BeaconStateId: config.Parameter{
+			ID:                   "beaconStateId",
+			Name:                 "Beacon State for Checks",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Select which Beacon Chain state the watchtower should read validator details from when performing checks such as the scrub check.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: "finalized"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Finalized",
+				Description: "Use the latest finalized state. This lags behind the chain head by a few epochs, but can't be reorged, which prevents false positives.",
+				Value:       "finalized",
+			}, {
+				Name:        "Head",
+				Description: "Use the state at the head of the chain. This is the most up-to-date, but it may contain ephemeral data that is later reorged out.",
+				Value:       "head",
+			}},
+		},
+
+		AlertCooldown: config.Parameter{
+			ID:                   "alertCooldown",
+			Name:                 "Alert Cooldown",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of minutes the watchtower will wait before sending another notification for the same alert. Repeats of an alert inside this window are suppressed so a prolonged outage doesn't flood your webhook.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(15)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AlertReminderInterval: config.Parameter{
+			ID:                   "alertReminderInterval",
+			Name:                 "Alert Reminder Interval",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of minutes between \"still failing\" reminders while a problem the watchtower has already alerted you about is ongoing. Each reminder includes how many repeats were suppressed since the last notification.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(240)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SubmitDeadlineBlocks: config.Parameter{
+			ID:                   "submitDeadlineBlocks",
+			Name:                 "Submission Deadline Blocks",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]If the watchtower hasn't submitted prices or balances for a checkpoint by this many blocks before the next checkpoint, it will abandon the old checkpoint instead of submitting values that are about to be superseded.\n\nSet this to 0 to always submit regardless of how close the next checkpoint is.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LeaderLeaseTime: config.Parameter{
+			ID:                   "leaderLeaseTime",
+			Name:                 "Leader Lease Time",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]If you run more than one watchtower with the same node wallet for redundancy, they can elect a leader so only one of them submits transactions while the others stay in sync and wait to take over. The leader holds a lease in the watchtower's state folder and renews it every third of this time; if it stops renewing for this many seconds, one of the standby watchtowers will take over.\n\nThe state folder's lock is only safe between watchtowers on the same machine, so this doesn't provide failover across hosts (even with the folder on a network mount). Set this to 0 to disable leader election.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PriceSources: config.Parameter{
+			ID:                   "priceSources",
+			Name:                 "RPL Price Sources",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of the sources the watchtower should query for the RPL / ETH price, such as `1inch,chainlink`. When more than one source is enabled, the watchtower submits the median of the prices they report.\n\nSupported sources: 1inch, chainlink, uniswap, contract.\n\nLeave this blank to only use the 1inch oracle.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "1inch"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		StartupWarmupSeconds: config.Parameter{
+			ID:                   "startupWarmupSeconds",
+			Name:                 "Startup Warmup Time",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of seconds the watchtower will wait after it starts before it submits any transactions. Read-only checks still run during this time. This gives your clients a chance to settle after a restart, since they can report that they're synced while still catching up.\n\nSet this to 0 to start submitting immediately.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EcHeaders: config.Parameter{
+			ID:                   "ecHeaders",
+			Name:                 "Execution Client Headers",
+			Description:          "Extra HTTP headers the Smartnode should send with every request to your Execution client, such as an authorization header required by an RPC provider.\n\nEnter them as a semicolon-separated list of `Name: Value` pairs, for example `Authorization: Bearer abc123; X-Api-Key: def456`.\n\nLeave this blank if your client doesn't need any extra headers.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BcHeaders: config.Parameter{
+			ID:                   "bcHeaders",
+			Name:                 "Beacon Client Headers",
+			Description:          "Extra HTTP headers the Smartnode should send with every request to your Beacon client, such as an authorization header required by an RPC provider.\n\nEnter them as a semicolon-separated list of `Name: Value` pairs, for example `Authorization: Bearer abc123`.\n\nLeave this blank if your client doesn't need any extra headers.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RefreshSnapshotScores: config.Parameter{
+			ID:                   "refreshSnapshotScores",
+			Name:                 "Refresh Snapshot Scores",
+			Description:          "Enable this to have the Smartnode request the latest scores for each active governance proposal from Snapshot when showing whether it has reached quorum, instead of using the scores included in the proposal list.\n\nThis makes one extra request to Snapshot per active proposal.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimThreshold: config.Parameter{
+			ID:                   "autoClaimThreshold",
+			Name:                 "Auto-Claim Threshold",
+			Description:          "Your node can automatically claim its RPL and Smoothing Pool ETH rewards once the total value of its unclaimed rewards (in ETH, with RPL valued at the current network price) reaches this amount. Setting this too low will waste gas on small claims.\n\nSet this to 0 to disable automatic claiming.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimRestake: config.Parameter{
+			ID:                   "autoClaimRestake",
+			Name:                 "Restake Auto-Claimed RPL",
+			Description:          "Enable this to have your node restake all of the RPL it claims automatically, instead of sending it to your withdrawal address.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimGasThreshold: config.Parameter{
+			ID:                   "autoClaimGasThreshold",
+			Name:                 "Auto-Claim Gas Threshold",
+			Description:          "Your node will use the `Rapid` suggestion from the gas estimator as the max fee when it automatically claims rewards. This threshold is a limit (in gwei) you can put on that suggestion; your node will wait to claim until the suggestion is below this limit.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(50)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ConsensusCooldownSeconds: config.Parameter{
+			ID:                   "consensusCooldownSeconds",
+			Name:                 "Consensus Cooldown Time",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of seconds the watchtower will wait after it sees the Oracle DAO reach consensus on prices or balances before it evaluates the next checkpoint. This gives your clients' view of the chain a chance to settle and reduces redundant submissions.\n\nSet this to 0 to evaluate the next checkpoint immediately.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PriceGasSource: config.Parameter{
+			ID:                   "priceGasSource",
+			Name:                 "Price Submission Gas Source",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Select where the watchtower should get the max fee for its RPL price submissions. If the selected source can't provide a fee, the fixed max fee is used instead.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.GasPriceSource_Fixed},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Fixed",
+				Description: "Use the watchtower's fixed max fee of 200 gwei.",
+				Value:       config.GasPriceSource_Fixed,
+			}, {
+				Name:        "Execution Client",
+				Description: "Use the gas price suggested by your Execution client.",
+				Value:       config.GasPriceSource_ExecutionClient,
+			}, {
+				Name:        "Gas Station",
+				Description: "Use the `Rapid` suggestion from Etherchain, falling back to Etherscan.",
+				Value:       config.GasPriceSource_GasStation,
+			}},
+		},
+
+		CompressRewardTrees: config.Parameter{
+			ID:                   "compressRewardTrees",
+			Name:                 "Compress Rewards Trees",
+			Description:          "Enable this to compress the rewards tree and minipool performance files your node saves with gzip, which saves disk space if you keep the files for past intervals.\n\nFiles are read correctly whether or not they're compressed, so you can change this at any time.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UseAccessList: config.Parameter{
+			ID:                   "useAccessList",
+			Name:                 "Use Access List",
+			Description:          "Enable this to build RPL price submissions with an access list from `eth_createAccessList`, which can reduce the gas spent on storage access. If your Execution client doesn't support that method, the normal transaction is sent instead.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MaxStalenessSeconds: config.Parameter{
+			ID:                   "maxStalenessSeconds",
+			Name:                 "Max Price Staleness",
+			Description:          "The maximum age, in seconds, of a price from an oracle that reports when it was last updated (such as Chainlink) relative to the block being priced. Older prices are excluded from the aggregate. The default covers the 24 hour heartbeat of the Chainlink RPL / USD feed. Set this to 0 to disable the check.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(90000)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SafeModeManualReset: config.Parameter{
+			ID:                   "safeModeManualReset",
+			Name:                 "Safe Mode Manual Reset",
+			Description:          "The watchtower stops submitting transactions while something looks wrong (an unsynced Execution client, a chain ID mismatch, or price sources that are unavailable or disagree). By default it resumes once the conditions clear; enable this to keep it stopped until you run `rocketpool watchtower reset-safe-mode`.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SafeModeMaxSourceDeviation: config.Parameter{
+			ID:                   "safeModeMaxSourceDeviation",
+			Name:                 "Safe Mode Price Source Deviation",
+			Description:          "If more than one price source is configured and their RPL prices differ by more than this percentage, the watchtower engages safe mode and stops submitting transactions. Set this to 0 to disable the check.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(10)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoStakeTargetCollateral: config.Parameter{
+			ID:                   "autoStakeTargetCollateral",
+			Name:                 "Auto-Stake Target Collateral",
+			Description:          "The node daemon will automatically stake RPL from the node wallet whenever the value of the node's RPL stake falls below this percentage of the ETH borrowed by its active minipools, bringing it back up to this target. Set this to 0 to disable automatic staking.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoStakeMinAmount: config.Parameter{
+			ID:                   "autoStakeMinAmount",
+			Name:                 "Auto-Stake Minimum Amount",
+			Description:          "The node daemon won't automatically stake less than this amount of RPL, so small price movements around the target don't cause a stream of tiny transactions.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(10)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoStakeGasThreshold: config.Parameter{
+			ID:                   "autoStakeGasThreshold",
+			Name:                 "Auto-Stake Gas Threshold",
+			Description:          "Your node will use the `Rapid` suggestion from the gas estimator as the max fee when it automatically stakes RPL. This threshold is a limit (in gwei) you can put on that suggestion; your node will wait to stake until the suggestion is below this limit.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(50)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ConfirmationDepth: config.Parameter{
+			ID:                   "confirmationDepth",
+			Name:                 "Confirmation Depth",
+			Description:          "The watchtower picks the block to report prices and balances for from the latest finalized block so a reorg can't remove it. If your Execution client doesn't report finality, it uses the block this many blocks behind the latest one instead.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(32)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MinAccountBalanceEth: config.Parameter{
+			ID:                   "minAccountBalanceEth",
+			Name:                 "Minimum Account Balance",
+			Description:          "If the node account's ETH balance falls below this amount, the watchtower will alert you and skip price and balance submissions instead of sending transactions that would fail. Set this to 0 to disable the check.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0.5)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SubmitGasLimit: config.Parameter{
+			ID:                   "submitGasLimit",
+			Name:                 "Submission Gas Limit",
+			Description:          "Use this gas limit for RPL price submissions instead of the automatically estimated one, in case the estimate is too low and submissions run out of gas. It will never be set below the estimated gas usage. Use 0 to rely on the estimate.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UniswapPoolAddress: config.Parameter{
+			ID:                   "uniswapPoolAddress",
+			Name:                 "Uniswap Pool Address",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The address of the Uniswap V3 RPL / WETH pool to read the RPL price from when the `uniswap` price source is enabled.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MinLiquidity: config.Parameter{
+			ID:                   "minLiquidity",
+			Name:                 "Minimum DEX Liquidity",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The minimum in-range liquidity, in ETH, the Uniswap pool must have had on average over the TWAP window for its RPL price to be used. The price from a pool with less liquidity than this is easy to manipulate, so it's left out of the median. Use 0 to disable this check.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(100)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UniswapTwapSeconds: config.Parameter{
+			ID:                   "uniswapTwapSeconds",
+			Name:                 "Uniswap TWAP Window",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of seconds the `uniswap` price source averages the pool's price over, using the pool's time-weighted average price (TWAP). A longer window makes the price more expensive to manipulate but slower to follow the market.\n\nThe pool must store enough observations to cover this window.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(1800)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RetryNonceTooLow: config.Parameter{
+			ID:                   "retryNonceTooLow",
+			Name:                 "Retry on Nonce Too Low",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]If a price submission fails because its nonce was already used (for example, by another transaction sent from the node account), refresh the nonce and try the submission once more.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SkipIdenticalPrice: config.Parameter{
+			ID:                   "skipIdenticalPrice",
+			Name:                 "Skip Identical Price",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Don't submit the RPL price if it's exactly the same as the price currently on-chain, since the submission would cost gas without changing anything. Any change in price is still submitted.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PprofPort: config.Parameter{
+			ID:                   "pprofPort",
+			Name:                 "Debug Profiling Port",
+			Description:          "The port the watchtower should serve Go's pprof profiling endpoints on, for diagnosing performance problems like CPU spikes or goroutine leaks. These endpoints expose internal details of the process, so leave this at 0 to keep them disabled unless you're debugging.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PprofAddress: config.Parameter{
+			ID:                   "pprofAddress",
+			Name:                 "Debug Profiling Address",
+			Description:          "The address the pprof profiling endpoints should listen on when the Debug Profiling Port is set. By default they're only reachable from the machine itself.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "127.0.0.1"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		OneInchOracleAddresses: config.Parameter{
+			ID:                   "oneInchOracleAddresses",
+			Name:                 "1inch Oracle Addresses",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of 1inch oracle contract addresses for the `1inch` price source to use, in order of preference. If one fails or returns an implausible rate, the next one is tried.\n\nLeave this blank to only use the default 1inch oracle for your network.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogFile: config.Parameter{
+			ID:                   "logFile",
+			Name:                 "Log File",
+			Description:          "The path of a file the node and watchtower daemons should write their logs to. The file is rotated once it reaches the max log size, keeping one previous file with a `.1` suffix. Color codes are removed from the file output.\n\nLeave this blank to only log to the console.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogMaxSizeMb: config.Parameter{
+			ID:                   "logMaxSizeMb",
+			Name:                 "Max Log Size",
+			Description:          "The size, in MB, the log file is rotated at.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(100)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogToConsole: config.Parameter{
+			ID:                   "logToConsole",
+			Name:                 "Log to Console",
+			Description:          "Enable this to keep writing logs to the console as well as the log file. Has no effect if no log file is set.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MaxClockSkewSeconds: config.Parameter{
+			ID:                   "maxClockSkewSeconds",
+			Name:                 "Max Clock Skew",
+			Description:          "The largest difference, in seconds, allowed between the system clock and the timestamp of the latest Execution client block. The watchtower's timing logic assumes an accurate clock, so if the difference is larger than this it engages safe mode and stops submitting transactions. Set this to 0 to disable the check.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(120)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MinOracleRate: config.Parameter{
+			ID:                   "minOracleRate",
+			Name:                 "Min Oracle Rate",
+			Description:          "The lowest RPL price, in ETH, a price source can return before the watchtower ignores it. Oracles report a zero rate when they can't price RPL, so those prices are always ignored; set this to 0 to only ignore zero prices.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0.00001)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 
-	// The contract address of rocketClaimTrustedNode from v1.0.0
-	legacyClaimTrustedNodeAddress map[config.Network]string `yaml:"-"`
+		MaxOracleRate: config.Parameter{
+			ID:                   "maxOracleRate",
+			Name:                 "Max Oracle Rate",
+			Description:          "The highest RPL price, in ETH, a price source can return before the watchtower ignores it. A faulty oracle can return an absurdly large value, such as a negative number misread as an unsigned one, so a price above this is treated as a failure.\n\nSet this to 0 to accept any price.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(10)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 
-	// The contract address of rocketMinipoolManager from v1.0.0
-	legacyMinipoolManagerAddress map[config.Network]string `yaml:"-"`
+		PriceFeedAddress: config.Parameter{
+			ID:                   "priceFeedAddress",
+			Name:                 "Price Feed Address",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The address of a price feed contract that reports the RPL / ETH price, used by the `contract` price source. This lets you bring your own on-chain aggregator.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 
-	// Addresses for RocketRewardsPool that have been upgraded during development
-	previousRewardsPoolAddresses map[config.Network]map[string][]common.Address `yaml:"-"`
+		PriceFeedMethod: config.Parameter{
+			ID:                   "priceFeedMethod",
+			Name:                 "Price Feed Method",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Select the method the `contract` price source uses to read the price feed contract.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.PriceFeedMethod_LatestRoundData},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "latestRoundData",
+				Description: "Use the Chainlink-style `latestRoundData()` method, which also reports when the price was last updated.",
+				Value:       config.PriceFeedMethod_LatestRoundData,
+			}, {
+				Name:        "latestAnswer",
+				Description: "Use the `latestAnswer()` method, which only reports the price.",
+				Value:       config.PriceFeedMethod_LatestAnswer,
+			}},
+		},
 
-	// The RocketOvmPriceMessenger address for each network
-	optimismPriceMessengerAddress map[config.Network]string `yaml:"-"`
+		PriceFeedDecimals: config.Parameter{
+			ID:                   "priceFeedDecimals",
+			Name:                 "Price Feed Decimals",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of decimal places in the answer the price feed contract reports, used to scale it to wei.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(18)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 
-	// Rewards submission block maps
-	rewardsSubmissionBlockMaps map[config.Network][]uint64 `yaml:"-"`
-}
+		MaxBackfillBlocks: config.Parameter{
+			ID:                   "maxBackfillBlocks",
+			Name:                 "Max Backfill Blocks",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]If the watchtower was offline long enough for several RPL price checkpoints to pass without consensus, it submits prices for the open checkpoints within this many blocks of the latest one when it starts, oldest first, before submitting for the latest one.\n\nSet this to 0 to only submit for the latest checkpoint.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 
-// Generates a new Smartnode configuration
-func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
+		RocketStorageAddressOverride: config.Parameter{
+			ID:                   "rocketStorageAddressOverride",
+			Name:                 "RocketStorage Address Override",
+			Description:          "[orange]**For development only.**\n\n[white]The address of a RocketStorage contract to use instead of the one deployed for the selected network. Set this to run the Smartnode against a custom deployment, such as a local devnet or a fork.\n\nLeave it blank to use the network's deployment.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 
-	return &SmartnodeConfig{
-		Title:  "Smartnode Settings",
-		parent: cfg,
+		FundingThresholdEth: config.Parameter{
+			ID:                   "fundingThresholdEth",
+			Name:                 "Funding Threshold",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]If the node account's ETH balance falls below this amount, the watchtower will log a funding request with the amount needed to bring it back up to the Funding Target, and send it to the Funding Webhook if one is set. This should be above the Minimum Account Balance so the account is topped up before submissions are skipped.\n\nSet this to 0 to disable funding requests.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 
-		ProjectName: config.Parameter{
-			ID:                   ProjectNameID,
-			Name:                 "Project Name",
-			Description:          "This is the prefix that will be attached to all of the Docker containers managed by the Smartnode.",
-			Type:                 config.ParameterType_String,
-			Default:              map[config.Network]interface{}{config.Network_All: defaultProjectName},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Eth1, config.ContainerID_Eth2, config.ContainerID_Validator, config.ContainerID_Grafana, config.ContainerID_Prometheus, config.ContainerID_Exporter},
-			EnvironmentVariables: []string{"COMPOSE_PROJECT_NAME"},
+		FundingTargetEth: config.Parameter{
+			ID:                   "fundingTargetEth",
+			Name:                 "Funding Target",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The ETH balance a funding request asks for the node account to be topped up to once it falls below the Funding Threshold.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		DataPath: config.Parameter{
-			ID:                   "dataPath",
-			Name:                 "Data Path",
-			Description:          "The absolute path of the `data` folder that contains your node wallet's encrypted file, the password for your node wallet, and all of the validator keys for your minipools. You may use environment variables in this string.",
+		FundingWebhookUrl: config.Parameter{
+			ID:                   "fundingWebhookUrl",
+			Name:                 "Funding Webhook URL",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The URL of a webhook, such as a funding bot, the watchtower should send funding requests to. Requests are sent as an HTTP POST with a JSON body containing the node address, its balance, the target balance and the amount to top up by, all in wei.\n\nLeave this blank to only write funding requests to the watchtower log.",
 			Type:                 config.ParameterType_String,
-			Default:              map[config.Network]interface{}{config.Network_All: getDefaultDataDir(cfg)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Validator},
-			EnvironmentVariables: []string{"ROCKETPOOL_DATA_FOLDER"},
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MedianTieBreak: config.Parameter{
+			ID:                   "medianTieBreak",
+			Name:                 "Median Tie-Break",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Select how the RPL price is chosen from the two middle prices when an even number of price sources returned one. Every Oracle DAO member should use the same setting so honest nodes agree on the same price.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.MedianTieBreak_Average},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Average",
+				Description: "Use the average of the two middle prices, rounded down to the nearest wei.",
+				Value:       config.MedianTieBreak_Average,
+			}, {
+				Name:        "Lower",
+				Description: "Use the lower of the two middle prices.",
+				Value:       config.MedianTieBreak_Lower,
+			}, {
+				Name:        "Higher",
+				Description: "Use the higher of the two middle prices.",
+				Value:       config.MedianTieBreak_Higher,
+			}},
 		},
 
-		WatchtowerStatePath: config.Parameter{
-			ID:                   "watchtowerPath",
-			Name:                 "Watchtower Path",
-			Description:          "The absolute path of the watchtower state folder that contains persistent state that is used by the watchtower process on trusted nodes. **Only relevant for trusted nodes.**",
-			Type:                 config.ParameterType_String,
-			Default:              map[config.Network]interface{}{config.Network_All: "$HOME/.rocketpool/watchtower"},
+		ReadOnlyMode: config.Parameter{
+			ID:                   "readOnlyMode",
+			Name:                 "Read-Only Mode",
+			Description:          "Enable this to have the watchtower monitor the Oracle DAO even though your node isn't a member. It will compute the RPL price and network balances at each checkpoint, compare them to the values on-chain and record the deviation metrics, but it will never submit anything.\n\nThis has no effect on Oracle DAO members, which always submit.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
-			EnvironmentVariables: []string{"ROCKETPOOL_WATCHTOWER_FOLDER"},
+			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		Network: config.Parameter{
-			ID:                   NetworkID,
-			Name:                 "Network",
-			Description:          "The Ethereum network you want to use - select Prater Testnet to practice with fake ETH, or Mainnet to stake on the real network using real ETH.",
-			Type:                 config.ParameterType_Choice,
-			Default:              map[config.Network]interface{}{config.Network_All: config.Network_Mainnet},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Eth1, config.ContainerID_Eth2, config.ContainerID_Validator},
-			EnvironmentVariables: []string{"NETWORK"},
+		MinipoolBatchSize: config.Parameter{
+			ID:                   "minipoolBatchSize",
+			Name:                 "Minipool Batch Size",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of minipools the watchtower loads details for in a single multicall when it goes through all of the minipools on the network, such as when checking for minipools to scrub, dissolve or mark as withdrawable. Larger batches need fewer round trips but put more load on your Execution client at once.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(20)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
-			Options:              getNetworkOptions(),
 		},
 
-		ManualMaxFee: config.Parameter{
-			ID:                   "manualMaxFee",
-			Name:                 "Manual Max Fee",
-			Description:          "Set this if you want all of the Smartnode's transactions to use this specific max fee value (in gwei), which is the most you'd be willing to pay (*including the priority fee*).\n\nA value of 0 will show you the current suggested max fee based on the current network conditions and let you specify it each time you do a transaction.\n\nAny other value will ignore the recommended max fee and explicitly use this value instead.\n\nThis applies to automated transactions (such as claiming RPL and staking minipools) as well.",
+		MaxSourceSpreadPct: config.Parameter{
+			ID:                   "maxSourceSpreadPct",
+			Name:                 "Max Source Spread",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]If the highest RPL price from your price sources is more than this percentage above the lowest one for a checkpoint, the watchtower will hold off submitting the price for that round and try again once the sources reconverge. This protects against submitting briefly unreliable prices around major market events.\n\nUnlike the safe mode limit, this only affects the price submission. Set this to 0 to disable the check.",
 			Type:                 config.ParameterType_Float,
 			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		PriorityFee: config.Parameter{
-			ID:                   "priorityFee",
-			Name:                 "Priority Fee",
-			Description:          "The default value for the priority fee (in gwei) for all of your transactions. This describes how much you're willing to pay *above the network's current base fee* - the higher this is, the more ETH you give to the validators for including your transaction, which generally means it will be included in a block faster (as long as your max fee is sufficiently high to cover the current network conditions).\n\nMust be larger than 0.",
-			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(2)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+		OracleSourceTimeout: config.Parameter{
+			ID:                   "oracleSourceTimeout",
+			Name:                 "Oracle Source Timeout",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of seconds each RPL price source has to return a price. A source that takes longer is treated as failed and excluded, so one slow source doesn't hold up the others.\n\nSet this to 0 to wait for every source no matter how long it takes.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(30)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		MinipoolStakeGasThreshold: config.Parameter{
-			ID:   "minipoolStakeGasThreshold",
-			Name: "Minipool Stake Gas Threshold",
-			Description: "Once a newly created minipool passes the scrub check and is ready to perform its second 16 ETH deposit (the `stake` transaction), your node will try to do so automatically using the `Rapid` suggestion from the gas estimator as its max fee. This threshold is a limit (in gwei) you can put on that suggestion; your node will not `stake` the new minipool until the suggestion is below this limit.\n\n" +
-				"Note that to ensure your minipool does not get dissolved, the node will ignore this limit and automatically execute the `stake` transaction at whatever the suggested fee happens to be once too much time has passed since its first deposit (currently 7 days).",
+		AutoFinaliseMaxPerRun: config.Parameter{
+			ID:                   "autoFinaliseMaxPerRun",
+			Name:                 "Auto-Finalise Max Per Run",
+			Description:          "The node daemon will automatically finalise minipools that have been withdrawn, returning their RPL stake to the node's accounting. This is the most minipools it will finalise in one run, so a large backlog is spread over several runs. Minipools with a refund available are skipped until you refund them with `rocketpool minipool refund`.\n\nSet this to 0 to disable automatic finalising.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoFinaliseGasThreshold: config.Parameter{
+			ID:                   "autoFinaliseGasThreshold",
+			Name:                 "Auto-Finalise Gas Threshold",
+			Description:          "Your node will use the `Rapid` suggestion from the gas estimator as the max fee when it automatically finalises minipools. This threshold is a limit (in gwei) you can put on that suggestion; your node will wait to finalise until the suggestion is below this limit.",
 			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(150)},
+			Default:              map[config.Network]interface{}{config.Network_All: float64(50)},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		RewardsTreeMode: config.Parameter{
-			ID:                   "rewardsTreeMode",
-			Name:                 "Rewards Tree Mode",
-			Description:          "Select how you want to acquire the Merkle Tree files for each rewards interval.",
-			Type:                 config.ParameterType_Choice,
-			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsMode_Download},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+		BcRetryAttempts: config.Parameter{
+			ID:                   "bcRetryAttempts",
+			Name:                 "Beacon Client Retry Attempts",
+			Description:          "The number of times the Smartnode will try a request to your Beacon client if it keeps getting one of the retryable status codes back, such as the 503 some clients return briefly during state transitions. Set this to 1 to disable retries.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(3)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
-			Options: []config.ParameterOption{{
-				Name:        "Download",
-				Description: "Automatically download the Merkle Tree rewards files that were published by the Oracle DAO after a rewards checkpoint.",
-				Value:       config.RewardsMode_Download,
-			}, {
-				Name:        "Generate",
-				Description: "Use your node to automatically generate the Merkle Tree rewards file once a checkpoint has passed. This option lets you build and verify the file that the Oracle DAO created if you prefer not to trust it and want to generate the tree yourself.\n\n[orange]WARNING: Generating the tree can take a *very long time* if many node operators are opted into the Smoothing Pool, which could impact your attestation performance!",
-				Value:       config.RewardsMode_Generate,
-			}},
 		},
 
-		ArchiveECUrl: config.Parameter{
-			ID:                   "archiveECUrl",
-			Name:                 "Archive-Mode EC URL",
-			Description:          "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
+		BcRetryBackoffMs: config.Parameter{
+			ID:                   "bcRetryBackoffMs",
+			Name:                 "Beacon Client Retry Backoff",
+			Description:          "The number of milliseconds the Smartnode waits before retrying a request to your Beacon client. The wait doubles with every retry.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(500)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BcRetryStatusCodes: config.Parameter{
+			ID:                   "bcRetryStatusCodes",
+			Name:                 "Beacon Client Retryable Status Codes",
+			Description:          "A comma-separated list of the HTTP status codes from your Beacon client that the Smartnode should retry the request on.\n\nLeave this blank to never retry.",
 			Type:                 config.ParameterType_String,
-			Default:              map[config.Network]interface{}{config.Network_All: ""},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			Default:              map[config.Network]interface{}{config.Network_All: "503"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
 		},
 
-		Web3StorageApiToken: config.Parameter{
-			ID:                   "web3StorageApiToken",
-			Name:                 "Web3.Storage API Token",
-			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The API token for your https://web3.storage/ account. This is required in order for you to upload Merkle rewards trees to Web3.Storage at each rewards interval.",
+		MaxReportableBlockAge: config.Parameter{
+			ID:                   "maxReportableBlockAge",
+			Name:                 "Max Reportable Block Age",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The maximum age, in seconds, of the block the watchtower reports prices and balances for. If the reportable block is older than this, your Execution client is probably lagging behind the chain and the watchtower won't submit stale data.\n\nThe reportable block is the latest checkpoint, so it can legitimately be up to one submission interval old; keep this above the interval. Set this to 0 to disable the check.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(86400)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PriceQuoteToken: config.Parameter{
+			ID:                   "priceQuoteToken",
+			Name:                 "Price Quote Token",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The address of the token the `1inch` price source quotes the RPL price against, such as WETH. The token must have 18 decimals, since the rate is submitted as an amount of ETH in wei.\n\nLeave this blank to quote against native ETH.",
 			Type:                 config.ParameterType_String,
 			Default:              map[config.Network]interface{}{config.Network_All: ""},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
@@ -271,6 +1292,66 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		MinSuccessfulSources: config.Parameter{
+			ID:                   "minSuccessfulSources",
+			Name:                 "Min Successful Sources",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The minimum number of price sources that have to return a usable RPL price before the watchtower will submit one. Sources that fail, time out, are stale or return an implausibly low rate don't count.\n\nRaise this to avoid relying on a single source that may have been compromised or manipulated.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		StateRetentionDays: config.Parameter{
+			ID:                   "stateRetentionDays",
+			Name:                 "State Retention",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of days the watchtower keeps submission audit records and alert history for. Older entries are pruned on startup and once a day so these files don't grow without bound.\n\nSet this to 0 to keep everything.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(90)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ChallengeResponseEarliestFraction: config.Parameter{
+			ID:                   "challengeResponseEarliestFraction",
+			Name:                 "Challenge Response Earliest Fraction",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]How far into the challenge window, as a fraction between 0 and 1, the watchtower waits before responding to a challenge against your node. Waiting gives a challenge that was made by mistake a chance to be withdrawn.\n\nThe watchtower always responds at least the Challenge Response Latest Margin before the deadline. Set this to 0 to respond as soon as the node is challenged.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ChallengeResponseLatestMargin: config.Parameter{
+			ID:                   "challengeResponseLatestMargin",
+			Name:                 "Challenge Response Latest Margin",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of seconds before a challenge's deadline by which the watchtower starts responding, even if the Challenge Response Earliest Fraction of the window hasn't passed yet. This leaves time to retry if the response transaction fails or gas prices are high; a node that doesn't respond before the deadline can be removed from the Oracle DAO.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(86400)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PriceSigFigs: config.Parameter{
+			ID:                   "priceSigFigs",
+			Name:                 "Price Significant Figures",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of significant figures to truncate the submitted RPL price to, counting from its first non-zero digit in wei. Matching the precision used by the other Oracle DAO clients makes it more likely that everyone submits exactly the same price.\n\nSet this to 0 to submit the full price.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		txWatchUrl: map[config.Network]string{
 			config.Network_Mainnet: "https://etherscan.io/tx",
 			config.Network_Prater:  "https://goerli.etherscan.io/tx",
@@ -301,6 +1382,18 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			config.Network_Devnet:  "0x4eDC966Df24264C9C817295a0753804EcC46Dd22",
 		},
 
+		wethAddress: map[config.Network]string{
+			config.Network_Mainnet: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2",
+			config.Network_Prater:  "0xB4FBF271143F4FBf7B91A5ded31805e42b2208d6",
+			config.Network_Devnet:  "0xB4FBF271143F4FBf7B91A5ded31805e42b2208d6",
+		},
+
+		multicallAddress: map[config.Network]string{
+			config.Network_Mainnet: "0xcA11bde05977b3631167028862bE2a173976CA11",
+			config.Network_Prater:  "0xcA11bde05977b3631167028862bE2a173976CA11",
+			config.Network_Devnet:  "0xcA11bde05977b3631167028862bE2a173976CA11",
+		},
+
 		rplTokenAddress: map[config.Network]string{
 			config.Network_Mainnet: "0xD33526068D116cE69F19A9ee46F0bd304F21A51f",
 			config.Network_Prater:  "0x5e932688e81a182e3de211db6544f98b8e4f89c7",
@@ -371,6 +1464,18 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			config.Network_Devnet:  "",
 		},
 
+		chainlinkRplUsdFeedAddress: map[config.Network]string{
+			config.Network_Mainnet: "0x4E155eD98aFE9034b7A5962f6C84c86d869daA9d",
+			config.Network_Prater:  "",
+			config.Network_Devnet:  "",
+		},
+
+		chainlinkEthUsdFeedAddress: map[config.Network]string{
+			config.Network_Mainnet: "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+			config.Network_Prater:  "",
+			config.Network_Devnet:  "",
+		},
+
 		rewardsSubmissionBlockMaps: map[config.Network][]uint64{
 			config.Network_Mainnet: {
 				15451165, 15637542, 15839520, 16038366,
@@ -407,6 +1512,74 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.RewardsTreeMode,
 		&cfg.ArchiveECUrl,
 		&cfg.Web3StorageApiToken,
+		&cfg.WatchtowerWsUrl,
+		&cfg.MaxDepositAssignmentTxs,
+		&cfg.AlertWebhookUrl,
+		&cfg.BeaconStateId,
+		&cfg.AlertCooldown,
+		&cfg.AlertReminderInterval,
+		&cfg.SubmitDeadlineBlocks,
+		&cfg.LeaderLeaseTime,
+		&cfg.PriceSources,
+		&cfg.StartupWarmupSeconds,
+		&cfg.EcHeaders,
+		&cfg.BcHeaders,
+		&cfg.RefreshSnapshotScores,
+		&cfg.AutoClaimThreshold,
+		&cfg.AutoClaimRestake,
+		&cfg.AutoClaimGasThreshold,
+		&cfg.ConsensusCooldownSeconds,
+		&cfg.PriceGasSource,
+		&cfg.CompressRewardTrees,
+		&cfg.UseAccessList,
+		&cfg.MaxStalenessSeconds,
+		&cfg.SafeModeManualReset,
+		&cfg.SafeModeMaxSourceDeviation,
+		&cfg.AutoStakeTargetCollateral,
+		&cfg.AutoStakeMinAmount,
+		&cfg.AutoStakeGasThreshold,
+		&cfg.ConfirmationDepth,
+		&cfg.MinAccountBalanceEth,
+		&cfg.SubmitGasLimit,
+		&cfg.UniswapPoolAddress,
+		&cfg.MinLiquidity,
+		&cfg.UniswapTwapSeconds,
+		&cfg.RetryNonceTooLow,
+		&cfg.SkipIdenticalPrice,
+		&cfg.PprofPort,
+		&cfg.PprofAddress,
+		&cfg.OneInchOracleAddresses,
+		&cfg.LogFile,
+		&cfg.LogMaxSizeMb,
+		&cfg.LogToConsole,
+		&cfg.MaxClockSkewSeconds,
+		&cfg.MinOracleRate,
+		&cfg.MaxOracleRate,
+		&cfg.PriceFeedAddress,
+		&cfg.PriceFeedMethod,
+		&cfg.PriceFeedDecimals,
+		&cfg.MaxBackfillBlocks,
+		&cfg.RocketStorageAddressOverride,
+		&cfg.FundingThresholdEth,
+		&cfg.FundingTargetEth,
+		&cfg.FundingWebhookUrl,
+		&cfg.MedianTieBreak,
+		&cfg.ReadOnlyMode,
+		&cfg.MinipoolBatchSize,
+		&cfg.MaxSourceSpreadPct,
+		&cfg.OracleSourceTimeout,
+		&cfg.AutoFinaliseMaxPerRun,
+		&cfg.AutoFinaliseGasThreshold,
+		&cfg.BcRetryAttempts,
+		&cfg.BcRetryBackoffMs,
+		&cfg.BcRetryStatusCodes,
+		&cfg.MaxReportableBlockAge,
+		&cfg.PriceQuoteToken,
+		&cfg.MinSuccessfulSources,
+		&cfg.StateRetentionDays,
+		&cfg.ChallengeResponseEarliestFraction,
+		&cfg.ChallengeResponseLatestMargin,
+		&cfg.PriceSigFigs,
 	}
 }
 
@@ -450,10 +1623,18 @@ func (cfg *SmartnodeConfig) GetValidatorKeychainPath() string {
 
 func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
 	if config.parent.IsNativeMode {
-		return filepath.Join(config.DataPath.Value.(string), WatchtowerFolder, "state.yml")
+		return filepath.Join(config.DataPath.Value.(string), WatchtowerFolder, WatchtowerStateFile)
+	}
+
+	return filepath.Join(DaemonDataPath, WatchtowerFolder, WatchtowerStateFile)
+}
+
+func (config *SmartnodeConfig) GetWatchtowerStateFolder() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.DataPath.Value.(string), WatchtowerFolder)
 	}
 
-	return filepath.Join(DaemonDataPath, WatchtowerFolder, "state.yml")
+	return filepath.Join(DaemonDataPath, WatchtowerFolder)
 }
 
 func (cfg *SmartnodeConfig) GetCustomKeyPath() string {
@@ -473,6 +1654,9 @@ func (cfg *SmartnodeConfig) GetCustomKeyPasswordFilePath() string {
 }
 
 func (cfg *SmartnodeConfig) GetStorageAddress() string {
+	if override := cfg.RocketStorageAddressOverride.Value.(string); override != "" {
+		return override
+	}
 	return cfg.storageAddress[cfg.Network.Value.(config.Network)]
 }
 
@@ -480,6 +1664,14 @@ func (cfg *SmartnodeConfig) GetOneInchOracleAddress() string {
 	return cfg.oneInchOracleAddress[cfg.Network.Value.(config.Network)]
 }
 
+func (cfg *SmartnodeConfig) GetWethAddress() string {
+	return cfg.wethAddress[cfg.Network.Value.(config.Network)]
+}
+
+func (cfg *SmartnodeConfig) GetMulticallAddress() string {
+	return cfg.multicallAddress[cfg.Network.Value.(config.Network)]
+}
+
 func (cfg *SmartnodeConfig) GetRplTokenAddress() string {
 	return cfg.rplTokenAddress[cfg.Network.Value.(config.Network)]
 }
@@ -597,10 +1789,75 @@ func (cfg *SmartnodeConfig) GetOptimismMessengerAddress() string {
 	return cfg.optimismPriceMessengerAddress[cfg.Network.Value.(config.Network)]
 }
 
+// Get the address to serve the pprof debug endpoints on, or an empty string if they're disabled
+func (cfg *SmartnodeConfig) GetPprofListenAddress() string {
+	port := cfg.PprofPort.Value.(uint64)
+	if port == 0 {
+		return ""
+	}
+	host := cfg.PprofAddress.Value.(string)
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, strconv.FormatUint(port, 10))
+}
+
+func (cfg *SmartnodeConfig) GetChainlinkRplUsdFeedAddress() string {
+	return cfg.chainlinkRplUsdFeedAddress[cfg.Network.Value.(config.Network)]
+}
+
+func (cfg *SmartnodeConfig) GetChainlinkEthUsdFeedAddress() string {
+	return cfg.chainlinkEthUsdFeedAddress[cfg.Network.Value.(config.Network)]
+}
+
 func (cfg *SmartnodeConfig) GetRewardsSubmissionBlockMaps() []uint64 {
 	return cfg.rewardsSubmissionBlockMaps[cfg.Network.Value.(config.Network)]
 }
 
+// Get the extra headers to send to the Execution client
+func (cfg *SmartnodeConfig) GetEcHeaders() (map[string]string, error) {
+	return parseHeaders(cfg.EcHeaders.Value.(string))
+}
+
+// Get the extra headers to send to the Beacon client
+func (cfg *SmartnodeConfig) GetBcHeaders() (map[string]string, error) {
+	return parseHeaders(cfg.BcHeaders.Value.(string))
+}
+
+// Parse a semicolon-separated list of "Name: Value" HTTP headers
+// Get the HTTP status codes from the Beacon client that should be retried
+func (cfg *SmartnodeConfig) GetBcRetryStatusCodes() ([]int, error) {
+	codes := []int{}
+	for _, code := range strings.Split(cfg.BcRetryStatusCodes.Value.(string), ",") {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		value, err := strconv.Atoi(code)
+		if err != nil || value < 100 || value > 599 {
+			return nil, fmt.Errorf("invalid HTTP status code [%s]", code)
+		}
+		codes = append(codes, value)
+	}
+	return codes, nil
+}
+
+func parseHeaders(value string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, header := range strings.Split(value, ";") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		elements := strings.SplitN(header, ":", 2)
+		if len(elements) != 2 || strings.TrimSpace(elements[0]) == "" {
+			return nil, fmt.Errorf("invalid header [%s]; headers must be in the form 'Name: Value'", header)
+		}
+		headers[strings.TrimSpace(elements[0])] = strings.TrimSpace(elements[1])
+	}
+	return headers, nil
+}
+
 func getNetworkOptions() []config.ParameterOption {
 	options := []config.ParameterOption{
 		{
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: map[string]string{}},
+		{name: "single header", value: "Authorization: Bearer abc", want: map[string]string{"Authorization": "Bearer abc"}},
+		{name: "multiple headers with spacing", value: " X-Api-Key:key ; X-Client : smartnode ;", want: map[string]string{"X-Api-Key": "key", "X-Client": "smartnode"}},
+		{name: "colon in value", value: "X-Target: http://localhost:8545", want: map[string]string{"X-Target": "http://localhost:8545"}},
+		{name: "missing colon", value: "Authorization Bearer abc", wantErr: true},
+		{name: "missing name", value: ": value", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseHeaders(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseHeaders(%q) error = %v, want error: %t", test.value, err, test.wantErr)
+			}
+			if !test.wantErr && !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseHeaders(%q) = %v, want %v", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetPprofListenAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		port    uint64
+		address string
+		want    string
+	}{
+		{name: "disabled", port: 0, address: "0.0.0.0", want: ""},
+		{name: "default address", port: 6060, address: "", want: "127.0.0.1:6060"},
+		{name: "custom address", port: 6060, address: "0.0.0.0", want: "0.0.0.0:6060"},
+		{name: "ipv6 address", port: 6060, address: "::1", want: "[::1]:6060"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := NewRocketPoolConfig("", true)
+			cfg.Smartnode.PprofPort.Value = test.port
+			cfg.Smartnode.PprofAddress.Value = test.address
+			if got := cfg.Smartnode.GetPprofListenAddress(); got != test.want {
+				t.Errorf("GetPprofListenAddress() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetBcRetryStatusCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		codes   string
+		want    []int
+		wantErr bool
+	}{
+		{name: "empty", codes: "", want: []int{}},
+		{name: "list", codes: "502, 503,504", want: []int{502, 503, 504}},
+		{name: "blank entries", codes: "503,,", want: []int{503}},
+		{name: "not a number", codes: "503,abc", wantErr: true},
+		{name: "out of range", codes: "99", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := NewRocketPoolConfig("", false)
+			cfg.Smartnode.BcRetryStatusCodes.Value = test.codes
+			got, err := cfg.Smartnode.GetBcRetryStatusCodes()
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("GetBcRetryStatusCodes(%q) didn't return an error", test.codes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetBcRetryStatusCodes(%q) returned an error: %s", test.codes, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("GetBcRetryStatusCodes(%q) = %v, want %v", test.codes, got, test.want)
+			}
+		})
+	}
+}
@@ -5,14 +5,20 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
+	"github.com/gorilla/websocket"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
@@ -25,6 +31,8 @@ type ExecutionClientManager struct {
 	fallbackEcUrl   string
 	primaryEc       *ethclient.Client
 	fallbackEc      *ethclient.Client
+	primaryRpc      *rpc.Client
+	fallbackRpc     *rpc.Client
 	logger          log.ColorLogger
 	primaryReady    bool
 	fallbackReady   bool
@@ -64,24 +72,33 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		}
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	headers, err := cfg.Smartnode.GetEcHeaders()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing EC headers: %w", err)
+	}
+
+	primaryRpc, err := dialEcWithHeaders(primaryEcUrl, headers)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
 
+	var fallbackRpc *rpc.Client
 	var fallbackEc *ethclient.Client
 	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+		fallbackRpc, err = dialEcWithHeaders(fallbackEcUrl, headers)
 		if err != nil {
 			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
 		}
+		fallbackEc = ethclient.NewClient(fallbackRpc)
 	}
 
 	return &ExecutionClientManager{
 		primaryEcUrl:  primaryEcUrl,
 		fallbackEcUrl: fallbackEcUrl,
-		primaryEc:     primaryEc,
+		primaryEc:     ethclient.NewClient(primaryRpc),
 		fallbackEc:    fallbackEc,
+		primaryRpc:    primaryRpc,
+		fallbackRpc:   fallbackRpc,
 		logger:        log.NewColorLogger(color.FgYellow),
 		primaryReady:  true,
 		fallbackReady: fallbackEc != nil,
@@ -89,6 +106,50 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 
 }
 
+// Connect to an EC, attaching the provided headers to every request
+func dialEcWithHeaders(ecUrl string, headers map[string]string) (*rpc.Client, error) {
+
+	// SetHeader only applies to HTTP connections, so WebSocket connections get the headers on their handshake instead.
+	// The dialer's proxy hook is the only place the handshake request can be changed before it's sent.
+	lowerUrl := strings.ToLower(ecUrl)
+	if strings.HasPrefix(lowerUrl, "ws://") || strings.HasPrefix(lowerUrl, "wss://") {
+		dialer := websocket.Dialer{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			Proxy: func(req *http.Request) (*url.URL, error) {
+				for key, value := range headers {
+					req.Header.Set(key, value)
+				}
+				return nil, nil
+			},
+		}
+		return rpc.DialWebsocketWithDialer(context.Background(), ecUrl, "", dialer)
+	}
+
+	rpcClient, err := rpc.DialContext(context.Background(), ecUrl)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		rpcClient.SetHeader(key, value)
+	}
+	return rpcClient, nil
+
+}
+
+// Connect to an EC other than the primary or fallback one, such as the archive EC, with the configured headers
+func DialEc(cfg *config.RocketPoolConfig, ecUrl string) (*ethclient.Client, error) {
+	headers, err := cfg.Smartnode.GetEcHeaders()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing EC headers: %w", err)
+	}
+	rpcClient, err := dialEcWithHeaders(ecUrl, headers)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(rpcClient), nil
+}
+
 /// ========================
 /// ContractCaller Functions
 /// ========================
@@ -213,6 +274,75 @@ func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.
 	return err
 }
 
+// CreateAccessList gets the access list for a call, along with the gas the call uses with it applied.
+// This is a Geth-specific RPC method, so not every client supports it.
+func (p *ExecutionClientManager) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, error) {
+
+	client, err := p.getReadyRpcClient()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	accessList, gasUsed, vmErr, err := gethclient.New(client).CreateAccessList(ctx, msg)
+	if err != nil {
+		return nil, 0, err
+	}
+	if vmErr != "" {
+		return nil, 0, fmt.Errorf("call failed while creating the access list: %s", vmErr)
+	}
+	return accessList, gasUsed, nil
+
+}
+
+// FinalizedBlockNumber gets the number of the latest finalized block.
+// Clients that don't track finality return an error.
+func (p *ExecutionClientManager) FinalizedBlockNumber(ctx context.Context) (uint64, error) {
+
+	client, err := p.getReadyRpcClient()
+	if err != nil {
+		return 0, err
+	}
+
+	var head *struct {
+		Number *hexutil.Big `json:"number"`
+	}
+	if err := client.CallContext(ctx, &head, "eth_getBlockByNumber", "finalized", false); err != nil {
+		return 0, err
+	}
+	if head == nil || head.Number == nil {
+		return 0, fmt.Errorf("the client did not return a finalized block")
+	}
+	return head.Number.ToInt().Uint64(), nil
+
+}
+
+// ClientVersion gets the name and version string the client reports for itself.
+func (p *ExecutionClientManager) ClientVersion(ctx context.Context) (string, error) {
+
+	client, err := p.getReadyRpcClient()
+	if err != nil {
+		return "", err
+	}
+
+	var version string
+	if err := client.CallContext(ctx, &version, "web3_clientVersion"); err != nil {
+		return "", err
+	}
+	return version, nil
+
+}
+
+// Get the RPC client for the EC that the other functions would use, for calls that ethclient doesn't support
+func (p *ExecutionClientManager) getReadyRpcClient() (*rpc.Client, error) {
+	if p.primaryReady {
+		return p.primaryRpc, nil
+	}
+	if p.fallbackReady {
+		return p.fallbackRpc, nil
+	}
+	return nil, fmt.Errorf("no Execution clients were ready")
+}
+
 /// ==========================
 /// ContractFilterer Functions
 /// ==========================
@@ -316,6 +446,17 @@ func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Add
 	return result.(uint64), err
 }
 
+// ChainID retrieves the current chain ID for transaction replay protection.
+func (p *ExecutionClientManager) ChainID(ctx context.Context) (*big.Int, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.ChainID(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*big.Int), nil
+}
+
 // SyncProgress retrieves the current progress of the sync algorithm. If there's
 // no sync currently running, it returns nil.
 func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// Start a JSON-RPC server that answers every call with 0x1 and sends the headers of each request it gets to the channel
+func newHeaderRecordingServer(t *testing.T, received chan<- http.Header) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+
+		// Keep WebSocket connections open until the client closes them
+		if websocket.IsWebSocketUpgrade(r) {
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("Error upgrading connection: %s", err)
+				return
+			}
+			defer conn.Close()
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}
+
+		var request struct {
+			Id json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Error decoding request: %s", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.Id,
+			"result":  "0x1",
+		})
+	}))
+	return server
+}
+
+func TestDialEcWithHeaders(t *testing.T) {
+	headers := map[string]string{
+		"Authorization": "Bearer secret",
+		"X-Custom":      "value",
+	}
+
+	tests := []struct {
+		name   string
+		scheme string
+	}{
+		{name: "http", scheme: "http"},
+		{name: "websocket", scheme: "ws"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			received := make(chan http.Header, 4)
+			server := newHeaderRecordingServer(t, received)
+			defer server.Close()
+			ecUrl := test.scheme + strings.TrimPrefix(server.URL, "http")
+
+			client, err := dialEcWithHeaders(ecUrl, headers)
+			if err != nil {
+				t.Fatalf("dialEcWithHeaders() returned an error: %s", err)
+			}
+			defer client.Close()
+
+			// HTTP clients only connect when they make a call
+			if test.scheme == "http" {
+				var result string
+				if err := client.Call(&result, "eth_chainId"); err != nil {
+					t.Fatalf("eth_chainId returned an error: %s", err)
+				}
+			}
+
+			header := <-received
+			for key, value := range headers {
+				if got := header.Get(key); got != value {
+					t.Errorf("header %s = %q, want %q", key, got, value)
+				}
+			}
+		})
+	}
+}
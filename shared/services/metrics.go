@@ -0,0 +1,35 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli"
+)
+
+// Get the labels attached to every exported metric, so the series from a fleet of nodes scraped into one Prometheus
+// don't collide
+func GetMetricLabels(c *cli.Context) (prometheus.Labels, error) {
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting node account: %w", err)
+	}
+	return getMetricLabels(cfg.Smartnode.Network.Value, nodeAccount.Address), nil
+}
+
+// Build the metric labels for a node on a network
+func getMetricLabels(network interface{}, nodeAddress common.Address) prometheus.Labels {
+	return prometheus.Labels{
+		"network":      fmt.Sprint(network),
+		"node_address": nodeAddress.Hex(),
+	}
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func TestGetMetricLabels(t *testing.T) {
+	nodeAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	labels := getMetricLabels(cfgtypes.Network_Prater, nodeAddress)
+	if len(labels) != 2 {
+		t.Errorf("getMetricLabels() = %v, want only the network and node address labels", labels)
+	}
+	if labels["network"] != "prater" {
+		t.Errorf("network label = %q, want %q", labels["network"], "prater")
+	}
+	if labels["node_address"] != nodeAddress.Hex() {
+		t.Errorf("node_address label = %q, want %q", labels["node_address"], nodeAddress.Hex())
+	}
+}
@@ -88,6 +88,13 @@ func RequireRocketStorage(c *cli.Context) error {
 		return err
 	}
 	if !rocketStorageLoaded {
+		cfg, err := GetConfig(c)
+		if err != nil {
+			return err
+		}
+		if override := cfg.Smartnode.RocketStorageAddressOverride.Value.(string); override != "" {
+			return fmt.Errorf("No contract code was found at the RocketStorage address override %s; please check that it matches your deployment.", override)
+		}
 		return errors.New("The Rocket Pool storage contract was not found; the configured address may be incorrect, or the Eth 1.0 node may not be synced. Please try again later.")
 	}
 	return nil
@@ -0,0 +1,63 @@
+package rewards
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// The magic bytes at the start of every gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Write a rewards tree or minipool performance file to disk, gzipping it if compression is enabled
+func WriteTreeFile(path string, data []byte, compress bool) error {
+	if compress {
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		if _, err := writer.Write(data); err != nil {
+			return fmt.Errorf("error compressing %s: %w", path, err)
+		}
+		if err := writer.Close(); err != nil {
+			return fmt.Errorf("error compressing %s: %w", path, err)
+		}
+		data = buffer.Bytes()
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Read a rewards tree or minipool performance file from disk, transparently decompressing it if it was gzipped
+func ReadTreeFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing %s: %w", path, err)
+	}
+	defer reader.Close()
+	data, err = ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// Read and deserialize a rewards tree file from disk
+func LoadRewardsFile(path string) (*RewardsFile, error) {
+	data, err := ReadTreeFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	var rewardsFile RewardsFile
+	if err := json.Unmarshal(data, &rewardsFile); err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return &rewardsFile, nil
+}
@@ -0,0 +1,96 @@
+package rewards
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTreeFileRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rewards")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	data := []byte(`{"rewardsFileVersion":1,"index":3,"merkleRoot":"0x1234"}`)
+	tests := []struct {
+		name     string
+		compress bool
+	}{
+		{"uncompressed", false},
+		{"gzipped", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.name+".json")
+			if err := WriteTreeFile(path, data, test.compress); err != nil {
+				t.Fatalf("WriteTreeFile() returned an error: %s", err)
+			}
+
+			// Only compressed files are stored as gzip
+			raw, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if isGzip := bytes.HasPrefix(raw, gzipMagic); isGzip != test.compress {
+				t.Errorf("file is gzipped: %t, want %t", isGzip, test.compress)
+			}
+
+			read, err := ReadTreeFile(path)
+			if err != nil {
+				t.Fatalf("ReadTreeFile() returned an error: %s", err)
+			}
+			if !bytes.Equal(read, data) {
+				t.Errorf("ReadTreeFile() = %s, want %s", read, data)
+			}
+		})
+	}
+}
+
+func TestReadTreeFileCorrupt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rewards")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A file with the gzip magic bytes but no valid stream behind them
+	path := filepath.Join(dir, "corrupt.json")
+	if err := ioutil.WriteFile(path, append(gzipMagic, 0x00, 0x01), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadTreeFile(path); err == nil {
+		t.Error("ReadTreeFile() didn't return an error for a corrupt gzip file")
+	}
+}
+
+func TestLoadRewardsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rewards")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rp-rewards-prater-3.json")
+	if err := WriteTreeFile(path, []byte(`{"rewardsFileVersion":1,"index":3,"merkleRoot":"0x1234"}`), true); err != nil {
+		t.Fatal(err)
+	}
+	rewardsFile, err := LoadRewardsFile(path)
+	if err != nil {
+		t.Fatalf("LoadRewardsFile() returned an error: %s", err)
+	}
+	if rewardsFile.Index != 3 || rewardsFile.MerkleRoot != "0x1234" {
+		t.Errorf("LoadRewardsFile() = index %d with root %s, want index 3 with root 0x1234", rewardsFile.Index, rewardsFile.MerkleRoot)
+	}
+
+	// Files that aren't rewards trees are rejected
+	if err := WriteTreeFile(path, []byte("not json"), false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRewardsFile(path); err == nil {
+		t.Error("LoadRewardsFile() didn't return an error for an invalid file")
+	}
+}
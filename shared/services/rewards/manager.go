@@ -105,7 +105,7 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	info.TreeFileExists = true
 
 	// Unmarshal it
-	fileBytes, err := ioutil.ReadFile(info.TreeFilePath)
+	fileBytes, err := ReadTreeFile(info.TreeFilePath)
 	if err != nil {
 		err = fmt.Errorf("error reading %s: %w", info.TreeFilePath, err)
 		return
@@ -337,7 +337,27 @@ func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid stri
 	if err != nil {
 		return fmt.Errorf("error expanding rewards tree path: %w", err)
 	}
-	rewardsTreeFilename := filepath.Base(rewardsTreePath)
+
+	// Download it
+	bytes, err := FetchRewardsFile(cfg, interval, cid, isDaemon)
+	if err != nil {
+		return err
+	}
+
+	// Write the file
+	err = WriteTreeFile(rewardsTreePath, bytes, cfg.Smartnode.CompressRewardTrees.Value == true)
+	if err != nil {
+		return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
+	}
+	return nil
+
+}
+
+// Downloads a single rewards file from IPFS and returns its decompressed contents without saving it
+func FetchRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, isDaemon bool) ([]byte, error) {
+
+	// Determine file name
+	rewardsTreeFilename := filepath.Base(cfg.Smartnode.GetRewardsTreePath(interval, isDaemon))
 	ipfsFilename := rewardsTreeFilename + config.RewardsTreeIpfsExtension
 
 	// Create URL list
@@ -373,17 +393,11 @@ func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid stri
 				errBuilder.WriteString(fmt.Sprintf("Error decompressing %s: %s\n", url, err.Error()))
 				continue
 			}
-
-			// Write the file
-			err = ioutil.WriteFile(rewardsTreePath, decompressedBytes, 0644)
-			if err != nil {
-				return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
-			}
-			return nil
+			return decompressedBytes, nil
 		}
 	}
 
-	return fmt.Errorf(errBuilder.String())
+	return nil, fmt.Errorf(errBuilder.String())
 
 }
 
@@ -49,6 +49,9 @@ func (c *Client) MinipoolStatus() (api.MinipoolStatusResponse, error) {
 		if mp.Validator.Balance == nil {
 			mp.Validator.Balance = big.NewInt(0)
 		}
+		if mp.Validator.EffectiveBalance == nil {
+			mp.Validator.EffectiveBalance = big.NewInt(0)
+		}
 		if mp.Validator.NodeBalance == nil {
 			mp.Validator.NodeBalance = big.NewInt(0)
 		}
@@ -56,6 +59,31 @@ func (c *Client) MinipoolStatus() (api.MinipoolStatusResponse, error) {
 	return response, nil
 }
 
+// Get the refundable ETH and finalization status of the node's minipools
+func (c *Client) MinipoolRefundInfo() (api.MinipoolRefundInfoResponse, error) {
+	responseBytes, err := c.callAPI("minipool refund-info")
+	if err != nil {
+		return api.MinipoolRefundInfoResponse{}, fmt.Errorf("Could not get minipool refund info: %w", err)
+	}
+	var response api.MinipoolRefundInfoResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolRefundInfoResponse{}, fmt.Errorf("Could not decode minipool refund info response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolRefundInfoResponse{}, fmt.Errorf("Could not get minipool refund info: %s", response.Error)
+	}
+	if response.TotalRefundable == nil {
+		response.TotalRefundable = big.NewInt(0)
+	}
+	for i := 0; i < len(response.Minipools); i++ {
+		minipool := &response.Minipools[i]
+		if minipool.RefundBalance == nil {
+			minipool.RefundBalance = big.NewInt(0)
+		}
+	}
+	return response, nil
+}
+
 // Check whether a minipool is eligible for a refund
 func (c *Client) CanRefundMinipool(address common.Address) (api.CanRefundMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-refund %s", address.Hex()))
@@ -88,6 +116,22 @@ func (c *Client) RefundMinipool(address common.Address) (api.RefundMinipoolRespo
 	return response, nil
 }
 
+// Get the position of a minipool in the deposit queue
+func (c *Client) MinipoolQueuePosition(address common.Address) (api.MinipoolQueuePositionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool queue-position %s", address.Hex()))
+	if err != nil {
+		return api.MinipoolQueuePositionResponse{}, fmt.Errorf("Could not get minipool queue position: %w", err)
+	}
+	var response api.MinipoolQueuePositionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolQueuePositionResponse{}, fmt.Errorf("Could not decode minipool queue position response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolQueuePositionResponse{}, fmt.Errorf("Could not get minipool queue position: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a minipool is eligible for staking
 func (c *Client) CanStakeMinipool(address common.Address) (api.CanStakeMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-stake %s", address.Hex()))
@@ -168,6 +212,22 @@ func (c *Client) CanExitMinipool(address common.Address) (api.CanExitMinipoolRes
 	return response, nil
 }
 
+// Check whether a minipool's validator is eligible to be voluntarily exited
+func (c *Client) MinipoolExitReadiness(address common.Address) (api.MinipoolExitReadinessResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool exit-readiness %s", address.Hex()))
+	if err != nil {
+		return api.MinipoolExitReadinessResponse{}, fmt.Errorf("Could not get minipool exit readiness: %w", err)
+	}
+	var response api.MinipoolExitReadinessResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolExitReadinessResponse{}, fmt.Errorf("Could not decode minipool exit readiness response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolExitReadinessResponse{}, fmt.Errorf("Could not get minipool exit readiness: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Exit a minipool
 func (c *Client) ExitMinipool(address common.Address) (api.ExitMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool exit %s", address.Hex()))
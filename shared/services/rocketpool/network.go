@@ -49,6 +49,69 @@ func (c *Client) RplPrice() (api.RplPriceResponse, error) {
 	return response, nil
 }
 
+// Get the next reportable prices and balances blocks and the estimated time until them
+func (c *Client) NextCheckpoint() (api.NextCheckpointResponse, error) {
+	responseBytes, err := c.callAPI("network next-checkpoint")
+	if err != nil {
+		return api.NextCheckpointResponse{}, fmt.Errorf("Could not get next checkpoint: %w", err)
+	}
+	var response api.NextCheckpointResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NextCheckpointResponse{}, fmt.Errorf("Could not decode next checkpoint response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NextCheckpointResponse{}, fmt.Errorf("Could not get next checkpoint: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the deposit pool balance and capacity, and how many queued deposits it could assign
+func (c *Client) DepositPoolInfo() (api.DepositPoolInfoResponse, error) {
+	responseBytes, err := c.callAPI("network deposit-pool-info")
+	if err != nil {
+		return api.DepositPoolInfoResponse{}, fmt.Errorf("Could not get deposit pool info: %w", err)
+	}
+	var response api.DepositPoolInfoResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DepositPoolInfoResponse{}, fmt.Errorf("Could not decode deposit pool info response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DepositPoolInfoResponse{}, fmt.Errorf("Could not get deposit pool info: %s", response.Error)
+	}
+	if response.Balance == nil {
+		response.Balance = big.NewInt(0)
+	}
+	if response.MaximumSize == nil {
+		response.MaximumSize = big.NewInt(0)
+	}
+	if response.ExcessBalance == nil {
+		response.ExcessBalance = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Get the rETH supply, collateral and exchange rate
+func (c *Client) RethInfo() (api.RethInfoResponse, error) {
+	responseBytes, err := c.callAPI("network reth-info")
+	if err != nil {
+		return api.RethInfoResponse{}, fmt.Errorf("Could not get rETH info: %w", err)
+	}
+	var response api.RethInfoResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RethInfoResponse{}, fmt.Errorf("Could not decode rETH info response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RethInfoResponse{}, fmt.Errorf("Could not get rETH info: %s", response.Error)
+	}
+	if response.TotalSupply == nil {
+		response.TotalSupply = big.NewInt(0)
+	}
+	if response.TotalCollateral == nil {
+		response.TotalCollateral = big.NewInt(0)
+	}
+	return response, nil
+}
+
 // Get network stats
 func (c *Client) NetworkStats() (api.NetworkStatsResponse, error) {
 	responseBytes, err := c.callAPI("network stats")
@@ -128,3 +191,19 @@ func (c *Client) GetActiveDAOProposals() (api.NetworkDAOProposalsResponse, error
 	}
 	return response, nil
 }
+
+// Get the pending and active on-chain protocol DAO proposals
+func (c *Client) GetOnchainDAOProposals() (api.NetworkOnchainDAOProposalsResponse, error) {
+	responseBytes, err := c.callAPI("network onchain-dao-proposals")
+	if err != nil {
+		return api.NetworkOnchainDAOProposalsResponse{}, fmt.Errorf("could not request on-chain DAO proposals: %w", err)
+	}
+	var response api.NetworkOnchainDAOProposalsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkOnchainDAOProposalsResponse{}, fmt.Errorf("could not decode on-chain dao proposals response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkOnchainDAOProposalsResponse{}, fmt.Errorf("error after requesting on-chain dao proposals: %s", response.Error)
+	}
+	return response, nil
+}
@@ -61,6 +61,22 @@ func (c *Client) NodeStatus() (api.NodeStatusResponse, error) {
 	return response, nil
 }
 
+// Get the node's withdrawal address info
+func (c *Client) NodeWithdrawalInfo() (api.NodeWithdrawalInfoResponse, error) {
+	responseBytes, err := c.callAPI("node withdrawal-info")
+	if err != nil {
+		return api.NodeWithdrawalInfoResponse{}, fmt.Errorf("Could not get node withdrawal info: %w", err)
+	}
+	var response api.NodeWithdrawalInfoResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeWithdrawalInfoResponse{}, fmt.Errorf("Could not decode node withdrawal info response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeWithdrawalInfoResponse{}, fmt.Errorf("Could not get node withdrawal info: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can be registered
 func (c *Client) CanRegisterNode(timezoneLocation string) (api.CanRegisterNodeResponse, error) {
 	responseBytes, err := c.callAPI("node can-register", timezoneLocation)
@@ -77,6 +93,37 @@ func (c *Client) CanRegisterNode(timezoneLocation string) (api.CanRegisterNodeRe
 	return response, nil
 }
 
+// Estimate the cost of registering the node at the current gas fees
+func (c *Client) GetRegistrationGasEstimate(timezoneLocation string) (api.RegisterGasEstimateResponse, error) {
+	responseBytes, err := c.callAPI("node get-registration-gas-estimate", timezoneLocation)
+	if err != nil {
+		return api.RegisterGasEstimateResponse{}, fmt.Errorf("Could not get registration gas estimate: %w", err)
+	}
+	var response api.RegisterGasEstimateResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RegisterGasEstimateResponse{}, fmt.Errorf("Could not decode registration gas estimate response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RegisterGasEstimateResponse{}, fmt.Errorf("Could not get registration gas estimate: %s", response.Error)
+	}
+	if response.BaseFee == nil {
+		response.BaseFee = big.NewInt(0)
+	}
+	if response.PriorityFee == nil {
+		response.PriorityFee = big.NewInt(0)
+	}
+	if response.MaxFee == nil {
+		response.MaxFee = big.NewInt(0)
+	}
+	if response.EstimatedCost == nil {
+		response.EstimatedCost = big.NewInt(0)
+	}
+	if response.MaxCost == nil {
+		response.MaxCost = big.NewInt(0)
+	}
+	return response, nil
+}
+
 // Register the node
 func (c *Client) RegisterNode(timezoneLocation string) (api.RegisterNodeResponse, error) {
 	responseBytes, err := c.callAPI("node register", timezoneLocation)
@@ -525,6 +572,59 @@ func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 	return response, nil
 }
 
+// Get the number of the node's minipools waiting in the deposit queue, assigned but not yet staking, and staking
+func (c *Client) NodeQueueSummary() (api.NodeQueueSummaryResponse, error) {
+	responseBytes, err := c.callAPI("node queue-summary")
+	if err != nil {
+		return api.NodeQueueSummaryResponse{}, fmt.Errorf("Could not get node queue summary: %w", err)
+	}
+	var response api.NodeQueueSummaryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeQueueSummaryResponse{}, fmt.Errorf("Could not decode node queue summary response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeQueueSummaryResponse{}, fmt.Errorf("Could not get node queue summary: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get a breakdown of the node's RPL stake
+func (c *Client) NodeStakeBreakdown() (api.NodeStakeBreakdownResponse, error) {
+	responseBytes, err := c.callAPI("node stake-breakdown")
+	if err != nil {
+		return api.NodeStakeBreakdownResponse{}, fmt.Errorf("Could not get node stake breakdown: %w", err)
+	}
+	var response api.NodeStakeBreakdownResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeStakeBreakdownResponse{}, fmt.Errorf("Could not decode node stake breakdown response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeStakeBreakdownResponse{}, fmt.Errorf("Could not get node stake breakdown: %s", response.Error)
+	}
+	if response.RplStake == nil {
+		response.RplStake = big.NewInt(0)
+	}
+	if response.EffectiveRplStake == nil {
+		response.EffectiveRplStake = big.NewInt(0)
+	}
+	if response.MinimumRplStake == nil {
+		response.MinimumRplStake = big.NewInt(0)
+	}
+	if response.MaximumRplStake == nil {
+		response.MaximumRplStake = big.NewInt(0)
+	}
+	if response.RplPrice == nil {
+		response.RplPrice = big.NewInt(0)
+	}
+	if response.RewardableStakeRemaining == nil {
+		response.RewardableStakeRemaining = big.NewInt(0)
+	}
+	if response.WithdrawableRpl == nil {
+		response.WithdrawableRpl = big.NewInt(0)
+	}
+	return response, nil
+}
+
 // Check whether the node has RPL rewards available to claim
 func (c *Client) CanNodeClaimRpl() (api.CanNodeClaimRplResponse, error) {
 	responseBytes, err := c.callAPI("node can-claim-rpl-rewards")
@@ -48,6 +48,22 @@ func (c *Client) TNDAOMembers() (api.TNDAOMembersResponse, error) {
 	return response, nil
 }
 
+// Get oracle DAO members along with their recent participation
+func (c *Client) OdaoMemberStats() (api.OdaoMembersResponse, error) {
+	responseBytes, err := c.callAPI("odao member-stats")
+	if err != nil {
+		return api.OdaoMembersResponse{}, fmt.Errorf("Could not get oracle DAO member stats: %w", err)
+	}
+	var response api.OdaoMembersResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.OdaoMembersResponse{}, fmt.Errorf("Could not decode oracle DAO member stats response: %w", err)
+	}
+	if response.Error != "" {
+		return api.OdaoMembersResponse{}, fmt.Errorf("Could not get oracle DAO member stats: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get oracle DAO proposals
 func (c *Client) TNDAOProposals() (api.TNDAOProposalsResponse, error) {
 	responseBytes, err := c.callAPI("odao proposals")
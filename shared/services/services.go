@@ -16,6 +16,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
@@ -45,6 +46,7 @@ var (
 	snapshotDelegation *contracts.SnapshotDelegation
 	beaconClient       beacon.Client
 	docker             *client.Client
+	stateStore         state.StateStore
 
 	initCfg                sync.Once
 	initPasswordManager    sync.Once
@@ -57,6 +59,7 @@ var (
 	initSnapshotDelegation sync.Once
 	initBeaconClient       sync.Once
 	initDocker             sync.Once
+	initStateStore         sync.Once
 )
 
 //
@@ -67,6 +70,11 @@ func GetConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	return getConfig(c)
 }
 
+// Load a fresh copy of the config from its source, without touching the one the services were created with
+func ReloadConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
+	return loadConfig(c)
+}
+
 func GetPasswordManager(c *cli.Context) (*passwords.PasswordManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -157,6 +165,14 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 	return getDocker()
 }
 
+func GetStateStore(c *cli.Context) (state.StateStore, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getStateStore(cfg), nil
+}
+
 //
 // Service instance getters
 //
@@ -164,15 +180,27 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	var err error
 	initCfg.Do(func() {
-		settingsFile := os.ExpandEnv(c.GlobalString("settings"))
-		cfg, err = rp.LoadConfigFromFile(settingsFile)
-		if cfg == nil && err == nil {
-			err = fmt.Errorf("Settings file [%s] not found.", settingsFile)
-		}
+		cfg, err = loadConfig(c)
 	})
 	return cfg, err
 }
 
+func loadConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
+	settingsFile := os.ExpandEnv(c.GlobalString("settings"))
+	if configUrl := c.GlobalString("configUrl"); configUrl != "" {
+		remoteCfg, err := config.LoadFromUrl(configUrl, settingsFile)
+		if err == nil {
+			return remoteCfg, nil
+		}
+		fmt.Fprintf(os.Stderr, "WARNING: could not load the config from %s, using the settings file instead: %s\n", configUrl, err.Error())
+	}
+	fileCfg, err := rp.LoadConfigFromFile(settingsFile)
+	if fileCfg == nil && err == nil {
+		err = fmt.Errorf("Settings file [%s] not found.", settingsFile)
+	}
+	return fileCfg, err
+}
+
 func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
 	initPasswordManager.Do(func() {
 		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
@@ -296,3 +324,10 @@ func getDocker() (*client.Client, error) {
 	})
 	return docker, err
 }
+
+func getStateStore(cfg *config.RocketPoolConfig) state.StateStore {
+	initStateStore.Do(func() {
+		stateStore = state.NewFilesystemStateStore(os.ExpandEnv(cfg.Smartnode.GetWatchtowerStateFolder()))
+	})
+	return stateStore
+}
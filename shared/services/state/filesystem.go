@@ -0,0 +1,249 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Settings
+const (
+	lockFilename      string        = ".lock"
+	lockRetryInterval time.Duration = 50 * time.Millisecond
+	lockTimeout       time.Duration = 5 * time.Second
+	staleLockAge      time.Duration = 30 * time.Second
+)
+
+// A state store that keeps each key in its own file within a folder.
+// Each file holds a single record with the key's expiration time (0 if it never expires) on the first line and the
+// value after it, so a value and its expiration time are always replaced together. Conditional writes are serialized
+// with a lock file so multiple processes sharing the folder can use it safely. The lock file relies on the local
+// filesystem's exclusive create, so it isn't safe for processes on different hosts sharing a network mount.
+type FilesystemStateStore struct {
+	folder string
+}
+
+// Create a new filesystem state store in the given folder
+func NewFilesystemStateStore(folder string) *FilesystemStateStore {
+	return &FilesystemStateStore{
+		folder: folder,
+	}
+}
+
+func (s *FilesystemStateStore) Get(key string) ([]byte, bool, error) {
+
+	path, err := s.getPath(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return s.read(key, path)
+
+}
+
+func (s *FilesystemStateStore) Set(key string, value []byte) error {
+
+	path, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	return s.write(key, path, value, time.Time{})
+
+}
+
+// Write the record for a key to a temporary file and move it into place, so a crash mid-write never leaves a truncated
+// file behind and readers never see a value without its expiration time
+func (s *FilesystemStateStore) write(key string, path string, value []byte, expires time.Time) error {
+
+	if err := os.MkdirAll(s.folder, 0755); err != nil {
+		return fmt.Errorf("Error creating state folder: %w", err)
+	}
+	tempFile, err := ioutil.TempFile(s.folder, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("Error creating temporary state file for %s: %w", key, err)
+	}
+	tempPath := tempFile.Name()
+	defer func() {
+		_ = os.Remove(tempPath)
+	}()
+
+	if _, err := tempFile.Write(encodeRecord(value, expires)); err != nil {
+		_ = tempFile.Close()
+		return fmt.Errorf("Error writing state for %s: %w", key, err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		_ = tempFile.Close()
+		return fmt.Errorf("Error writing state for %s: %w", key, err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("Error writing state for %s: %w", key, err)
+	}
+	if err := os.Chmod(tempPath, 0644); err != nil {
+		return fmt.Errorf("Error setting permissions on state for %s: %w", key, err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("Error saving state for %s: %w", key, err)
+	}
+	return nil
+
+}
+
+func (s *FilesystemStateStore) Delete(key string) error {
+
+	path, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Error deleting state for %s: %w", key, err)
+	}
+	return nil
+
+}
+
+func (s *FilesystemStateStore) SetIfAbsent(key string, value []byte, ttl time.Duration) (bool, error) {
+
+	path, err := s.getPath(key)
+	if err != nil {
+		return false, err
+	}
+
+	stored := false
+	err = s.withLock(func() error {
+		_, exists, err := s.read(key, path)
+		if err != nil || exists {
+			return err
+		}
+		if err := s.write(key, path, value, time.Now().Add(ttl)); err != nil {
+			return err
+		}
+		stored = true
+		return nil
+	})
+	return stored, err
+
+}
+
+func (s *FilesystemStateStore) Refresh(key string, value []byte, ttl time.Duration) (bool, error) {
+
+	path, err := s.getPath(key)
+	if err != nil {
+		return false, err
+	}
+
+	refreshed := false
+	err = s.withLock(func() error {
+		current, exists, err := s.read(key, path)
+		if err != nil || !exists || !bytes.Equal(current, value) {
+			return err
+		}
+		if err := s.write(key, path, value, time.Now().Add(ttl)); err != nil {
+			return err
+		}
+		refreshed = true
+		return nil
+	})
+	return refreshed, err
+
+}
+
+// Read the value for a key, treating it as missing if it has expired
+func (s *FilesystemStateStore) read(key string, path string) ([]byte, bool, error) {
+
+	record, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("Error reading state for %s: %w", key, err)
+	}
+
+	value, expires, err := decodeRecord(record)
+	if err != nil {
+		return nil, false, fmt.Errorf("Error reading state for %s: %w", key, err)
+	}
+	if !expires.IsZero() && !time.Now().Before(expires) {
+		return nil, false, nil
+	}
+	return value, true, nil
+
+}
+
+// Encode a value and its expiration time into a single record
+func encodeRecord(value []byte, expires time.Time) []byte {
+	expiry := int64(0)
+	if !expires.IsZero() {
+		expiry = expires.UnixNano()
+	}
+	record := []byte(strconv.FormatInt(expiry, 10) + "\n")
+	return append(record, value...)
+}
+
+// Decode a record into its value and expiration time, which is zero if the value never expires
+func decodeRecord(record []byte) ([]byte, time.Time, error) {
+	newline := bytes.IndexByte(record, '\n')
+	if newline < 0 {
+		return nil, time.Time{}, fmt.Errorf("missing expiration time")
+	}
+	expiry, err := strconv.ParseInt(string(record[:newline]), 10, 64)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid expiration time: %w", err)
+	}
+	value := append([]byte{}, record[newline+1:]...)
+	if expiry == 0 {
+		return value, time.Time{}, nil
+	}
+	return value, time.Unix(0, expiry), nil
+}
+
+// Run a function while holding the store's lock file
+func (s *FilesystemStateStore) withLock(action func() error) error {
+
+	if err := os.MkdirAll(s.folder, 0755); err != nil {
+		return fmt.Errorf("Error creating state folder: %w", err)
+	}
+	lockPath := filepath.Join(s.folder, lockFilename)
+
+	// Take the lock, breaking it if the process holding it appears to have died
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_ = lockFile.Close()
+			break
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("Error creating state lock: %w", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timed out waiting for the state lock at %s", lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+	defer func() {
+		_ = os.Remove(lockPath)
+	}()
+
+	return action()
+
+}
+
+// Get the path of the file for a key
+func (s *FilesystemStateStore) getPath(key string) (string, error) {
+	if key == "" || strings.ContainsAny(key, `/\`) || key == "." || key == ".." || key == lockFilename {
+		return "", fmt.Errorf("Invalid state key [%s]", key)
+	}
+	return filepath.Join(s.folder, key), nil
+}
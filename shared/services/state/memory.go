@@ -0,0 +1,100 @@
+package state
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// A value in the in-memory store, with an optional expiration time
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// Check if the entry has expired
+func (e memoryEntry) isExpired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// A state store that only keeps values in memory
+type MemoryStateStore struct {
+	entries map[string]memoryEntry
+	lock    sync.Mutex
+}
+
+// Create a new in-memory state store
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		entries: map[string]memoryEntry{},
+	}
+}
+
+func (s *MemoryStateStore) Get(key string) ([]byte, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entry, exists := s.getEntry(key)
+	if !exists {
+		return nil, false, nil
+	}
+	return append([]byte{}, entry.value...), true, nil
+}
+
+func (s *MemoryStateStore) Set(key string, value []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.entries[key] = memoryEntry{
+		value: append([]byte{}, value...),
+	}
+	return nil
+}
+
+func (s *MemoryStateStore) Delete(key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *MemoryStateStore) SetIfAbsent(key string, value []byte, ttl time.Duration) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, exists := s.getEntry(key); exists {
+		return false, nil
+	}
+	s.entries[key] = memoryEntry{
+		value:   append([]byte{}, value...),
+		expires: time.Now().Add(ttl),
+	}
+	return true, nil
+}
+
+func (s *MemoryStateStore) Refresh(key string, value []byte, ttl time.Duration) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entry, exists := s.getEntry(key)
+	if !exists || !bytes.Equal(entry.value, value) {
+		return false, nil
+	}
+	entry.expires = time.Now().Add(ttl)
+	s.entries[key] = entry
+	return true, nil
+}
+
+// Get an entry, removing it if it has expired. The caller must hold the lock.
+func (s *MemoryStateStore) getEntry(key string) (memoryEntry, bool) {
+	entry, exists := s.entries[key]
+	if !exists {
+		return memoryEntry{}, false
+	}
+	if entry.isExpired(time.Now()) {
+		delete(s.entries, key)
+		return memoryEntry{}, false
+	}
+	return entry, true
+}
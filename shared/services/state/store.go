@@ -0,0 +1,23 @@
+package state
+
+import "time"
+
+// Persistent storage for the daemon's local state, such as scan progress and alert history
+type StateStore interface {
+	// Get the value stored under a key, and whether or not it exists
+	Get(key string) ([]byte, bool, error)
+
+	// Store a value under a key, replacing the existing value (and any expiration time) if there is one
+	Set(key string, value []byte) error
+
+	// Remove the value stored under a key; this is a no-op if it doesn't exist
+	Delete(key string) error
+
+	// Atomically store a value that expires after the TTL, but only if the key doesn't exist or has expired.
+	// Returns true if the value was stored.
+	SetIfAbsent(key string, value []byte, ttl time.Duration) (bool, error)
+
+	// Atomically reset the expiration time of a key to the TTL, but only if it still holds the given value.
+	// Returns true if the key was refreshed.
+	Refresh(key string, value []byte, ttl time.Duration) (bool, error)
+}
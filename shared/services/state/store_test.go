@@ -0,0 +1,279 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Create a filesystem store in a new temporary folder, returning a function that removes it
+func newTestFilesystemStore(t *testing.T) (*FilesystemStateStore, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "state-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewFilesystemStateStore(dir), func() {
+		os.RemoveAll(dir)
+	}
+}
+
+// Fail the test if a key doesn't hold the expected value
+func requireValue(t *testing.T, store StateStore, key string, want []byte) {
+	t.Helper()
+	value, exists, err := store.Get(key)
+	if err != nil {
+		t.Fatalf("Get(%s) returned an error: %s", key, err)
+	}
+	if want == nil {
+		if exists {
+			t.Fatalf("Get(%s) = %q, want no value", key, value)
+		}
+		return
+	}
+	if !exists || !bytes.Equal(value, want) {
+		t.Fatalf("Get(%s) = (%q, %t), want %q", key, value, exists, want)
+	}
+}
+
+// Check the basic behavior every state store must have
+func testStateStore(t *testing.T, store StateStore) {
+	requireValue(t, store, "key", nil)
+
+	if err := store.Set("key", []byte("first")); err != nil {
+		t.Fatal(err)
+	}
+	requireValue(t, store, "key", []byte("first"))
+
+	if err := store.Set("key", []byte("second")); err != nil {
+		t.Fatal(err)
+	}
+	requireValue(t, store, "key", []byte("second"))
+	requireValue(t, store, "other", nil)
+
+	if err := store.Delete("key"); err != nil {
+		t.Fatal(err)
+	}
+	requireValue(t, store, "key", nil)
+	if err := store.Delete("key"); err != nil {
+		t.Fatalf("Delete() of a missing key returned an error: %s", err)
+	}
+}
+
+func TestMemoryStateStore(t *testing.T) {
+	testStateStore(t, NewMemoryStateStore())
+}
+
+func TestMemoryStateStoreCopiesValues(t *testing.T) {
+	store := NewMemoryStateStore()
+	value := []byte("value")
+	if err := store.Set("key", value); err != nil {
+		t.Fatal(err)
+	}
+	value[0] = 'X'
+	requireValue(t, store, "key", []byte("value"))
+
+	stored, _, _ := store.Get("key")
+	stored[0] = 'X'
+	requireValue(t, store, "key", []byte("value"))
+}
+
+func TestFilesystemStateStore(t *testing.T) {
+	store, cleanup := newTestFilesystemStore(t)
+	defer cleanup()
+	testStateStore(t, store)
+}
+
+func TestFilesystemStateStorePersists(t *testing.T) {
+	store, cleanup := newTestFilesystemStore(t)
+	defer cleanup()
+	if err := store.Set("key", []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	requireValue(t, NewFilesystemStateStore(store.folder), "key", []byte("value"))
+}
+
+func TestFilesystemStateStoreInvalidKeys(t *testing.T) {
+	store, cleanup := newTestFilesystemStore(t)
+	defer cleanup()
+	for _, key := range []string{"", ".", "..", "a/b", `a\b`, "../escape"} {
+		if err := store.Set(key, []byte("value")); err == nil {
+			t.Errorf("Set(%q) should fail for an invalid key", key)
+		}
+		if _, _, err := store.Get(key); err == nil {
+			t.Errorf("Get(%q) should fail for an invalid key", key)
+		}
+	}
+}
+
+// Check the lease behavior every state store must have
+func testStateStoreLease(t *testing.T, store StateStore) {
+	const ttl = 200 * time.Millisecond
+
+	// Only the first holder can take the key
+	stored, err := store.SetIfAbsent("lease", []byte("a"), ttl)
+	if err != nil || !stored {
+		t.Fatalf("SetIfAbsent() = (%t, %v), want (true, nil) for a free key", stored, err)
+	}
+	stored, err = store.SetIfAbsent("lease", []byte("b"), ttl)
+	if err != nil || stored {
+		t.Fatalf("SetIfAbsent() = (%t, %v), want (false, nil) for a held key", stored, err)
+	}
+	requireValue(t, store, "lease", []byte("a"))
+
+	// Only the holder can refresh it
+	refreshed, err := store.Refresh("lease", []byte("b"), ttl)
+	if err != nil || refreshed {
+		t.Fatalf("Refresh() = (%t, %v), want (false, nil) for another holder's key", refreshed, err)
+	}
+	time.Sleep(ttl / 2)
+	refreshed, err = store.Refresh("lease", []byte("a"), ttl)
+	if err != nil || !refreshed {
+		t.Fatalf("Refresh() = (%t, %v), want (true, nil) for the holder's key", refreshed, err)
+	}
+
+	// The refresh pushed the expiration back
+	time.Sleep(ttl * 3 / 4)
+	requireValue(t, store, "lease", []byte("a"))
+
+	// Once it expires, anyone can take it
+	time.Sleep(ttl)
+	requireValue(t, store, "lease", nil)
+	refreshed, err = store.Refresh("lease", []byte("a"), ttl)
+	if err != nil || refreshed {
+		t.Fatalf("Refresh() = (%t, %v), want (false, nil) for an expired key", refreshed, err)
+	}
+	stored, err = store.SetIfAbsent("lease", []byte("b"), ttl)
+	if err != nil || !stored {
+		t.Fatalf("SetIfAbsent() = (%t, %v), want (true, nil) for an expired key", stored, err)
+	}
+	requireValue(t, store, "lease", []byte("b"))
+
+	// Set clears the expiration
+	if err := store.Set("lease", []byte("c")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(ttl * 3 / 2)
+	requireValue(t, store, "lease", []byte("c"))
+}
+
+func TestMemoryStateStoreLease(t *testing.T) {
+	testStateStoreLease(t, NewMemoryStateStore())
+}
+
+func TestFilesystemStateStoreLease(t *testing.T) {
+	store, cleanup := newTestFilesystemStore(t)
+	defer cleanup()
+	testStateStoreLease(t, store)
+}
+
+// Check that only one of several concurrent SetIfAbsent calls for a free key stores its value
+func testStateStoreSetIfAbsentRace(t *testing.T, store StateStore) {
+	const contenders = 8
+	var wg sync.WaitGroup
+	results := make(chan string, contenders)
+	for i := 0; i < contenders; i++ {
+		wg.Add(1)
+		go func(value string) {
+			defer wg.Done()
+			stored, err := store.SetIfAbsent("lease", []byte(value), time.Minute)
+			if err != nil {
+				t.Errorf("SetIfAbsent() returned an error: %s", err)
+			}
+			if stored {
+				results <- value
+			}
+		}(fmt.Sprintf("contender-%d", i))
+	}
+	wg.Wait()
+	close(results)
+
+	winners := []string{}
+	for value := range results {
+		winners = append(winners, value)
+	}
+	if len(winners) != 1 {
+		t.Fatalf("%d contenders stored the key (%v), want exactly 1", len(winners), winners)
+	}
+	requireValue(t, store, "lease", []byte(winners[0]))
+}
+
+func TestMemoryStateStoreSetIfAbsentRace(t *testing.T) {
+	testStateStoreSetIfAbsentRace(t, NewMemoryStateStore())
+}
+
+func TestFilesystemStateStoreSetIfAbsentRace(t *testing.T) {
+	store, cleanup := newTestFilesystemStore(t)
+	defer cleanup()
+	testStateStoreSetIfAbsentRace(t, store)
+}
+
+func TestFilesystemStateStoreSingleRecord(t *testing.T) {
+	store, cleanup := newTestFilesystemStore(t)
+	defer cleanup()
+
+	// A value and its expiration time are kept in one file, so nothing else is left in the folder
+	stored, err := store.SetIfAbsent("lease", []byte("a"), 100*time.Millisecond)
+	if err != nil || !stored {
+		t.Fatalf("SetIfAbsent() = (%t, %v), want (true, nil)", stored, err)
+	}
+	files, err := ioutil.ReadDir(store.folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != "lease" {
+		names := []string{}
+		for _, file := range files {
+			names = append(names, file.Name())
+		}
+		t.Fatalf("state folder holds %v, want only the lease", names)
+	}
+
+	// Another store on the same folder sees the value until it expires
+	other := NewFilesystemStateStore(store.folder)
+	requireValue(t, other, "lease", []byte("a"))
+	time.Sleep(150 * time.Millisecond)
+	requireValue(t, other, "lease", nil)
+
+	// Replacing a leased value clears its expiration time along with it
+	if err := store.Set("lease", []byte("b")); err != nil {
+		t.Fatal(err)
+	}
+	requireValue(t, other, "lease", []byte("b"))
+}
+
+func TestDecodeRecord(t *testing.T) {
+	expires := time.Unix(0, 1700000000123456789)
+	tests := []struct {
+		name        string
+		record      []byte
+		wantValue   []byte
+		wantExpires time.Time
+		wantErr     bool
+	}{
+		{"no expiration", encodeRecord([]byte("value"), time.Time{}), []byte("value"), time.Time{}, false},
+		{"expiration", encodeRecord([]byte("value"), expires), []byte("value"), expires, false},
+		{"value with newlines", encodeRecord([]byte("a\nb\n"), expires), []byte("a\nb\n"), expires, false},
+		{"empty value", encodeRecord([]byte{}, time.Time{}), []byte{}, time.Time{}, false},
+		{"missing header", []byte("value"), nil, time.Time{}, true},
+		{"invalid expiration", []byte("soon\nvalue"), nil, time.Time{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, expires, err := decodeRecord(test.record)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("decodeRecord() error = %v, want error %t", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if !bytes.Equal(value, test.wantValue) || !expires.Equal(test.wantExpires) {
+				t.Errorf("decodeRecord() = (%q, %s), want (%q, %s)", value, expires, test.wantValue, test.wantExpires)
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -65,6 +67,17 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
+
+	// Run the guard right before signing, which is the last step before the transaction is sent
+	if guard := w.transactorGuard; guard != nil {
+		signer := transactor.Signer
+		transactor.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if err := guard(); err != nil {
+				return nil, err
+			}
+			return signer(address, tx)
+		}
+	}
 	return transactor, err
 
 }
@@ -88,6 +101,23 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
 }
 
+// Get the derivation path of the node account
+func (w *Wallet) GetNodeDerivationPath() (string, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return "", errors.New("Wallet is not initialized")
+	}
+
+	// Get the path
+	_, path, err := w.getNodePrivateKey()
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+
+}
+
 // Get the node private key
 func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 
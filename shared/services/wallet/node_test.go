@@ -0,0 +1,107 @@
+package wallet
+
+import (
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+)
+
+// A well-known mnemonic used by Ethereum development tools
+const testMnemonic string = "test test test test test test test test test test test junk"
+
+func TestGetNodeDerivationPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name           string
+		derivationPath string
+		walletIndex    uint
+		want           string
+	}{
+		{"default path", DefaultNodeKeyPath, 0, "m/44'/60'/0'/0/0"},
+		{"default path with an index", DefaultNodeKeyPath, 3, "m/44'/60'/0'/0/3"},
+		{"Ledger Live path", LedgerLiveNodeKeyPath, 2, "m/44'/60'/2/0/0"},
+		{"MyEtherWallet path", MyEtherWalletNodeKeyPath, 1, "m/44'/60'/0'/1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+			w, err := NewWallet(filepath.Join(dir, "wallet"), 1, nil, nil, 0, pm)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The path isn't known until the wallet is initialized
+			if _, err := w.GetNodeDerivationPath(); err == nil {
+				t.Fatal("GetNodeDerivationPath() didn't return an error for an uninitialized wallet")
+			}
+
+			if err := w.TestRecovery(test.derivationPath, test.walletIndex, testMnemonic); err != nil {
+				t.Fatal(err)
+			}
+			got, err := w.GetNodeDerivationPath()
+			if err != nil {
+				t.Fatalf("GetNodeDerivationPath() returned an error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("GetNodeDerivationPath() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTransactorGuard(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	w, err := NewWallet(filepath.Join(dir, "wallet"), 1, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.TestRecovery(DefaultNodeKeyPath, 0, testMnemonic); err != nil {
+		t.Fatal(err)
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID: big.NewInt(1),
+		Nonce:   1,
+	})
+
+	// Transactions are signed as usual while the guard passes
+	guardErr := error(nil)
+	checks := 0
+	w.SetTransactorGuard(func() error {
+		checks++
+		return guardErr
+	})
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := opts.Signer(opts.From, tx); err != nil {
+		t.Fatalf("Signer() returned an error with the guard passing: %s", err)
+	}
+
+	// The guard is checked again on each signature, so a transactor created earlier is refused once it fails
+	guardErr = errors.New("not the leader")
+	if _, err := opts.Signer(opts.From, tx); err != guardErr {
+		t.Fatalf("Signer() = %v with the guard failing, want %v", err, guardErr)
+	}
+	if checks != 2 {
+		t.Errorf("the guard was checked %d times, want 2", checks)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/google/uuid"
 	"github.com/tyler-smith/go-bip39"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -63,6 +64,9 @@ type Wallet struct {
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+
+	// Check run before each node account transaction is signed
+	transactorGuard func() error
 }
 
 // Encrypted wallet store
@@ -109,6 +113,12 @@ func (w *Wallet) GetChainID() *big.Int {
 	return copy
 }
 
+// Set a check that runs right before each transaction from the node account is signed; the transaction is refused if
+// it returns an error
+func (w *Wallet) SetTransactorGuard(guard func() error) {
+	w.transactorGuard = guard
+}
+
 // Add a keystore to the wallet
 func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
@@ -320,6 +330,28 @@ func (w *Wallet) SignMessage(message string) ([]byte, error) {
 	return signedMessage, nil
 }
 
+// Signs EIP-712 typed data using the wallet's private key
+func (w *Wallet) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	// Get the wallet's private key
+	privateKey, _, err := w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	dataHash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error hashing typed data: %w", err)
+	}
+	signedData, err := crypto.Sign(dataHash, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing typed data: %w", err)
+	}
+
+	// fix the ECDSA 'v' the same way as for messages
+	signedData[crypto.RecoveryIDOffset] += 27
+	return signedData, nil
+}
+
 // Reloads wallet from disk
 func (w *Wallet) Reload() error {
 	_, err := w.loadStore()
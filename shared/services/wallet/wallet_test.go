@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+)
+
+func TestSignTypedData(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	w, err := NewWallet(filepath.Join(dir, "wallet"), 1, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.TestRecovery(DefaultNodeKeyPath, 0, testMnemonic); err != nil {
+		t.Fatal(err)
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{{Name: "name", Type: "string"}},
+			"Mail":         []apitypes.Type{{Name: "contents", Type: "string"}},
+		},
+		PrimaryType: "Mail",
+		Domain:      apitypes.TypedDataDomain{Name: "Test"},
+		Message:     apitypes.TypedDataMessage{"contents": "hello"},
+	}
+	signature, err := w.SignTypedData(typedData)
+	if err != nil {
+		t.Fatalf("SignTypedData() returned an error: %s", err)
+	}
+	if len(signature) != crypto.SignatureLength || signature[crypto.RecoveryIDOffset] < 27 {
+		t.Fatalf("SignTypedData() returned a malformed signature %x", signature)
+	}
+
+	// The signature recovers to the node account
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recoverable := append([]byte{}, signature...)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+	publicKey, err := crypto.SigToPub(hash, recoverable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer := crypto.PubkeyToAddress(*publicKey); signer != nodeAccount.Address {
+		t.Errorf("signature recovers to %s, want %s", signer.Hex(), nodeAccount.Address.Hex())
+	}
+}
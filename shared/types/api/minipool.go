@@ -41,11 +41,29 @@ type MinipoolDetails struct {
 	Penalties           uint64                 `json:"penalties"`
 }
 type ValidatorDetails struct {
-	Exists      bool     `json:"exists"`
-	Active      bool     `json:"active"`
-	Index       uint64   `json:"index"`
-	Balance     *big.Int `json:"balance"`
-	NodeBalance *big.Int `json:"nodeBalance"`
+	Exists           bool     `json:"exists"`
+	Active           bool     `json:"active"`
+	Index            uint64   `json:"index"`
+	BeaconStatus     string   `json:"beaconStatus"`
+	Balance          *big.Int `json:"balance"`
+	EffectiveBalance *big.Int `json:"effectiveBalance"`
+	NodeBalance      *big.Int `json:"nodeBalance"`
+}
+
+type MinipoolRefundInfoResponse struct {
+	Status          string               `json:"status"`
+	Error           string               `json:"error"`
+	Minipools       []MinipoolRefundInfo `json:"minipools"`
+	TotalRefundable *big.Int             `json:"totalRefundable"`
+}
+type MinipoolRefundInfo struct {
+	Address           common.Address       `json:"address"`
+	Status            types.MinipoolStatus `json:"status"`
+	RefundBalance     *big.Int             `json:"refundBalance"`
+	RefundAvailable   bool                 `json:"refundAvailable"`
+	Finalised         bool                 `json:"finalised"`
+	FinaliseAvailable bool                 `json:"finaliseAvailable"`
+	CloseAvailable    bool                 `json:"closeAvailable"`
 }
 
 type CanRefundMinipoolResponse struct {
@@ -80,6 +98,19 @@ type CanExitMinipoolResponse struct {
 	CanExit       bool   `json:"canExit"`
 	InvalidStatus bool   `json:"invalidStatus"`
 }
+type MinipoolExitReadinessResponse struct {
+	Status               string `json:"status"`
+	Error                string `json:"error"`
+	CanExit              bool   `json:"canExit"`
+	ValidatorMissing     bool   `json:"validatorMissing"`
+	InActivationQueue    bool   `json:"inActivationQueue"`
+	AlreadyExiting       bool   `json:"alreadyExiting"`
+	TooRecentlyActivated bool   `json:"tooRecentlyActivated"`
+	ValidatorStatus      string `json:"validatorStatus"`
+	ActivationEpoch      uint64 `json:"activationEpoch"`
+	ExitableEpoch        uint64 `json:"exitableEpoch"`
+	CurrentEpoch         uint64 `json:"currentEpoch"`
+}
 type ExitMinipoolResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
@@ -171,6 +202,16 @@ type SetUseLatestDelegateResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type MinipoolQueuePositionResponse struct {
+	Status          string `json:"status"`
+	Error           string `json:"error"`
+	InQueue         bool   `json:"inQueue"`
+	Queue           string `json:"queue"`
+	Position        uint64 `json:"position"`
+	OverallPosition uint64 `json:"overallPosition"`
+	TotalLength     uint64 `json:"totalLength"`
+}
+
 type CanStakeMinipoolResponse struct {
 	Status   string             `json:"status"`
 	Error    string             `json:"error"`
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
 )
 
 type NodeFeeResponse struct {
@@ -24,6 +25,36 @@ type RplPriceResponse struct {
 	MaxPerMinipoolRplStake *big.Int `json:"maxPerMinipoolRplStake"`
 }
 
+type NextCheckpointResponse struct {
+	Status           string  `json:"status"`
+	Error            string  `json:"error"`
+	CurrentBlock     uint64  `json:"currentBlock"`
+	AverageBlockTime float64 `json:"averageBlockTime"`
+	PricesBlock      uint64  `json:"pricesBlock"`
+	PricesSeconds    uint64  `json:"pricesSeconds"`
+	BalancesBlock    uint64  `json:"balancesBlock"`
+	BalancesSeconds  uint64  `json:"balancesSeconds"`
+}
+
+type DepositPoolInfoResponse struct {
+	Status                 string   `json:"status"`
+	Error                  string   `json:"error"`
+	Balance                *big.Int `json:"balance"`
+	MaximumSize            *big.Int `json:"maximumSize"`
+	ExcessBalance          *big.Int `json:"excessBalance"`
+	HalfDepositsAssignable uint64   `json:"halfDepositsAssignable"`
+	FullDepositsAssignable uint64   `json:"fullDepositsAssignable"`
+}
+
+type RethInfoResponse struct {
+	Status          string   `json:"status"`
+	Error           string   `json:"error"`
+	TotalSupply     *big.Int `json:"totalSupply"`
+	TotalCollateral *big.Int `json:"totalCollateral"`
+	ExchangeRate    float64  `json:"exchangeRate"`
+	CollateralRate  float64  `json:"collateralRate"`
+}
+
 type NetworkStatsResponse struct {
 	Status                    string         `json:"status"`
 	Error                     string         `json:"error"`
@@ -75,4 +106,16 @@ type NetworkDAOProposalsResponse struct {
 	VotingDelegate          common.Address         `json:"votingDelegate"`
 	ActiveSnapshotProposals []SnapshotProposal     `json:"activeSnapshotProposals"`
 	ProposalVotes           []SnapshotProposalVote `json:"proposalVotes"`
+	NodeProposalVotes       []SnapshotProposalVote `json:"nodeProposalVotes"`
+	DelegateProposalVotes   []SnapshotProposalVote `json:"delegateProposalVotes"`
+	ProposalsError          string                 `json:"proposalsError"`
+	VotingDelegateError     string                 `json:"votingDelegateError"`
+	ProposalVotesError      string                 `json:"proposalVotesError"`
+}
+
+type NetworkOnchainDAOProposalsResponse struct {
+	Status         string                `json:"status"`
+	Error          string                `json:"error"`
+	AccountAddress common.Address        `json:"accountAddress"`
+	Proposals      []dao.ProposalDetails `json:"proposals"`
 }
@@ -54,10 +54,19 @@ type NodeStatusResponse struct {
 	SnapshotResponse            struct {
 		Error                   string                 `json:"error"`
 		ProposalVotes           []SnapshotProposalVote `json:"proposalVotes"`
+		NodeProposalVotes       []SnapshotProposalVote `json:"nodeProposalVotes"`
+		DelegateProposalVotes   []SnapshotProposalVote `json:"delegateProposalVotes"`
 		ActiveSnapshotProposals []SnapshotProposal     `json:"activeSnapshotProposals"`
 	} `json:"snapshotResponse"`
 }
 
+type NodeWithdrawalInfoResponse struct {
+	Status         string                `json:"status"`
+	Error          string                `json:"error"`
+	NodeAddress    common.Address        `json:"nodeAddress"`
+	WithdrawalInfo rp.NodeWithdrawalInfo `json:"withdrawalInfo"`
+}
+
 type CanRegisterNodeResponse struct {
 	Status               string             `json:"status"`
 	Error                string             `json:"error"`
@@ -66,6 +75,16 @@ type CanRegisterNodeResponse struct {
 	RegistrationDisabled bool               `json:"registrationDisabled"`
 	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
 }
+type RegisterGasEstimateResponse struct {
+	Status        string             `json:"status"`
+	Error         string             `json:"error"`
+	GasInfo       rocketpool.GasInfo `json:"gasInfo"`
+	BaseFee       *big.Int           `json:"baseFee"`
+	PriorityFee   *big.Int           `json:"priorityFee"`
+	MaxFee        *big.Int           `json:"maxFee"`
+	EstimatedCost *big.Int           `json:"estimatedCost"`
+	MaxCost       *big.Int           `json:"maxCost"`
+}
 type RegisterNodeResponse struct {
 	Status string      `json:"status"`
 	Error  string      `json:"error"`
@@ -177,6 +196,28 @@ type NodeStakeRplAllowanceResponse struct {
 	Allowance *big.Int `json:"allowance"`
 }
 
+type NodeStakeBreakdownResponse struct {
+	Status                   string   `json:"status"`
+	Error                    string   `json:"error"`
+	RplStake                 *big.Int `json:"rplStake"`
+	EffectiveRplStake        *big.Int `json:"effectiveRplStake"`
+	MinimumRplStake          *big.Int `json:"minimumRplStake"`
+	MaximumRplStake          *big.Int `json:"maximumRplStake"`
+	RplPrice                 *big.Int `json:"rplPrice"`
+	ActiveMinipools          uint64   `json:"activeMinipools"`
+	CollateralRatio          float64  `json:"collateralRatio"`
+	RewardableStakeRemaining *big.Int `json:"rewardableStakeRemaining"`
+	WithdrawableRpl          *big.Int `json:"withdrawableRpl"`
+}
+
+type NodeQueueSummaryResponse struct {
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+	Queued    uint64 `json:"queued"`
+	Prelaunch uint64 `json:"prelaunch"`
+	Staking   uint64 `json:"staking"`
+}
+
 type CanNodeWithdrawRplResponse struct {
 	Status                       string             `json:"status"`
 	Error                        string             `json:"error"`
@@ -421,6 +462,7 @@ type SnapshotProposal struct {
 	ScoresTotal   float64   `json:"scores_total"`
 	ScoresUpdated int64     `json:"scores_updated"`
 	Quorum        int64     `json:"quorum"`
+	QuorumReached bool      `json:"quorumReached"`
 	Link          string    `json:"link"`
 }
 type SnapshotResponse struct {
@@ -430,6 +472,11 @@ type SnapshotResponse struct {
 		Proposals []SnapshotProposal `json:"proposals"`
 	}
 }
+type SnapshotProposalResponse struct {
+	Data struct {
+		Proposal *SnapshotProposal `json:"proposal"`
+	} `json:"data"`
+}
 type SnapshotVotingPower struct {
 	Data struct {
 		Vp struct {
@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao"
@@ -29,12 +30,44 @@ type TNDAOStatusResponse struct {
 	} `json:"proposalCounts"`
 }
 
+type ChallengesResponse struct {
+	Status           string             `json:"status"`
+	Error            string             `json:"error"`
+	NodeAddress      common.Address     `json:"nodeAddress"`
+	IsMember         bool               `json:"isMember"`
+	IsChallenged     bool               `json:"isChallenged"`
+	ChallengedBy     common.Address     `json:"challengedBy"`
+	ChallengeTime    time.Time          `json:"challengeTime"`
+	ChallengeWindow  uint64             `json:"challengeWindow"`
+	ResponseDeadline time.Time          `json:"responseDeadline"`
+	CanResolve       bool               `json:"canResolve"`
+	GasInfo          rocketpool.GasInfo `json:"gasInfo"`
+}
+
 type TNDAOMembersResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
 	Members []tn.MemberDetails `json:"members"`
 }
 
+type OdaoMemberStats struct {
+	Address               common.Address `json:"address"`
+	ID                    string         `json:"id"`
+	Url                   string         `json:"url"`
+	JoinedTime            time.Time      `json:"joinedTime"`
+	PricesParticipation   float64        `json:"pricesParticipation"`
+	PricesEligible        bool           `json:"pricesEligible"`
+	BalancesParticipation float64        `json:"balancesParticipation"`
+	BalancesEligible      bool           `json:"balancesEligible"`
+}
+
+type OdaoMembersResponse struct {
+	Status              string            `json:"status"`
+	Error               string            `json:"error"`
+	ParticipationWindow uint64            `json:"participationWindow"`
+	Members             []OdaoMemberStats `json:"members"`
+}
+
 type TNDAOProposalsResponse struct {
 	Status    string                `json:"status"`
 	Error     string                `json:"error"`
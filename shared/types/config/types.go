@@ -9,6 +9,9 @@ type ConsensusClient string
 type RewardsMode string
 type MevRelayID string
 type MevSelectionMode string
+type GasPriceSource string
+type PriceFeedMethod string
+type MedianTieBreak string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -81,6 +84,29 @@ const (
 	RewardsMode_Generate RewardsMode = "generate"
 )
 
+// Enum to describe where the watchtower gets the max fee for its price submissions
+const (
+	GasPriceSource_Unknown         GasPriceSource = ""
+	GasPriceSource_Fixed           GasPriceSource = "fixed"
+	GasPriceSource_ExecutionClient GasPriceSource = "executionClient"
+	GasPriceSource_GasStation      GasPriceSource = "gasStation"
+)
+
+// Enum to describe the method used to read a price feed contract
+const (
+	PriceFeedMethod_Unknown         PriceFeedMethod = ""
+	PriceFeedMethod_LatestRoundData PriceFeedMethod = "latestRoundData"
+	PriceFeedMethod_LatestAnswer    PriceFeedMethod = "latestAnswer"
+)
+
+// Enum to describe how the median of an even number of prices is chosen
+const (
+	MedianTieBreak_Unknown MedianTieBreak = ""
+	MedianTieBreak_Average MedianTieBreak = "average"
+	MedianTieBreak_Lower   MedianTieBreak = "lower"
+	MedianTieBreak_Higher  MedianTieBreak = "higher"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
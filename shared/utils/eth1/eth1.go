@@ -9,7 +9,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -62,6 +61,14 @@ func CheckForNonceOverride(c *cli.Context, opts *bind.TransactOpts) error {
 
 }
 
+// Check if an error from the EC indicates that it doesn't have the state for the requested block
+func IsMissingStateError(err error) bool {
+	errMessage := err.Error()
+	return strings.Contains(errMessage, "missing trie node") || // Geth
+		strings.Contains(errMessage, "No state available for block") || // Nethermind
+		strings.Contains(errMessage, "Internal error") // Besu
+}
+
 // Determines if the primary EC can be used for historical queries, or if the Archive EC is required
 func GetBestApiClient(primary *rocketpool.RocketPool, cfg *config.RocketPoolConfig, printMessage func(string), blockNumber *big.Int) (*rocketpool.RocketPool, error) {
 
@@ -75,15 +82,13 @@ func GetBestApiClient(primary *rocketpool.RocketPool, cfg *config.RocketPoolConf
 	if err != nil {
 		errMessage := err.Error()
 		printMessage(fmt.Sprintf("Error getting state for block %d: %s", blockNumber.Uint64(), errMessage))
-		if strings.Contains(errMessage, "missing trie node") || // Geth
-			strings.Contains(errMessage, "No state available for block") || // Nethermind
-			strings.Contains(errMessage, "Internal error") { // Besu
+		if IsMissingStateError(err) {
 
 			// The state was missing so fall back to the archive node
 			archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
 			if archiveEcUrl != "" {
 				printMessage(fmt.Sprintf("Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", blockNumber.Uint64(), archiveEcUrl))
-				ec, err := ethclient.Dial(archiveEcUrl)
+				ec, err := services.DialEc(cfg, archiveEcUrl)
 				if err != nil {
 					return nil, fmt.Errorf("Error connecting to archive EC: %w", err)
 				}
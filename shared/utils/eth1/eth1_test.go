@@ -0,0 +1,26 @@
+package eth1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsMissingStateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"geth", errors.New("missing trie node 0x1234 (path )"), true},
+		{"nethermind", errors.New("No state available for block 0x1234"), true},
+		{"besu", errors.New("Internal error"), true},
+		{"other error", errors.New("connection refused"), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsMissingStateError(test.err); got != test.want {
+				t.Errorf("IsMissingStateError(%q) = %t, want %t", test.err, got, test.want)
+			}
+		})
+	}
+}
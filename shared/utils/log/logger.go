@@ -1,6 +1,7 @@
 package log
 
 import (
+	"io"
 	"log"
 
 	"github.com/fatih/color"
@@ -9,11 +10,12 @@ import (
 // Logger with ANSI color output
 type ColorLogger struct {
 	Color       color.Attribute
+	Output      io.Writer
 	sprintFunc  func(a ...interface{}) string
 	sprintfFunc func(format string, a ...interface{}) string
 }
 
-// Create new color logger
+// Create new color logger, writing to the standard logger's output
 func NewColorLogger(colorAttr color.Attribute) ColorLogger {
 	return ColorLogger{
 		Color:       colorAttr,
@@ -24,20 +26,28 @@ func NewColorLogger(colorAttr color.Attribute) ColorLogger {
 
 // Print values
 func (l *ColorLogger) Print(v ...interface{}) {
-	log.Print(l.sprintFunc(v...))
+	l.logger().Print(l.sprintFunc(v...))
 }
 
 // Print values with a newline
 func (l *ColorLogger) Println(v ...interface{}) {
-	log.Println(l.sprintFunc(v...))
+	l.logger().Println(l.sprintFunc(v...))
 }
 
 // Print a formatted string
 func (l *ColorLogger) Printf(format string, v ...interface{}) {
-	log.Print(l.sprintfFunc(format, v...))
+	l.logger().Print(l.sprintfFunc(format, v...))
 }
 
 // Print a formatted string with a newline
 func (l *ColorLogger) Printlnf(format string, v ...interface{}) {
-	log.Println(l.sprintfFunc(format, v...))
+	l.logger().Println(l.sprintfFunc(format, v...))
+}
+
+// Get the logger to write to; the standard logger is used unless an output has been set
+func (l *ColorLogger) logger() *log.Logger {
+	if l.Output == nil {
+		return log.Default()
+	}
+	return log.New(l.Output, log.Prefix(), log.Flags())
 }
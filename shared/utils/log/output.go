@@ -0,0 +1,119 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// Matches ANSI color escape sequences
+var colorCodePattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// A writer that removes ANSI color codes before passing output on
+type colorStripper struct {
+	writer io.Writer
+}
+
+// Create a writer that strips color codes from everything written to w
+func NewColorStripper(w io.Writer) io.Writer {
+	return &colorStripper{
+		writer: w,
+	}
+}
+
+// Write the output without color codes; the length of the original output is returned so callers don't treat the
+// removed codes as a short write
+func (s *colorStripper) Write(p []byte) (int, error) {
+	if _, err := s.writer.Write(colorCodePattern.ReplaceAll(p, nil)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// A file writer that rotates the file once it reaches a maximum size, keeping one previous file with a `.1` suffix
+type RotatingFileWriter struct {
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+	lock    sync.Mutex
+}
+
+// Create a rotating file writer, appending to the file at path if it already exists
+func NewRotatingFileWriter(path string, maxSize int64) (*RotatingFileWriter, error) {
+	w := &RotatingFileWriter{
+		path:    path,
+		maxSize: maxSize,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write to the file, rotating it first if the write would take it past the max size
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close the file
+func (w *RotatingFileWriter) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.file.Close()
+}
+
+// Open the log file for appending
+func (w *RotatingFileWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("Error opening log file %s: %w", w.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("Error getting info for log file %s: %w", w.path, err)
+	}
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+// Move the current file aside and start a new one
+func (w *RotatingFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("Error closing log file %s: %w", w.path, err)
+	}
+	if err := os.Rename(w.path, w.path+".1"); err != nil {
+		return fmt.Errorf("Error rotating log file %s: %w", w.path, err)
+	}
+	return w.open()
+}
+
+// Get the writer logs should go to: the console, a rotating file with the color codes removed, or both
+func NewOutput(console io.Writer, path string, maxSizeMb uint64, logToConsole bool) (io.Writer, error) {
+	if path == "" {
+		return console, nil
+	}
+	file, err := NewRotatingFileWriter(path, int64(maxSizeMb)*1024*1024)
+	if err != nil {
+		return nil, err
+	}
+	fileOutput := NewColorStripper(file)
+	if !logToConsole {
+		return fileOutput, nil
+	}
+	return io.MultiWriter(console, fileOutput), nil
+}
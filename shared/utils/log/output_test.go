@@ -0,0 +1,126 @@
+package log
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestColorStripper(t *testing.T) {
+	var buffer bytes.Buffer
+	input := []byte("\x1b[32mgreen\x1b[0m and \x1b[1;31mbold red\x1b[0m\n")
+	n, err := NewColorStripper(&buffer).Write(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(input) {
+		t.Errorf("Write() returned %d, want %d", n, len(input))
+	}
+	if got := buffer.String(); got != "green and bold red\n" {
+		t.Errorf("stripped output is %q", got)
+	}
+}
+
+func TestRotatingFileWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "watchtower.log")
+
+	w, err := NewRotatingFileWriter(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the current file and one previous file are kept
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path, "third\n"},
+		{path + ".1", "second\n"},
+	}
+	for _, test := range tests {
+		contents, err := ioutil.ReadFile(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(contents) != test.want {
+			t.Errorf("%s contains %q, want %q", filepath.Base(test.path), contents, test.want)
+		}
+	}
+
+	// Reopening appends to the existing file
+	w, err = NewRotatingFileWriter(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("fourth\n")); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(contents) != "third\nfourth\n" {
+		t.Errorf("reopened file contains %q", contents)
+	}
+}
+
+func TestNewOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name         string
+		path         string
+		logToConsole bool
+		wantConsole  string
+		wantFile     string
+	}{
+		{"console only", "", true, "\x1b[32mok\x1b[0m\n", ""},
+		{"file only", filepath.Join(dir, "file-only.log"), false, "", "ok\n"},
+		{"console and file", filepath.Join(dir, "both.log"), true, "\x1b[32mok\x1b[0m\n", "ok\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var console bytes.Buffer
+			output, err := NewOutput(&console, test.path, 1, test.logToConsole)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := output.Write([]byte("\x1b[32mok\x1b[0m\n")); err != nil {
+				t.Fatal(err)
+			}
+			if console.String() != test.wantConsole {
+				t.Errorf("console output is %q, want %q", console.String(), test.wantConsole)
+			}
+			if test.path == "" {
+				return
+			}
+			contents, err := ioutil.ReadFile(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(contents) != test.wantFile {
+				t.Errorf("file output is %q, want %q", contents, test.wantFile)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package multicall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The parts of the Multicall3 ABI used to aggregate calls
+const multicallAbi string = `[
+	{"inputs":[{"internalType":"bool","name":"requireSuccess","type":"bool"},{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call[]","name":"calls","type":"tuple[]"}],"name":"tryAggregate","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}
+]`
+
+// A read-only contract call to run as part of a multicall
+type Call struct {
+	Contract *rocketpool.Contract
+	Method   string
+	Args     []interface{}
+	Output   interface{}
+}
+
+// A call as encoded for Multicall3
+type aggregateCall struct {
+	Target   common.Address
+	CallData []byte
+}
+
+// The result of a call as returned by Multicall3
+type aggregateResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Runs several read-only contract calls in a single eth_call through a Multicall3 contract
+type MultiCaller struct {
+	contract *bind.BoundContract
+}
+
+// Create a new multicaller for the Multicall3 contract at the given address
+func NewMultiCaller(client rocketpool.ExecutionClient, address common.Address) (*MultiCaller, error) {
+	parsed, err := abi.JSON(strings.NewReader(multicallAbi))
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding Multicall3 ABI: %w", err)
+	}
+	return &MultiCaller{
+		contract: bind.NewBoundContract(address, parsed, client, client, client),
+	}, nil
+}
+
+// Run the calls in a single eth_call and unpack each result into its call's output.
+// Returns the error of each call (nil if it succeeded), or an error if the calls couldn't be run at all.
+func (mc *MultiCaller) Execute(calls []Call, opts *bind.CallOpts) ([]error, error) {
+
+	// Encode the calls
+	aggregateCalls := make([]aggregateCall, len(calls))
+	for i, call := range calls {
+		callData, err := call.Contract.ABI.Pack(call.Method, call.Args...)
+		if err != nil {
+			return nil, fmt.Errorf("Could not encode call to %s on %s: %w", call.Method, call.Contract.Address.Hex(), err)
+		}
+		aggregateCalls[i] = aggregateCall{
+			Target:   *call.Contract.Address,
+			CallData: callData,
+		}
+	}
+
+	// Run them, allowing individual calls to fail
+	results := new([]aggregateResult)
+	if err := mc.contract.Call(opts, &[]interface{}{results}, "tryAggregate", false, aggregateCalls); err != nil {
+		return nil, fmt.Errorf("Could not run multicall: %w", err)
+	}
+	if len(*results) != len(calls) {
+		return nil, fmt.Errorf("Multicall returned %d results for %d calls", len(*results), len(calls))
+	}
+
+	// Decode the results
+	callErrs := make([]error, len(calls))
+	for i, result := range *results {
+		call := calls[i]
+		if !result.Success {
+			callErrs[i] = fmt.Errorf("Call to %s on %s reverted", call.Method, call.Contract.Address.Hex())
+			continue
+		}
+		if err := call.Contract.ABI.UnpackIntoInterface(call.Output, call.Method, result.ReturnData); err != nil {
+			callErrs[i] = fmt.Errorf("Could not decode result of %s on %s: %w", call.Method, call.Contract.Address.Hex(), err)
+		}
+	}
+	return callErrs, nil
+
+}
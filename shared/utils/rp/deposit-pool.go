@@ -0,0 +1,36 @@
+package rp
+
+import (
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The amount of user ETH a half or full deposit minipool is assigned from the deposit pool
+const depositPoolAssignmentEth float64 = 16.0
+
+// Get the number of half and full deposits a deposit pool balance could assign in one assignment, given the queue
+// lengths and the maximum number of assignments. Half deposits are assigned before full deposits, and each takes 16 ETH.
+func GetAssignableDeposits(balance *big.Int, halfQueueLength uint64, fullQueueLength uint64, maxAssignments uint64) (uint64, uint64) {
+
+	// Get the number of assignments the balance covers
+	available := uint64(0)
+	if balance != nil && balance.Sign() > 0 {
+		available = big.NewInt(0).Quo(balance, eth.EthToWei(depositPoolAssignmentEth)).Uint64()
+	}
+	if available > maxAssignments {
+		available = maxAssignments
+	}
+
+	// Assign the half deposits first, then the full deposits
+	half := halfQueueLength
+	if half > available {
+		half = available
+	}
+	full := fullQueueLength
+	if full > available-half {
+		full = available - half
+	}
+	return half, full
+
+}
@@ -0,0 +1,40 @@
+package rp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+func TestGetAssignableDeposits(t *testing.T) {
+	tests := []struct {
+		name            string
+		balance         *big.Int
+		halfQueueLength uint64
+		fullQueueLength uint64
+		maxAssignments  uint64
+		wantHalf        uint64
+		wantFull        uint64
+	}{
+		{"covers every deposit", eth.EthToWei(160), 2, 3, 10, 2, 3},
+		{"half deposits first", eth.EthToWei(48), 2, 3, 10, 2, 1},
+		{"only half deposits", eth.EthToWei(32), 5, 3, 10, 2, 0},
+		{"partial assignment rounded down", eth.EthToWei(47.9), 5, 3, 10, 2, 0},
+		{"capped by max assignments", eth.EthToWei(320), 2, 10, 4, 2, 2},
+		{"less than one assignment", eth.EthToWei(15), 2, 3, 10, 0, 0},
+		{"no balance", big.NewInt(0), 2, 3, 10, 0, 0},
+		{"negative balance", eth.EthToWei(-32), 2, 3, 10, 0, 0},
+		{"nil balance", nil, 2, 3, 10, 0, 0},
+		{"empty queue", eth.EthToWei(160), 0, 0, 10, 0, 0},
+		{"no assignments allowed", eth.EthToWei(160), 2, 3, 0, 0, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			half, full := GetAssignableDeposits(test.balance, test.halfQueueLength, test.fullQueueLength, test.maxAssignments)
+			if half != test.wantHalf || full != test.wantFull {
+				t.Errorf("GetAssignableDeposits() = (%d, %d), want (%d, %d)", half, full, test.wantHalf, test.wantFull)
+			}
+		})
+	}
+}
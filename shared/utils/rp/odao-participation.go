@@ -0,0 +1,169 @@
+package rp
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"golang.org/x/sync/errgroup"
+)
+
+// The number of recent checkpoints participation is measured over
+const ParticipationWindow uint64 = 10
+
+// The participation rates of the Oracle DAO members for each submission type, keyed by member address.
+// Members that weren't eligible for any of the recent checkpoints are left out.
+type OdaoParticipation struct {
+	Prices   map[string]float64
+	Balances map[string]float64
+}
+
+// Whether a member submitted for a checkpoint they were eligible for
+type checkpointParticipation struct {
+	Eligible  bool
+	Submitted bool
+}
+
+// Get the RocketStorage key that records whether a node has submitted prices for a block
+func SubmittedPricesStorageKey(nodeAddress common.Address, blockNumber uint64) common.Hash {
+	blockNumberBuf := make([]byte, 32)
+	big.NewInt(0).SetUint64(blockNumber).FillBytes(blockNumberBuf)
+	return crypto.Keccak256Hash([]byte("network.prices.submitted.node"), nodeAddress.Bytes(), blockNumberBuf)
+}
+
+// Get the RocketStorage key that records whether a node has submitted balances for a block
+func SubmittedBalancesStorageKey(nodeAddress common.Address, blockNumber uint64) common.Hash {
+	blockNumberBuf := make([]byte, 32)
+	big.NewInt(0).SetUint64(blockNumber).FillBytes(blockNumberBuf)
+	return crypto.Keccak256Hash([]byte("network.balances.submitted.node"), nodeAddress.Bytes(), blockNumberBuf)
+}
+
+// Get the participation of the given Oracle DAO members over the checkpoints leading up to the latest prices
+// and balances blocks
+func GetOdaoParticipation(rp *rocketpool.RocketPool, members []common.Address, joinedTimes []uint64, pricesBlock uint64, balancesBlock uint64) (*OdaoParticipation, error) {
+
+	// Get the submission frequencies
+	pricesFrequency, err := protocol.GetSubmitPricesFrequency(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting prices submission frequency: %w", err)
+	}
+	balancesFrequency, err := protocol.GetSubmitBalancesFrequency(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting balances submission frequency: %w", err)
+	}
+
+	// Get the participation for each type
+	pricesRates, err := getParticipationRates(rp, members, joinedTimes, getRecentCheckpoints(pricesBlock, pricesFrequency, ParticipationWindow), SubmittedPricesStorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting prices participation: %w", err)
+	}
+	balancesRates, err := getParticipationRates(rp, members, joinedTimes, getRecentCheckpoints(balancesBlock, balancesFrequency, ParticipationWindow), SubmittedBalancesStorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting balances participation: %w", err)
+	}
+
+	return &OdaoParticipation{
+		Prices:   pricesRates,
+		Balances: balancesRates,
+	}, nil
+
+}
+
+// Get the fraction of eligible checkpoints a member submitted for, and whether they were eligible for any
+func getParticipationRate(checkpoints []checkpointParticipation) (float64, bool) {
+	eligible := 0
+	submitted := 0
+	for _, checkpoint := range checkpoints {
+		if !checkpoint.Eligible {
+			continue
+		}
+		eligible++
+		if checkpoint.Submitted {
+			submitted++
+		}
+	}
+	if eligible == 0 {
+		return 0, false
+	}
+	return float64(submitted) / float64(eligible), true
+}
+
+// Get the most recent consensus checkpoints, newest first
+func getRecentCheckpoints(latestBlock uint64, frequency uint64, count uint64) []uint64 {
+	checkpoints := []uint64{}
+	if frequency == 0 {
+		return checkpoints
+	}
+	for block := latestBlock; block > 0 && uint64(len(checkpoints)) < count; block -= frequency {
+		checkpoints = append(checkpoints, block)
+		if block < frequency {
+			break
+		}
+	}
+	return checkpoints
+}
+
+// Get the participation rate of each member over a set of checkpoints, keyed by member address.
+// Members that joined after all of the checkpoints are left out.
+func getParticipationRates(rp *rocketpool.RocketPool, members []common.Address, joinedTimes []uint64, checkpoints []uint64, getStorageKey func(common.Address, uint64) common.Hash) (map[string]float64, error) {
+
+	// Get the time of each checkpoint
+	checkpointTimes := make([]uint64, len(checkpoints))
+	var wg errgroup.Group
+	for i, block := range checkpoints {
+		i, block := i, block
+		wg.Go(func() error {
+			header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(block))
+			if err != nil {
+				return fmt.Errorf("Error getting header for block %d: %w", block, err)
+			}
+			checkpointTimes[i] = header.Time
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Check which members submitted for each checkpoint
+	participation := make([][]checkpointParticipation, len(members))
+	for i := range members {
+		participation[i] = make([]checkpointParticipation, len(checkpoints))
+	}
+	for i, member := range members {
+		for j, block := range checkpoints {
+			if checkpointTimes[j] < joinedTimes[i] {
+				continue
+			}
+			i, j, member, block := i, j, member, block
+			wg.Go(func() error {
+				submitted, err := rp.RocketStorage.GetBool(nil, getStorageKey(member, block))
+				if err != nil {
+					return fmt.Errorf("Error checking submission from member %s for block %d: %w", member.Hex(), block, err)
+				}
+				participation[i][j] = checkpointParticipation{
+					Eligible:  true,
+					Submitted: submitted,
+				}
+				return nil
+			})
+		}
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the rates
+	rates := map[string]float64{}
+	for i, member := range members {
+		if rate, eligible := getParticipationRate(participation[i]); eligible {
+			rates[member.Hex()] = rate
+		}
+	}
+	return rates, nil
+
+}
@@ -0,0 +1,90 @@
+package rp
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestGetParticipationRate(t *testing.T) {
+	tests := []struct {
+		name         string
+		checkpoints  []checkpointParticipation
+		wantRate     float64
+		wantEligible bool
+	}{
+		{"no checkpoints", nil, 0, false},
+		{"never eligible", []checkpointParticipation{{Eligible: false}, {Eligible: false, Submitted: true}}, 0, false},
+		{"submitted for all", []checkpointParticipation{{true, true}, {true, true}}, 1, true},
+		{"missed some", []checkpointParticipation{{true, true}, {true, false}, {true, true}, {true, false}}, 0.5, true},
+		{"ineligible checkpoints are ignored", []checkpointParticipation{{false, false}, {true, true}, {false, false}}, 1, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rate, eligible := getParticipationRate(test.checkpoints)
+			if rate != test.wantRate || eligible != test.wantEligible {
+				t.Errorf("getParticipationRate() = (%g, %t), want (%g, %t)", rate, eligible, test.wantRate, test.wantEligible)
+			}
+		})
+	}
+}
+
+func TestGetRecentCheckpoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		latestBlock uint64
+		frequency   uint64
+		count       uint64
+		want        []uint64
+	}{
+		{"newest first", 500, 100, 3, []uint64{500, 400, 300}},
+		{"stops at the start of the chain", 200, 100, 5, []uint64{200, 100}},
+		{"no consensus yet", 0, 100, 5, []uint64{}},
+		{"zero frequency", 500, 0, 5, []uint64{}},
+		{"zero count", 500, 100, 0, []uint64{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := getRecentCheckpoints(test.latestBlock, test.frequency, test.count)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("getRecentCheckpoints(%d, %d, %d) = %v, want %v", test.latestBlock, test.frequency, test.count, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSubmittedStorageKeys(t *testing.T) {
+	nodeAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	otherAddress := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	tests := []struct {
+		name       string
+		prefix     string
+		storageKey func(common.Address, uint64) common.Hash
+	}{
+		{"prices", "network.prices.submitted.node", SubmittedPricesStorageKey},
+		{"balances", "network.balances.submitted.node", SubmittedBalancesStorageKey},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The key is keccak256(abi.encodePacked(prefix, nodeAddress, block))
+			packed := append([]byte(test.prefix), nodeAddress.Bytes()...)
+			packed = append(packed, common.LeftPadBytes(big.NewInt(15000000).Bytes(), 32)...)
+			want := crypto.Keccak256Hash(packed)
+			key := test.storageKey(nodeAddress, 15000000)
+			if key != want {
+				t.Errorf("storage key = %s, want %s", key.Hex(), want.Hex())
+			}
+
+			// Different nodes and blocks have different keys
+			if key == test.storageKey(otherAddress, 15000000) {
+				t.Error("got the same key for different nodes")
+			}
+			if key == test.storageKey(nodeAddress, 15000001) {
+				t.Error("got the same key for different blocks")
+			}
+		})
+	}
+}
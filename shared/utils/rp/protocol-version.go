@@ -0,0 +1,49 @@
+package rp
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rputils "github.com/rocket-pool/rocketpool-go/utils"
+)
+
+// The Rocket Pool protocol version this build's contract bindings were written for
+const SupportedProtocolVersion string = "1.1.0"
+
+// Returned when the deployed protocol doesn't match the version the contract bindings support
+type ProtocolVersionMismatchError struct {
+	OnChain   *version.Version
+	Supported *version.Version
+}
+
+func (e *ProtocolVersionMismatchError) Error() string {
+	if e.OnChain.GreaterThan(e.Supported) {
+		return fmt.Sprintf("the Rocket Pool contracts have been upgraded to v%s but this Smartnode only supports v%s; contract calls may revert until the Smartnode is updated", e.OnChain, e.Supported)
+	}
+	return fmt.Sprintf("the Rocket Pool contracts are at v%s but this Smartnode expects v%s; contract calls may revert", e.OnChain, e.Supported)
+}
+
+// Compare a deployed protocol version with a supported one, returning a mismatch error if they differ
+func CompareProtocolVersions(onChain *version.Version, supported *version.Version) error {
+	if onChain.Equal(supported) {
+		return nil
+	}
+	return &ProtocolVersionMismatchError{
+		OnChain:   onChain,
+		Supported: supported,
+	}
+}
+
+// Check the deployed protocol version against the one this build supports
+func CheckProtocolVersion(rp *rocketpool.RocketPool) error {
+	supported, err := version.NewSemver(SupportedProtocolVersion)
+	if err != nil {
+		return fmt.Errorf("Error parsing supported protocol version: %w", err)
+	}
+	onChain, err := rputils.GetCurrentVersion(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting the on-chain protocol version: %w", err)
+	}
+	return CompareProtocolVersions(onChain, supported)
+}
@@ -0,0 +1,42 @@
+package rp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestCompareProtocolVersions(t *testing.T) {
+	supported := version.Must(version.NewSemver("1.1.0"))
+	tests := []struct {
+		name         string
+		onChain      string
+		wantMismatch bool
+		wantMessage  string
+	}{
+		{"same version", "1.1.0", false, ""},
+		{"same version without a patch", "1.1", false, ""},
+		{"upgraded contracts", "1.2.0", true, "upgraded to v1.2.0"},
+		{"older contracts", "1.0.0", true, "are at v1.0.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CompareProtocolVersions(version.Must(version.NewSemver(test.onChain)), supported)
+			if !test.wantMismatch {
+				if err != nil {
+					t.Errorf("CompareProtocolVersions() returned an error: %s", err)
+				}
+				return
+			}
+			var mismatch *ProtocolVersionMismatchError
+			if !errors.As(err, &mismatch) {
+				t.Fatalf("CompareProtocolVersions() returned %v, want a mismatch error", err)
+			}
+			if !strings.Contains(err.Error(), test.wantMessage) {
+				t.Errorf("mismatch error %q doesn't contain %q", err, test.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package rp
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"golang.org/x/sync/errgroup"
+)
+
+type NodeWithdrawalInfo struct {
+	WithdrawalAddress              common.Address `json:"withdrawalAddress"`
+	PendingWithdrawalAddress       common.Address `json:"pendingWithdrawalAddress"`
+	HasPendingChange               bool           `json:"hasPendingChange"`
+	WithdrawalAddressIsNodeAddress bool           `json:"withdrawalAddressIsNodeAddress"`
+}
+
+func GetNodeWithdrawalInfo(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (*NodeWithdrawalInfo, error) {
+
+	var withdrawalAddress common.Address
+	var pendingWithdrawalAddress common.Address
+
+	// Sync
+	var wg errgroup.Group
+
+	// Get the withdrawal addresses
+	wg.Go(func() error {
+		var err error
+		withdrawalAddress, err = storage.GetNodeWithdrawalAddress(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		pendingWithdrawalAddress, err = storage.GetNodePendingWithdrawalAddress(rp, nodeAddress, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return getNodeWithdrawalInfo(nodeAddress, withdrawalAddress, pendingWithdrawalAddress), nil
+
+}
+
+// Compare a node's withdrawal addresses against its own address.
+// There is no pending change if the pending address is unset or is already the withdrawal address.
+func getNodeWithdrawalInfo(nodeAddress common.Address, withdrawalAddress common.Address, pendingWithdrawalAddress common.Address) *NodeWithdrawalInfo {
+	return &NodeWithdrawalInfo{
+		WithdrawalAddress:              withdrawalAddress,
+		PendingWithdrawalAddress:       pendingWithdrawalAddress,
+		HasPendingChange:               pendingWithdrawalAddress != (common.Address{}) && pendingWithdrawalAddress != withdrawalAddress,
+		WithdrawalAddressIsNodeAddress: withdrawalAddress == nodeAddress,
+	}
+}
@@ -0,0 +1,39 @@
+package rp
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestGetNodeWithdrawalInfo(t *testing.T) {
+	node := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	withdrawal := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	pending := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	tests := []struct {
+		name                  string
+		withdrawalAddress     common.Address
+		pendingAddress        common.Address
+		wantPendingChange     bool
+		wantIsNodeWithdrawing bool
+	}{
+		{"node address, no change", node, common.Address{}, false, true},
+		{"separate address, no change", withdrawal, common.Address{}, false, false},
+		{"change pending", node, pending, true, true},
+		{"pending address already confirmed", withdrawal, withdrawal, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := getNodeWithdrawalInfo(node, test.withdrawalAddress, test.pendingAddress)
+			if info.WithdrawalAddress != test.withdrawalAddress || info.PendingWithdrawalAddress != test.pendingAddress {
+				t.Errorf("info has addresses %s and %s, want %s and %s", info.WithdrawalAddress.Hex(), info.PendingWithdrawalAddress.Hex(), test.withdrawalAddress.Hex(), test.pendingAddress.Hex())
+			}
+			if info.HasPendingChange != test.wantPendingChange {
+				t.Errorf("HasPendingChange = %t, want %t", info.HasPendingChange, test.wantPendingChange)
+			}
+			if info.WithdrawalAddressIsNodeAddress != test.wantIsNodeWithdrawing {
+				t.Errorf("WithdrawalAddressIsNodeAddress = %t, want %t", info.WithdrawalAddressIsNodeAddress, test.wantIsNodeWithdrawing)
+			}
+		})
+	}
+}